@@ -0,0 +1,17 @@
+// Package api holds the specification of the service and the assets of its
+// documentation, embedded in the binary to be served wherever it runs
+package api
+
+import "embed"
+
+// Swagger
+// OpenAPI specification of the REST api, as written
+//
+//go:embed swagger.yml
+var Swagger []byte
+
+// Docs
+// Swagger UI page and its vendored assets (see swagger-ui/LICENSE)
+//
+//go:embed index.html swagger-ui
+var Docs embed.FS
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	apikeysUsecase "github.com/japhy-tech/backend-test/internal/usecases/apikeys"
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+// runCommand
+// Run a management command instead of the service:
+//
+//	apikeys create -name <name> -scopes <read,write,admin>
+//	apikeys list
+//	apikeys revoke -name <name>
+func runCommand(ctx context.Context, datastore gateways.IDatastore, args []string, out io.Writer) error {
+	switch args[0] {
+	case "apikeys":
+		return runAPIKeysCommand(ctx, datastore, args[1:], out)
+	default:
+		return fmt.Errorf("%w %s", ErrUnknownCommand, args[0])
+	}
+}
+
+func runAPIKeysCommand(ctx context.Context, datastore gateways.IDatastore, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: apikeys expects one of create, list, revoke", ErrUnknownCommand)
+	}
+
+	flags := flag.NewFlagSet("apikeys "+args[0], flag.ContinueOnError)
+	flags.SetOutput(out)
+	switch args[0] {
+	case "create":
+		name := flags.String("name", "", "unique name of the key, e.g. who it is given to")
+		scopes := flags.String("scopes", auth.ScopeRead.String(), "comma separated scopes among read, write, admin")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		res, err := usecases.New(&apikeysUsecase.CreateOne{}, datastore).Handle(ctx, apikeysUsecase.CreateOneOpts{
+			Name:   *name,
+			Scopes: strings.Split(*scopes, ","),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "api key %s created with scopes %s, it will not be shown again:\n%s\n", res.APIKey.Name(), scopesString(res.APIKey), res.Key)
+		return err
+	case "list":
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		res, err := usecases.New(&apikeysUsecase.List{}, datastore).Handle(ctx, struct{}{})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSCOPES\tCREATED AT\tREVOKED AT")
+		for _, val := range res {
+			revokedAt := "-"
+			if val.Revoked() {
+				revokedAt = val.RevokedAt().Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", val.Name(), scopesString(val), val.CreatedAt().Format(time.RFC3339), revokedAt)
+		}
+		return tw.Flush()
+	case "revoke":
+		name := flags.String("name", "", "name of the key to revoke")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if err := usecases.NewSimple(&apikeysUsecase.RevokeOneByName{}, datastore).Handle(ctx, *name); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(out, "api key %s revoked\n", *name)
+		return err
+	default:
+		return fmt.Errorf("%w apikeys %s", ErrUnknownCommand, args[0])
+	}
+}
+
+func scopesString(key *apikeys.APIKey) string {
+	return strings.Join(common.Map(key.Scopes(), func(val auth.Scope) string { return val.String() }), ",")
+}
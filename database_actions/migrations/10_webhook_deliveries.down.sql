@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.webhook_deliveries;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS core.webhook_deliveries (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    webhook_id VARCHAR(36) NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_name VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    status enum('pending', 'succeeded', 'failed') NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME(6) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    delivered_at DATETIME(6) NULL DEFAULT NULL,
+
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_webhook_deliveries_event (webhook_id, event_id),
+    INDEX idx_webhook_deliveries_pending (status, next_attempt_at)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.api_keys;
\ No newline at end of file
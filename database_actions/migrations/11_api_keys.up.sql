@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS core.api_keys (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    revoked_at DATETIME(6) NULL DEFAULT NULL,
+
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_api_keys_name (name),
+    UNIQUE KEY uq_api_keys_key_hash (key_hash)
+);
@@ -0,0 +1 @@
+ALTER TABLE core.breeds DROP COLUMN updated_at;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE core.breeds ADD COLUMN updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.idempotency_keys;
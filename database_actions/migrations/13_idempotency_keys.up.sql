@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS core.idempotency_keys (
+    client VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status SMALLINT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    body MEDIUMBLOB NULL,
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+
+    PRIMARY KEY (client, idempotency_key),
+    KEY idx_idempotency_keys_expires_at (expires_at)
+);
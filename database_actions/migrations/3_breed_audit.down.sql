@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.breed_audit;
\ No newline at end of file
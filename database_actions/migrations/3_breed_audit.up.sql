@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS core.breed_audit (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    breed_name VARCHAR(255) NOT NULL,
+    operation enum('create', 'update', 'delete') NOT NULL,
+    usecase VARCHAR(255) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    before_snapshot JSON NULL,
+    after_snapshot JSON NULL,
+    created_at DATETIME(6) NOT NULL,
+
+    PRIMARY KEY (id),
+    INDEX idx_breed_audit_breed_name (breed_name)
+);
\ No newline at end of file
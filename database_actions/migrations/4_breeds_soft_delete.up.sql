@@ -0,0 +1 @@
+ALTER TABLE core.breeds ADD COLUMN deleted_at DATETIME(6) NULL DEFAULT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE core.breed_audit MODIFY COLUMN operation enum('create', 'update', 'delete') NOT NULL;
\ No newline at end of file
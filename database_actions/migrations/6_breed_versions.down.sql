@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.breed_versions;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS core.breed_versions (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    version INT NOT NULL,
+    species enum('dog', 'cat') NOT NULL,
+    pet_size enum('small', 'medium', 'tall') NOT NULL,
+    average_male_adult_weight INT DEFAULT 0,
+    average_female_adult_weight INT DEFAULT 0,
+    valid_from DATETIME(6) NOT NULL,
+    valid_to DATETIME(6) NULL DEFAULT NULL,
+
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_breed_versions_name_version (name, version),
+    INDEX idx_breed_versions_validity (valid_from, valid_to)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM core.breed_versions;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- The history of a breed starts at its last audited write. Breeds written before the audit was
+-- recorded have no such date: their history starts at the time of the migration
+INSERT INTO core.breed_versions (name, version, species, pet_size, average_male_adult_weight, average_female_adult_weight, valid_from)
+SELECT b.name, 1, b.species, b.pet_size, b.average_male_adult_weight, b.average_female_adult_weight,
+    COALESCE((SELECT MAX(a.created_at) FROM core.breed_audit a WHERE a.breed_name = b.name), UTC_TIMESTAMP())
+FROM core.breeds b
+WHERE b.deleted_at IS NULL;
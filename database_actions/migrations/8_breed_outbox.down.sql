@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.breed_outbox;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS core.breed_outbox (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    event_name VARCHAR(64) NOT NULL,
+    breed_name VARCHAR(255) NOT NULL,
+    payload JSON NOT NULL,
+    occurred_at DATETIME(6) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(6) NOT NULL,
+    last_error TEXT NULL,
+    delivered_at DATETIME(6) NULL DEFAULT NULL,
+
+    PRIMARY KEY (id),
+    INDEX idx_breed_outbox_pending (delivered_at, next_attempt_at),
+    INDEX idx_breed_outbox_breed_name (breed_name)
+);
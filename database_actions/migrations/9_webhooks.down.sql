@@ -0,0 +1 @@
+DROP TABLE IF EXISTS core.webhooks;
\ No newline at end of file
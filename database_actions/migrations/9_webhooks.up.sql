@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS core.webhooks (
+    id VARCHAR(36) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    disabled_at DATETIME(6) NULL DEFAULT NULL,
+    created_at DATETIME(6) NOT NULL,
+
+    PRIMARY KEY (id)
+);
@@ -1,20 +1,30 @@
 package database_actions
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+const migrationsURL = "file://database_actions/migrations"
+
 var driver database.Driver
 
+var (
+	ErrDirtyMigration    = errors.New("the last migration failed")
+	ErrOutdatedMigration = errors.New("the database is not migrated to the latest version")
+)
+
 // InitMigrator initiates values essential for migrations
 func InitMigrator(dsnMigrate string) error {
 	var err error
@@ -35,7 +45,7 @@ func InitMigrator(dsnMigrate string) error {
 // Default 'steps' as 0 (runs all migrations)
 func RunMigrate(migrationType string, steps int) (string, error) {
 	m, err := migrate.NewWithDatabaseInstance(
-		"file://database_actions/migrations",
+		migrationsURL,
 		"mysql",
 		driver,
 	)
@@ -81,3 +91,49 @@ func migrationsSuccessMessage(migrationType string, steps int) string {
 
 	return msg + " " + strings.Trim(strconv.Itoa(steps), "-") + " " + migrationType + " migrations"
 }
+
+// CheckVersion verifies that the last migration applied is the latest one and succeeded
+func CheckVersion(_ context.Context) error {
+	if driver == nil {
+		return errors.New("migrator is not initiated")
+	}
+	current, dirty, err := driver.Version()
+	if err != nil {
+		return fmt.Errorf("error while reading migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w: version %d", ErrDirtyMigration, current)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		return err
+	}
+	if current != int(latest) {
+		return fmt.Errorf("%w: version %d, latest %d", ErrOutdatedMigration, current, latest)
+	}
+	return nil
+}
+
+func latestVersion() (uint, error) {
+	src, err := source.Open(migrationsURL)
+	if err != nil {
+		return 0, fmt.Errorf("error while opening migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("error while reading migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error while reading migrations: %w", err)
+		}
+		version = next
+	}
+}
@@ -0,0 +1,194 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/jwtauth"
+	"github.com/japhy-tech/backend-test/internal/policy"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	apikeysUsecase "github.com/japhy-tech/backend-test/internal/usecases/apikeys"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newAuthHandler(logger *charmLog.Logger, datastore gateways.IDatastore, issuer *testutils.Issuer, opts ...api.ServerOption) http.Handler {
+	verifier := jwtauth.NewVerifier(issuer.KeySet(), jwtauth.WithIssuer(testutils.IssuerURL), jwtauth.WithAudience(testutils.IssuerAudience))
+	return api.HandlerWithOptions(api.New(logger, datastore, opts...), api.GorillaServerOptions{
+		BaseURL:    "/v1",
+		BaseRouter: mux.NewRouter(),
+		Middlewares: []api.MiddlewareFunc{api.AuthMiddleware(
+			api.APIKeyAuthenticator(datastore.APIKeys()),
+			api.BearerAuthenticator(verifier),
+		)},
+	})
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		issuer, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		var (
+			ta     = tdhttp.NewTestAPI(t, newAuthHandler(logger, datastore, issuer))
+			keys   = map[string]string{}
+			create = usecases.New(&apikeysUsecase.CreateOne{}, datastore)
+			breed  = api.Breed{Name: "bengal", Species: api.Cat, PetSize: api.Medium, AverageFemaleAdultWeight: common.ToPointer(4000), AverageMaleAdultWeight: common.ToPointer(5000)}
+		)
+		for _, scope := range []string{"read", "write", "admin"} {
+			res, err := create.Handle(ctx, apikeysUsecase.CreateOneOpts{Name: scope, Scopes: []string{scope}})
+			require.CmpNoError(err)
+			keys[scope] = res.Key
+		}
+
+		ta.Name("invalid case -- missing credentials").Get("/v1/breeds").
+			CmpStatus(http.StatusUnauthorized).
+			CmpJSONBody(td.JSON(`{"message": "unauthenticated error: credentials are required"}`))
+		ta.Name("invalid case -- unknown key").Get("/v1/breeds", api.APIKeyHeader, "bk_unknown").
+			CmpStatus(http.StatusUnauthorized).
+			CmpJSONBody(td.JSON(`{"message": "unauthenticated error: invalid api key"}`))
+
+		ta.Name("valid case -- read").Get("/v1/breeds", api.APIKeyHeader, keys["read"]).
+			CmpStatus(http.StatusOK)
+		ta.Name("invalid case -- read cannot write").PostJSON("/v1/breeds", breed, api.APIKeyHeader, keys["read"]).
+			CmpStatus(http.StatusForbidden).
+			CmpJSONBody(td.JSON(`{"message": "forbidden error: <CREATE BREED> requires the breeds:write role"}`))
+		ta.Name("valid case -- write").PostJSON("/v1/breeds", breed, api.APIKeyHeader, keys["write"]).
+			CmpStatus(http.StatusCreated)
+		ta.Name("valid case -- write can read").Get("/v1/breeds/name/bengal", api.APIKeyHeader, keys["write"]).
+			CmpStatus(http.StatusOK)
+		ta.Name("invalid case -- write cannot delete").Delete("/v1/breeds/name/bengal", nil, api.APIKeyHeader, keys["write"]).
+			CmpStatus(http.StatusForbidden)
+		ta.Name("invalid case -- read cannot list deleted").Get("/v1/breeds?include_deleted=true", api.APIKeyHeader, keys["read"]).
+			CmpStatus(http.StatusForbidden).
+			CmpJSONBody(td.JSON(`{"message": "forbidden error: <LIST BREED> with include_deleted requires the breeds:admin role"}`))
+		ta.Name("valid case -- admin lists deleted").Get("/v1/breeds?include_deleted=true", api.APIKeyHeader, keys["admin"]).
+			CmpStatus(http.StatusOK)
+		ta.Name("invalid case -- webhooks are for admins").Get("/v1/webhooks", api.APIKeyHeader, keys["write"]).
+			CmpStatus(http.StatusForbidden)
+		ta.Name("valid case -- admin").Get("/v1/webhooks", api.APIKeyHeader, keys["admin"]).
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- admin can delete").Delete("/v1/breeds/name/bengal", nil, api.APIKeyHeader, keys["admin"]).
+			CmpStatus(http.StatusNoContent)
+
+		require.CmpNoError(usecases.NewSimple(&apikeysUsecase.RevokeOneByName{}, datastore).Handle(ctx, "admin"))
+		ta.Name("invalid case -- revoked key").Get("/v1/breeds", api.APIKeyHeader, keys["admin"]).
+			CmpStatus(http.StatusUnauthorized).
+			CmpJSONBody(td.JSON(`{"message": "unauthenticated error: api key is revoked"}`))
+	})
+}
+
+func TestAuthMiddleware_Bearer(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		issuer, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		other, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		var (
+			ta     = tdhttp.NewTestAPI(t, newAuthHandler(logger, datastore, issuer))
+			bearer = func(token string, err error) string {
+				require.CmpNoError(err)
+				return "Bearer " + token
+			}
+			breed = api.Breed{Name: "bengal", Species: api.Cat, PetSize: api.Medium, AverageFemaleAdultWeight: common.ToPointer(4000), AverageMaleAdultWeight: common.ToPointer(5000)}
+		)
+
+		ta.Name("invalid case -- malformed token").Get("/v1/breeds", "Authorization", "Bearer token").
+			CmpStatus(http.StatusUnauthorized)
+		ta.Name("invalid case -- unknown signing key").Get("/v1/breeds", "Authorization", bearer(other.Token("alice", "breeds:read"))).
+			CmpStatus(http.StatusUnauthorized)
+		ta.Name("invalid case -- expired").Get("/v1/breeds", "Authorization", bearer(issuer.Sign(jwt.MapClaims{
+			"iss": testutils.IssuerURL, "aud": testutils.IssuerAudience, "sub": "alice", "exp": time.Now().Add(-time.Hour).Unix(), "roles": []string{"breeds:read"},
+		}))).
+			CmpStatus(http.StatusUnauthorized)
+		ta.Name("invalid case -- other audience").Get("/v1/breeds", "Authorization", bearer(issuer.Sign(jwt.MapClaims{
+			"iss": testutils.IssuerURL, "aud": "other", "sub": "alice", "exp": time.Now().Add(time.Hour).Unix(), "roles": []string{"breeds:read"},
+		}))).
+			CmpStatus(http.StatusUnauthorized)
+
+		ta.Name("valid case -- read").Get("/v1/breeds", "Authorization", bearer(issuer.Token("alice", "breeds:read"))).
+			CmpStatus(http.StatusOK)
+		ta.Name("invalid case -- no role").PostJSON("/v1/breeds", breed, "Authorization", bearer(issuer.Token("alice", "offline_access"))).
+			CmpStatus(http.StatusForbidden)
+		ta.Name("valid case -- write").PostJSON("/v1/breeds", breed, "Authorization", bearer(issuer.Token("alice", "breeds:write"))).
+			CmpStatus(http.StatusCreated)
+		ta.Name("invalid case -- delete requires admin").Delete("/v1/breeds/name/bengal", nil, "Authorization", bearer(issuer.Token("alice", "breeds:write"))).
+			CmpStatus(http.StatusForbidden).
+			CmpJSONBody(td.JSON(`{"message": "forbidden error: <DELETE BREED> requires the breeds:admin role"}`))
+		ta.Name("invalid case -- breeds roles do not manage webhooks").Get("/v1/webhooks", "Authorization", bearer(issuer.Token("alice", "breeds:admin"))).
+			CmpStatus(http.StatusForbidden)
+		ta.Name("invalid case -- stream requires breeds:read").Get("/v1/breeds/events", "Authorization", bearer(issuer.Token("alice", "webhooks:admin"))).
+			CmpStatus(http.StatusForbidden)
+		ta.Name("valid case -- admin").Delete("/v1/breeds/name/bengal", nil, "Authorization", bearer(issuer.Token("alice", "breeds:admin"))).
+			CmpStatus(http.StatusNoContent)
+	})
+}
+
+func TestAuthMiddleware_Actor(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		issuer, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		token, err := issuer.Token("alice", "breeds:admin")
+		require.CmpNoError(err)
+		var (
+			r     = mux.NewRouter()
+			ta    = tdhttp.NewTestAPI(t, r)
+			breed = api.Breed{Name: "bengal", Species: api.Cat, PetSize: api.Medium, AverageFemaleAdultWeight: common.ToPointer(4000), AverageMaleAdultWeight: common.ToPointer(5000)}
+		)
+		r.Use(api.RequestContextMiddleware)
+		r.PathPrefix("/").Handler(newAuthHandler(logger, datastore, issuer))
+
+		ta.PostJSON("/v1/breeds", breed, http.Header{"Authorization": {"Bearer " + token}, api.ActorHeader: {"back_office"}}).
+			CmpStatus(http.StatusCreated)
+
+		ta.Name("valid case -- the principal is the actor").Get("/v1/breeds/name/bengal/history", "Authorization", "Bearer "+token).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[SuperMapOf({"operation": "create", "actor": "alice"})]`))
+	})
+}
+
+func TestServer_Policy(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		issuer, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		engine, err := policy.Parse([]byte(`
+rules:
+  - name: operators may edit cats only
+    usecases: [breed]
+    actions: [create, update]
+    without_roles: [breeds:admin]
+    input:
+      species:
+        not_in: [cat]
+`))
+		require.CmpNoError(err)
+		var (
+			ta    = tdhttp.NewTestAPI(t, newAuthHandler(logger, datastore, issuer, api.WithUsecaseOptions(usecases.WithInterceptors(append(usecases.DefaultInterceptors(), usecases.PolicyEnforcement(engine))...))))
+			token = func(roles ...string) string {
+				res, err := issuer.Token("alice", roles...)
+				require.CmpNoError(err)
+				return "Bearer " + res
+			}
+			cat = api.Breed{Name: "bengal", Species: api.Cat, PetSize: api.Medium, AverageFemaleAdultWeight: common.ToPointer(4000), AverageMaleAdultWeight: common.ToPointer(5000)}
+			dog = api.Breed{Name: "beagle", Species: api.Dog, PetSize: api.Medium, AverageFemaleAdultWeight: common.ToPointer(9000), AverageMaleAdultWeight: common.ToPointer(10000)}
+		)
+
+		ta.Name("valid case -- operator creates a cat").PostJSON("/v1/breeds", cat, "Authorization", token("breeds:write")).
+			CmpStatus(http.StatusCreated)
+		ta.Name("invalid case -- operator creates a dog").PostJSON("/v1/breeds", dog, "Authorization", token("breeds:write")).
+			CmpStatus(http.StatusForbidden).
+			CmpJSONBody(td.JSON(`{"message": "forbidden error: <CREATE BREED> denied by the policy \"operators may edit cats only\""}`))
+		ta.Name("valid case -- admin creates a dog").PostJSON("/v1/breeds", dog, "Authorization", token("breeds:admin")).
+			CmpStatus(http.StatusCreated)
+		ta.Name("invalid case -- operator turns a cat into a dog").PutJSON("/v1/breeds/name/bengal", dog, "Authorization", token("breeds:write")).
+			CmpStatus(http.StatusForbidden)
+	})
+}
@@ -1,15 +1,47 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
+
+	"github.com/japhy-tech/backend-test/internal/encoders"
+)
+
+const (
+	AcceptHeader      = "Accept"
+	ContentTypeHeader = "Content-Type"
+	VaryHeader        = "Vary"
 )
 
 func SendJSON[T any](w http.ResponseWriter, val T, status int) error {
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(ContentTypeHeader, "application/json")
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(val); err != nil {
 		return err
 	}
 	return nil
 }
+
+// Send
+// Send the value with the encoder negotiated for the request. With validators, only
+// them are sent with a 304 when the client already holds the same representation.
+// The error is the one of the encoding, nothing being sent then
+func Send[T any](w http.ResponseWriter, r *http.Request, enc encoders.Encoder, val T, status int, validators *Validators) error {
+	var body bytes.Buffer
+	if err := enc.Encode(&body, val); err != nil {
+		return err
+	}
+
+	w.Header().Add(VaryHeader, AcceptHeader)
+	if validators != nil && setValidators(w, r, body.Bytes(), *validators) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set(ContentTypeHeader, enc.ContentType())
+	w.WriteHeader(status)
+	// The response is started, a failed write cannot be reported to the client
+	_, _ = w.Write(body.Bytes())
+	return nil
+}
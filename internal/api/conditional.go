@@ -0,0 +1,76 @@
+package api
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	ETagHeader            = "ETag"
+	LastModifiedHeader    = "Last-Modified"
+	CacheControlHeader    = "Cache-Control"
+	IfNoneMatchHeader     = "If-None-Match"
+	IfModifiedSinceHeader = "If-Modified-Since"
+
+	// DefaultCacheControl lets the clients store the responses as long as they
+	// revalidate them before each use
+	DefaultCacheControl = "no-cache"
+)
+
+// Validators
+// What the conditional requests on a response are checked against. The ETag is
+// computed from the content of the response
+type Validators struct {
+	// LastModified is the last time the resources of the response changed, left
+	// out of the response when zero
+	LastModified time.Time
+	CacheControl string
+}
+
+// setValidators
+// Send the validators of the encoded body, and whether the client already holds
+// the same representation
+func setValidators(w http.ResponseWriter, r *http.Request, body []byte, validators Validators) bool {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	h := w.Header()
+	h.Set(ETagHeader, etag)
+	if !validators.LastModified.IsZero() {
+		h.Set(LastModifiedHeader, validators.LastModified.UTC().Format(http.TimeFormat))
+	}
+	h.Set(CacheControlHeader, cmp.Or(validators.CacheControl, DefaultCacheControl))
+
+	return notModified(r, etag, validators.LastModified)
+}
+
+// notModified
+// Evaluate the preconditions of the request as RFC 9110 does for a GET: If-Modified-Since
+// is ignored when If-None-Match is sent
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get(IfNoneMatchHeader); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match uses the weak comparison
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims := r.Header.Get(IfModifiedSinceHeader)
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// Last-Modified is sent with a precision of one second
+	return !lastModified.Truncate(time.Second).After(since)
+}
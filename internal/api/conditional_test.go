@@ -0,0 +1,99 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore,
+				api.WithCacheControl("GET /breeds", "private, max-age=60"),
+			), api.GorillaServerOptions{
+				BaseURL:    "/v1",
+				BaseRouter: mux.NewRouter(),
+			})
+			ta = tdhttp.NewTestAPI(t, h)
+
+			etag, lastModified, listETag string
+		)
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "conditional",
+			Species:             values.Cat.String(),
+			PetSize:             values.Small.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().CreateOne(ctx, b)
+		require.CmpNoError(err)
+
+		ta.Name("valid case -- validators are sent").Get("/v1/breeds/name/conditional").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{api.CacheControlHeader: {api.DefaultCacheControl}}, td.MapEntries{
+				http.CanonicalHeaderKey(api.ETagHeader): td.Bag(td.Catch(&etag, td.Re(`^"[0-9a-f]{64}"$`))),
+				api.LastModifiedHeader:                  td.Bag(td.Catch(&lastModified, td.NotEmpty())),
+			}))
+
+		ta.Name("valid case -- same content same etag").Get("/v1/breeds/name/conditional").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.ETagHeader): {etag}}, nil))
+		ta.Name("valid case -- if-none-match").Get("/v1/breeds/name/conditional", http.Header{api.IfNoneMatchHeader: {`"other", W/` + etag}}).
+			CmpStatus(http.StatusNotModified).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.ETagHeader): {etag}}, nil)).
+			NoBody()
+		ta.Name("valid case -- if-modified-since").Get("/v1/breeds/name/conditional", http.Header{api.IfModifiedSinceHeader: {lastModified}}).
+			CmpStatus(http.StatusNotModified).
+			NoBody()
+		ta.Name("valid case -- if-none-match takes precedence").Get("/v1/breeds/name/conditional", http.Header{
+			api.IfNoneMatchHeader:     {`"other"`},
+			api.IfModifiedSinceHeader: {lastModified},
+		}).
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- modified since").Get("/v1/breeds/name/conditional", http.Header{
+			api.IfModifiedSinceHeader: {time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)},
+		}).
+			CmpStatus(http.StatusOK)
+
+		ta.Name("valid case -- list with configured cache control").Get("/v1/breeds").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{api.CacheControlHeader: {"private, max-age=60"}}, td.MapEntries{
+				http.CanonicalHeaderKey(api.ETagHeader): td.Bag(td.Catch(&listETag, td.NotEmpty())),
+				api.LastModifiedHeader:                  td.Len(1),
+			}))
+		ta.Get("/v1/breeds", http.Header{api.IfNoneMatchHeader: {listETag}}).
+			CmpStatus(http.StatusNotModified)
+
+		updated, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "conditional",
+			Species:             values.Dog.String(),
+			PetSize:             values.Small.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().UpdateOne(ctx, updated)
+		require.CmpNoError(err)
+
+		ta.Name("invalid case -- stale etag").Get("/v1/breeds/name/conditional", http.Header{api.IfNoneMatchHeader: {etag}}).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{}, td.MapEntries{http.CanonicalHeaderKey(api.ETagHeader): td.Not([]string{etag})}))
+		ta.Name("invalid case -- stale list etag").Get("/v1/breeds", http.Header{api.IfNoneMatchHeader: {listETag}}).
+			CmpStatus(http.StatusOK)
+	})
+}
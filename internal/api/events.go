@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+const (
+	// EventsHeartbeat keeps the idle streams open through proxies
+	EventsHeartbeat = 15 * time.Second
+	// EventsRetry is the reconnection delay advised to the clients, in milliseconds
+	EventsRetry = 3000
+)
+
+// Stream the breeds changes
+// (GET /breeds/events)
+func (s Server) StreamBreedEvents(w http.ResponseWriter, r *http.Request, params StreamBreedEventsParams) {
+	// Streaming the changes is listing the breeds as they go
+	if err := usecases.Authorize(r.Context(), usecases.UseCaseInfo{Action: usecases.ActionList, Name: usecases.BreedUsecase}); err != nil {
+		HandleErrorResponse(w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if s.broker == nil || !ok {
+		_ = SendJSON(w, Error{Message: "events stream is not available"}, http.StatusServiceUnavailable)
+		return
+	}
+
+	var (
+		species     string
+		lastEventID *int64
+	)
+	if params.Species != nil {
+		val, err := values.SpeciesFromString(string(*params.Species))
+		if err != nil {
+			HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies))
+			return
+		}
+		species = val.String()
+	}
+	if params.LastEventID != nil && *params.LastEventID != "" {
+		id, err := strconv.ParseInt(*params.LastEventID, 10, 64)
+		if err != nil {
+			HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrDomainValidation, errors.New("Last-Event-ID must be an event id")))
+			return
+		}
+		lastEventID = &id
+	}
+
+	replay, events, cancel, err := s.broker.Subscribe(r.Context(), lastEventID, species)
+	if errors.Is(err, sse.ErrClosed) {
+		_ = SendJSON(w, Error{Message: err.Error()}, http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		HandleErrorResponse(w, err)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", EventsRetry); err != nil {
+		return
+	}
+	for _, evt := range replay {
+		if err := evt.Write(w); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(EventsHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := evt.Write(w); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
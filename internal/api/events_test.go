@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// readEvent
+// Read the next event of a stream, skipping the comments and the retry field
+func readEvent(require *td.T, r *bufio.Reader) map[string]string {
+	res := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		require.CmpNoError(err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if len(res) > 0 {
+				return res
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") || strings.HasPrefix(line, "retry:") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		res[field] = value
+	}
+}
+
+func TestServer_StreamBreedEvents(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			broker = sse.NewBroker(datastore.Outbox(), logger)
+			r      = mux.NewRouter()
+			h      = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore, api.WithBroker(broker)), r, "/v1")
+			srv    = httptest.NewServer(h)
+			ta     = tdhttp.NewTestAPI(t, h)
+		)
+		defer srv.Close()
+		require.CmpNoError(broker.Poll(ctx))
+
+		ta.Name("invalid case -- invalid species").Get("/v1/breeds/events?species=bird").
+			CmpStatus(http.StatusBadRequest)
+		ta.Name("invalid case -- invalid Last-Event-ID").Get("/v1/breeds/events", "Last-Event-ID", "last").
+			CmpStatus(http.StatusBadRequest)
+		noBroker := tdhttp.NewTestAPI(t, api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), mux.NewRouter(), "/v1"))
+		noBroker.Name("invalid case -- no broker").Get("/v1/breeds/events").
+			CmpStatus(http.StatusServiceUnavailable)
+
+		for _, val := range []struct {
+			name    string
+			species values.Species
+		}{{"cat", values.Cat}, {"dog", values.Dog}} {
+			b, err := breeds.NewFactory(breeds.FactoryOpts{Name: val.name, Species: val.species.String(), PetSize: values.Tall.String()}).Instantiate()
+			require.CmpNoError(err)
+			_, err = datastore.Breeds().CreateOne(ctx, b)
+			require.CmpNoError(err)
+		}
+		require.CmpNoError(broker.Poll(ctx))
+
+		// Resume from the beginning, only the dogs
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/breeds/events?species=dog", nil)
+		require.CmpNoError(err)
+		req.Header.Set("Last-Event-ID", "0")
+		res, err := srv.Client().Do(req)
+		require.CmpNoError(err)
+		defer res.Body.Close()
+		require.Cmp(res.StatusCode, http.StatusOK)
+		require.Cmp(res.Header.Get("Content-Type"), "text/event-stream")
+
+		body := bufio.NewReader(res.Body)
+		evt := readEvent(require, body)
+		require.Cmp(evt["event"], breeds.EventBreedCreated)
+		var data map[string]any
+		require.CmpNoError(json.Unmarshal([]byte(evt["data"]), &data))
+		require.Cmp(data, td.SuperMapOf(map[string]any{"breed_name": "dog"}, nil))
+		dogID, err := strconv.ParseInt(evt["id"], 10, 64)
+		require.CmpNoError(err)
+
+		// Live events
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "cat"))
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "dog"))
+		require.CmpNoError(broker.Poll(ctx))
+		evt = readEvent(require, body)
+		require.Cmp(evt["event"], breeds.EventBreedDeleted)
+		require.Cmp(evt["id"], td.Code(func(id string) bool {
+			n, err := strconv.ParseInt(id, 10, 64)
+			return err == nil && n > dogID
+		}))
+
+		// Shutting down ends the stream
+		broker.Close()
+		_, err = io.ReadAll(body)
+		require.CmpNoError(err)
+	})
+}
@@ -4,13 +4,46 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gorilla/mux"
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for AuditEntryOperation.
+const (
+	Create  AuditEntryOperation = "create"
+	Delete  AuditEntryOperation = "delete"
+	Purge   AuditEntryOperation = "purge"
+	Restore AuditEntryOperation = "restore"
+	Update  AuditEntryOperation = "update"
+)
+
+// Defines values for BreedEvent.
+const (
+	BreedCreated  BreedEvent = "breed.created"
+	BreedDeleted  BreedEvent = "breed.deleted"
+	BreedPurged   BreedEvent = "breed.purged"
+	BreedRestored BreedEvent = "breed.restored"
+	BreedUpdated  BreedEvent = "breed.updated"
+)
+
 // Defines values for PetSize.
 const (
 	Medium PetSize = "medium"
@@ -24,6 +57,63 @@ const (
 	Dog Species = "dog"
 )
 
+// Defines values for WebhookDeliveryStatus.
+const (
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for ListBreedsParamsFields.
+const (
+	ListBreedsParamsFieldsAverageFemaleAdultWeight ListBreedsParamsFields = "average_female_adult_weight"
+	ListBreedsParamsFieldsAverageMaleAdultWeight   ListBreedsParamsFields = "average_male_adult_weight"
+	ListBreedsParamsFieldsDeletedAt                ListBreedsParamsFields = "deleted_at"
+	ListBreedsParamsFieldsName                     ListBreedsParamsFields = "name"
+	ListBreedsParamsFieldsPetSize                  ListBreedsParamsFields = "pet_size"
+	ListBreedsParamsFieldsSpecies                  ListBreedsParamsFields = "species"
+)
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Actor Identity of the caller, the authenticated principal or else the X-Actor header
+	Actor     string              `json:"actor"`
+	After     *Breeds             `json:"after,omitempty"`
+	Before    *Breeds             `json:"before,omitempty"`
+	BreedName string              `json:"breed_name"`
+	CreatedAt time.Time           `json:"created_at"`
+	Operation AuditEntryOperation `json:"operation"`
+
+	// RequestId Id of the request given by the X-Request-ID header or generated by the server
+	RequestId string `json:"request_id"`
+
+	// Usecase Usecase which performed the mutation. Empty when done outside of a usecase (csv synchronization)
+	Usecase string `json:"usecase"`
+}
+
+// AuditEntryOperation defines model for AuditEntry.Operation.
+type AuditEntryOperation string
+
+// BreedDiff defines model for BreedDiff.
+type BreedDiff struct {
+	Changes []FieldChange `json:"changes"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+}
+
+// BreedEvent defines model for BreedEvent.
+type BreedEvent string
+
+// BreedVersion defines model for BreedVersion.
+type BreedVersion struct {
+	Breed     Breeds    `json:"breed"`
+	ValidFrom time.Time `json:"valid_from"`
+
+	// ValidTo End of the validity of this version (excluded). Not set on the current version
+	ValidTo *time.Time `json:"valid_to,omitempty"`
+	Version int        `json:"version"`
+}
+
 // Breeds defines model for Breeds.
 type Breeds struct {
 	// AverageFemaleAdultWeight Average weight of the female adult in gramme
@@ -32,6 +122,9 @@ type Breeds struct {
 	// AverageMaleAdultWeight Average weight of the male adult in gramme
 	AverageMaleAdultWeight *int `json:"average_male_adult_weight,omitempty"`
 
+	// DeletedAt Date of the soft deletion. Only set on deleted breeds
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
 	// Name Name of the breed. Should be in snake case and should be unique
 	Name string `json:"name"`
 
@@ -42,15 +135,119 @@ type Breeds struct {
 
 // Error defines model for Error.
 type Error struct {
+	// Fields Violations of the specification, sent with the 400 of the requests not matching it
+	Fields  *[]FieldError `json:"fields,omitempty"`
+	Message string        `json:"message"`
+}
+
+// FieldChange defines model for FieldChange.
+type FieldChange struct {
+	Field string `json:"field"`
+
+	// From Value of the field in the first version
+	From interface{} `json:"from"`
+
+	// To Value of the field in the second version
+	To interface{} `json:"to"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Parameter or dotted path of the body property in error
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// GraphQLError defines model for GraphQLError.
+type GraphQLError struct {
+	Extensions *GraphQLError_Extensions `json:"extensions,omitempty"`
+	Message    string                   `json:"message"`
+	Path       *[]interface{}           `json:"path,omitempty"`
+}
+
+// GraphQLError_Extensions defines model for GraphQLError.Extensions.
+type GraphQLError_Extensions struct {
+	Code                 *string                `json:"code,omitempty"`
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// GraphQLRequest defines model for GraphQLRequest.
+type GraphQLRequest struct {
+	OperationName *string                 `json:"operationName,omitempty"`
+	Query         string                  `json:"query"`
+	Variables     *map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse defines model for GraphQLResponse.
+type GraphQLResponse struct {
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]GraphQLError         `json:"errors,omitempty"`
+}
+
+// PartialBreed Breed restricted to the fields requested, the name being always sent (see the Breeds schema)
+type PartialBreed struct {
+	AverageFemaleAdultWeight *int       `json:"average_female_adult_weight,omitempty"`
+	AverageMaleAdultWeight   *int       `json:"average_male_adult_weight,omitempty"`
+	DeletedAt                *time.Time `json:"deleted_at,omitempty"`
+	Name                     string     `json:"name"`
+
+	// PetSize size of the pet
+	PetSize *PetSize `json:"pet_size,omitempty"`
+	Species *Species `json:"species,omitempty"`
+}
+
 // PetSize size of the pet
 type PetSize string
 
 // Species defines model for Species.
 type Species string
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	ConsecutiveFailures *int       `json:"consecutive_failures,omitempty"`
+	CreatedAt           *time.Time `json:"created_at,omitempty"`
+
+	// DisabledAt Date of the automatic disabling. Only set on disabled webhooks
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+
+	// Enabled A webhook failing too many deliveries in a row is disabled
+	Enabled    *bool        `json:"enabled,omitempty"`
+	EventTypes []BreedEvent `json:"event_types"`
+	Id         *string      `json:"id,omitempty"`
+
+	// Secret Key of the signature of the deliveries. Every delivery is a POST of the event with the headers
+	// X-Event-Name, X-Event-ID, X-Webhook-Delivery, X-Webhook-Timestamp and
+	// X-Webhook-Signature: "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+	// Required on creation
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	EventId     int64      `json:"event_id"`
+	EventName   BreedEvent `json:"event_name"`
+	Id          int64      `json:"id"`
+	LastError   *string    `json:"last_error,omitempty"`
+
+	// LastStatusCode Http status of the last attempt. 0 when the webhook could not be reached
+	LastStatusCode int       `json:"last_status_code"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+
+	// Payload Event sent to the webhook
+	Payload map[string]interface{} `json:"payload"`
+	Status  WebhookDeliveryStatus  `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// AsOf defines model for AsOf.
+type AsOf = time.Time
+
 // AverageFemaleAdultWeight Average weight of the female adult in gramme
 type AverageFemaleAdultWeight = int
 
@@ -60,14 +257,38 @@ type AverageMaleAdultWeight = int
 // BreedName defines model for BreedName.
 type BreedName = string
 
+// Fields defines model for Fields.
+type Fields = []string
+
+// Include defines model for Include.
+type Include = []string
+
+// IncludeDeleted defines model for IncludeDeleted.
+type IncludeDeleted = bool
+
+// WebhookID defines model for WebhookID.
+type WebhookID = string
+
 // BadRequestError defines model for BadRequestError.
 type BadRequestError = Error
 
+// BreedHistory defines model for BreedHistory.
+type BreedHistory = []AuditEntry
+
 // BreedResponse defines model for BreedResponse.
 type BreedResponse = Breeds
 
+// BreedVersionsList defines model for BreedVersionsList.
+type BreedVersionsList = []BreedVersion
+
 // BreedsList defines model for BreedsList.
-type BreedsList = []Breeds
+type BreedsList = []PartialBreed
+
+// CacheableBreedResponse defines model for CacheableBreedResponse.
+type CacheableBreedResponse = Breeds
+
+// ForbiddenError defines model for ForbiddenError.
+type ForbiddenError = Error
 
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = Error
@@ -78,6 +299,27 @@ type ResourceAlreadyExistsError = Error
 // ResourceNotFoundError defines model for ResourceNotFoundError.
 type ResourceNotFoundError = Error
 
+// TooManyRequestsError defines model for TooManyRequestsError.
+type TooManyRequestsError = Error
+
+// UnauthorizedError defines model for UnauthorizedError.
+type UnauthorizedError = Error
+
+// UnprocessableEntityError defines model for UnprocessableEntityError.
+type UnprocessableEntityError = Error
+
+// WebhookDeliveriesList defines model for WebhookDeliveriesList.
+type WebhookDeliveriesList = []WebhookDelivery
+
+// WebhookDeliveryResponse defines model for WebhookDeliveryResponse.
+type WebhookDeliveryResponse = WebhookDelivery
+
+// WebhookResponse defines model for WebhookResponse.
+type WebhookResponse = Webhook
+
+// WebhooksList defines model for WebhooksList.
+type WebhooksList = []Webhook
+
 // Breed defines model for Breed.
 type Breed = Breeds
 
@@ -87,6 +329,40 @@ type ListBreedsParams struct {
 	AverageFemaleAdultWeight *AverageFemaleAdultWeight `form:"average_female_adult_weight,omitempty" json:"average_female_adult_weight,omitempty"`
 	AverageMaleAdultWeight   *AverageMaleAdultWeight   `form:"average_male_adult_weight,omitempty" json:"average_male_adult_weight,omitempty"`
 	PetSize                  *PetSize                  `form:"pet_size,omitempty" json:"pet_size,omitempty"`
+
+	// IncludeDeleted Include soft deleted breeds. Reserved to administrators
+	IncludeDeleted *IncludeDeleted `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// AsOf Read the breeds as they were at this date (RFC 3339) instead of the current ones
+	AsOf *AsOf `form:"as_of,omitempty" json:"as_of,omitempty"`
+
+	// Fields Comma separated attributes of the breeds to send, e.g. `name,species`, only them being read from the datastore.
+	// The name is always sent, all the attributes are when unset
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Include Comma separated relations to embed in the breeds. The breeds have no relation yet, every value is rejected
+	Include *Include `form:"include,omitempty" json:"include,omitempty"`
+}
+
+// ListBreedsParamsFields defines parameters for ListBreeds.
+type ListBreedsParamsFields string
+
+// StreamBreedEventsParams defines parameters for StreamBreedEvents.
+type StreamBreedEventsParams struct {
+	Species     *Species `form:"species,omitempty" json:"species,omitempty"`
+	LastEventID *string  `json:"Last-Event-ID,omitempty"`
+}
+
+// GetBreedByNameParams defines parameters for GetBreedByName.
+type GetBreedByNameParams struct {
+	// AsOf Read the breeds as they were at this date (RFC 3339) instead of the current ones
+	AsOf *AsOf `form:"as_of,omitempty" json:"as_of,omitempty"`
+}
+
+// DiffBreedVersionsByNameParams defines parameters for DiffBreedVersionsByName.
+type DiffBreedVersionsByNameParams struct {
+	From int `form:"from" json:"from"`
+	To   int `form:"to" json:"to"`
 }
 
 // CreateOneBreedJSONRequestBody defines body for CreateOneBreed for application/json ContentType.
@@ -95,6 +371,83 @@ type CreateOneBreedJSONRequestBody = Breeds
 // CreateOrUpdateBreedByNameJSONRequestBody defines body for CreateOrUpdateBreedByName for application/json ContentType.
 type CreateOrUpdateBreedByNameJSONRequestBody = Breeds
 
+// ExecuteGraphQLJSONRequestBody defines body for ExecuteGraphQL for application/json ContentType.
+type ExecuteGraphQLJSONRequestBody = GraphQLRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
+// UpdateWebhookByIDJSONRequestBody defines body for UpdateWebhookByID for application/json ContentType.
+type UpdateWebhookByIDJSONRequestBody = Webhook
+
+// Getter for additional properties for GraphQLError_Extensions. Returns the specified
+// element and whether it was found
+func (a GraphQLError_Extensions) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for GraphQLError_Extensions
+func (a *GraphQLError_Extensions) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for GraphQLError_Extensions to handle AdditionalProperties
+func (a *GraphQLError_Extensions) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["code"]; found {
+		err = json.Unmarshal(raw, &a.Code)
+		if err != nil {
+			return fmt.Errorf("error reading 'code': %w", err)
+		}
+		delete(object, "code")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for GraphQLError_Extensions to handle AdditionalProperties
+func (a GraphQLError_Extensions) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	if a.Code != nil {
+		object["code"], err = json.Marshal(a.Code)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'code': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List breeds
@@ -103,15 +456,54 @@ type ServerInterface interface {
 	// Create one breed
 	// (POST /breeds)
 	CreateOneBreed(w http.ResponseWriter, r *http.Request)
+	// Stream the breeds changes
+	// (GET /breeds/events)
+	StreamBreedEvents(w http.ResponseWriter, r *http.Request, params StreamBreedEventsParams)
 	// Delete a given breed by its name
 	// (DELETE /breeds/name/{breed_name})
 	DeleteBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
 	// Retrieve a given breed by its name
 	// (GET /breeds/name/{breed_name})
-	GetBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
+	GetBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName, params GetBreedByNameParams)
 	// Update or create one breed
 	// (PUT /breeds/name/{breed_name})
 	CreateOrUpdateBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
+	// Retrieve the history of a given breed
+	// (GET /breeds/name/{breed_name}/history)
+	GetBreedHistoryByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
+	// Restore a deleted breed
+	// (POST /breeds/name/{breed_name}/restore)
+	RestoreBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
+	// List the versions of a given breed
+	// (GET /breeds/name/{breed_name}/versions)
+	ListBreedVersionsByName(w http.ResponseWriter, r *http.Request, breedName BreedName)
+	// Compare two versions of a given breed
+	// (GET /breeds/name/{breed_name}/versions/diff)
+	DiffBreedVersionsByName(w http.ResponseWriter, r *http.Request, breedName BreedName, params DiffBreedVersionsByNameParams)
+	// Execute a GraphQL operation
+	// (POST /graphql)
+	ExecuteGraphQL(w http.ResponseWriter, r *http.Request)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Create one webhook
+	// (POST /webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete a given webhook
+	// (DELETE /webhooks/{webhook_id})
+	DeleteWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// Retrieve a given webhook by its id
+	// (GET /webhooks/{webhook_id})
+	GetWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// Update a given webhook
+	// (PUT /webhooks/{webhook_id})
+	UpdateWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// List the deliveries of a given webhook
+	// (GET /webhooks/{webhook_id}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// Replay a delivery
+	// (POST /webhooks/{webhook_id}/deliveries/{delivery_id}/replay)
+	ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookID, deliveryId int64)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -129,6 +521,10 @@ func (siw *ServerInterfaceWrapper) ListBreeds(w http.ResponseWriter, r *http.Req
 
 	var err error
 
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListBreedsParams
 
@@ -164,6 +560,38 @@ func (siw *ServerInterfaceWrapper) ListBreeds(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// ------------- Optional query parameter "include_deleted" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deleted", r.URL.Query(), &params.IncludeDeleted)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_deleted", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "as_of" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "as_of", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "include", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListBreeds(w, r, params)
 	}))
@@ -179,6 +607,10 @@ func (siw *ServerInterfaceWrapper) ListBreeds(w http.ResponseWriter, r *http.Req
 func (siw *ServerInterfaceWrapper) CreateOneBreed(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.CreateOneBreed(w, r)
 	}))
@@ -190,6 +622,59 @@ func (siw *ServerInterfaceWrapper) CreateOneBreed(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// StreamBreedEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamBreedEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamBreedEventsParams
+
+	// ------------- Optional query parameter "species" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "species", r.URL.Query(), &params.Species)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "species", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamBreedEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // DeleteBreedByName operation middleware
 func (siw *ServerInterfaceWrapper) DeleteBreedByName(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -205,6 +690,10 @@ func (siw *ServerInterfaceWrapper) DeleteBreedByName(w http.ResponseWriter, r *h
 		return
 	}
 
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.DeleteBreedByName(w, r, breedName)
 	}))
@@ -231,8 +720,23 @@ func (siw *ServerInterfaceWrapper) GetBreedByName(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBreedByNameParams
+
+	// ------------- Optional query parameter "as_of" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "as_of", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBreedByName(w, r, breedName)
+		siw.Handler.GetBreedByName(w, r, breedName, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -257,6 +761,10 @@ func (siw *ServerInterfaceWrapper) CreateOrUpdateBreedByName(w http.ResponseWrit
 		return
 	}
 
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.CreateOrUpdateBreedByName(w, r, breedName)
 	}))
@@ -268,6 +776,375 @@ func (siw *ServerInterfaceWrapper) CreateOrUpdateBreedByName(w http.ResponseWrit
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetBreedHistoryByName operation middleware
+func (siw *ServerInterfaceWrapper) GetBreedHistoryByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "breed_name" -------------
+	var breedName BreedName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "breed_name", mux.Vars(r)["breed_name"], &breedName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "breed_name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBreedHistoryByName(w, r, breedName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RestoreBreedByName operation middleware
+func (siw *ServerInterfaceWrapper) RestoreBreedByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "breed_name" -------------
+	var breedName BreedName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "breed_name", mux.Vars(r)["breed_name"], &breedName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "breed_name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreBreedByName(w, r, breedName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListBreedVersionsByName operation middleware
+func (siw *ServerInterfaceWrapper) ListBreedVersionsByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "breed_name" -------------
+	var breedName BreedName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "breed_name", mux.Vars(r)["breed_name"], &breedName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "breed_name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBreedVersionsByName(w, r, breedName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DiffBreedVersionsByName operation middleware
+func (siw *ServerInterfaceWrapper) DiffBreedVersionsByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "breed_name" -------------
+	var breedName BreedName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "breed_name", mux.Vars(r)["breed_name"], &breedName, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "breed_name", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffBreedVersionsByNameParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffBreedVersionsByName(w, r, breedName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ExecuteGraphQL operation middleware
+func (siw *ServerInterfaceWrapper) ExecuteGraphQL(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExecuteGraphQL(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteWebhookByID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", mux.Vars(r)["webhook_id"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhookByID(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetWebhookByID operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", mux.Vars(r)["webhook_id"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookByID(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateWebhookByID operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhookByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", mux.Vars(r)["webhook_id"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhookByID(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", mux.Vars(r)["webhook_id"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplayWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", mux.Vars(r)["webhook_id"], &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "delivery_id", mux.Vars(r)["delivery_id"], &deliveryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "delivery_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayWebhookDelivery(w, r, webhookId, deliveryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -385,11 +1262,209 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/breeds", wrapper.CreateOneBreed).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/breeds/events", wrapper.StreamBreedEvents).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}", wrapper.DeleteBreedByName).Methods("DELETE")
 
 	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}", wrapper.GetBreedByName).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}", wrapper.CreateOrUpdateBreedByName).Methods("PUT")
 
+	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}/history", wrapper.GetBreedHistoryByName).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}/restore", wrapper.RestoreBreedByName).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}/versions", wrapper.ListBreedVersionsByName).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/breeds/name/{breed_name}/versions/diff", wrapper.DiffBreedVersionsByName).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/graphql", wrapper.ExecuteGraphQL).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/webhooks", wrapper.ListWebhooks).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/webhooks", wrapper.CreateWebhook).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhook_id}", wrapper.DeleteWebhookByID).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhook_id}", wrapper.GetWebhookByID).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhook_id}", wrapper.UpdateWebhookByID).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhook_id}/deliveries", wrapper.ListWebhookDeliveries).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/webhooks/{webhook_id}/deliveries/{delivery_id}/replay", wrapper.ReplayWebhookDelivery).Methods("POST")
+
 	return r
 }
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+w9aXPcNpZ/BcWdD/Yu+5BkeyddtR9kS55oJz5WkiepdXvVaPJ1N8YkwACgpLZG/33r",
+	"4eDdlyQrceKqVNwkcTw8PLz7QTdBJNJMcOBaBaObYAE0Bml+vqLRAl4JrqVI8DkGFUmWaSZ4MDJfGZ+T",
+	"TCQsWhIxI3oBRGQgKbYIyYSLXoRDTEjOE1CKRILP2DyXEBOhFyCvmIIgDFS0gJTiDHqZQTAKlJaMz4Pb",
+	"2zA4Pqfz9txnWgo+J5c0YTHVQhJcQ64hJjMpUgNIJLgGrj1cElQmuIKQaEEU8JhMafSZME4mJ7PeW8Gh",
+	"94bqaDHZAM9PVOk3ImYzBnEbLvxKNEvBzDmVALFqQkCiBeVziLsh8WP3zhiPYNInh1OFyzDrgjTTS5Iw",
+	"pdVaMG/DIKOSpqDdTh6qd7M2tKdA4yqgVOHTklyBBEI10QumSEw1kCenr1+Rg4ODH54SxpXGfm5VUS6l",
+	"QTMHhInhuL/mIJdBGHCaIlhUXYhZDV64pmmW4Lf94f6L3vCgN9w7Hw5H5r//DcJgJmRKdTAKcPYeIjQI",
+	"Ozbj8BIkncNrSGkCh3Ge6J+BzRca5+iExLa/mJkOFxR7XFzZLlX46mhysxDb0C/cjkHMGLh5c0lTA2ax",
+	"uL3hcBgGKeMszdNgNCxWwLiGOcjqEt7stoD7g78J+P1tgH8pAeK3BjQHb0b1ogTX0NWFeQgDCb/mTOKp",
+	"0TKHKrwpvf4J+FwvgtH+8+dm1uIZKVlrkDj4/32kvS+f/uPJhf336b//pZMqXjNIYtXBsESaUqIAjway",
+	"Cqq1ZNNcQ3FE3Tlw5zIk0J/3yQThD1UGEQM1CYngyRJbp2QKyP8knoaC7cRUU6WFhP6Yny+AYGfCFKHJ",
+	"FV0qHFeHhCaJaVyBgEogVwvgJOcK9JibvcgSEUMwmtFEQffZmtm1VrHJNKRm9cBx5z4GDv9uBUEYZKAv",
+	"FPuCL9efiHXkFkMCGuILqoNPrV0oXlAp6RKflV6aA48nG59PeJTkMWzeJQmJkSdmWyCdQowEW+5Wn5wX",
+	"v8mCXgLhouhElqBDApcglygscrMVEv4JkYZ4SxQzB2knju+67iOLvfby3XeixEwTh+NipaegQF5CjKig",
+	"MR5OpSXKPxWshf3CDdTgEjOaJ7pYu4N8KkQClJuT9B70GdLJCm5UoaNy2L9ImAWj4N8GpVoxsF/VwI+H",
+	"Y585clwxdkmt2w3th8Ohf4bpQojPJ0cr2NKV/X7B4rVsqUOqYmNQ+qWIHeiGA+IPp27gT5plCYsM/Q3+",
+	"qXBXb7ZchBlNlVOVcJWrerDJ/Hhds5k3Vl2xy6TxqV36sZRCPhgMdjQDQfMYGN2OOIQHXtgcsdnsYdFt",
+	"RuyA4NSra4YrWwXOwIIsROVRBErN8qSA7EemtJDLnYAr2Mg6KA/zmOljruWyzV/uD7Zv/1Vo+H6g/QOk",
+	"Qsb/E1P64dFaneJrIPYrgf2eSs1oYqZ4QLD75J1Ta/x3tKWMcmFUE4UrCFvGYa9iHXZB7doPapZkxapb",
+	"18e0cRZXr2pyretUM88MOszUdJrA75Xcv0msvhZyyuIY+CPJA9TxIpokIEksQBEutFX2DF5FAsRLMDJd",
+	"1h0RVuvSIDlNzlB9ko8E8ykokcsIDLAzkXNzYN+KNf6Dc0MlmQQ8b1aDXUBSrClKmLEf8Osq/wUREg15",
+	"MetyJ4SG9jRLkqbx3h/zjvbYnM25QLwaMm5OxqxJY+yVb5CM/RYdJhJovDy+ZkqrxyYPaicnYGYPKmC9",
+	"Ffo10s1vSrDnQryhfOn0P/X4Bx6uI4AYrKtKUg0kYSnTLY9jnQRPqYafsF3P/L991t7m6RQkjuL4Mhrp",
+	"ibgqDcwrxmNxVTgH8ngOusvvVnWJlNOeQkoZR9Nhm6kTmGkyhZmQ4PwKZpEQ7zKhgo51nkEkeKxIzjVL",
+	"KivBszvLk2RJJBiPxeapQMtl73CmQa6eRgtyRVmxFol9EAlrx8bRP3Ca64WQ7As8FsG/YUohqoUkzBkc",
+	"kYQYOKpY5iR+4JkUKKlRgzjmmunlIx4AFkOaCQ08WpLP6JilquAWuUINDZk9N770qqnkTLsjSNglSAZf",
+	"SRetT/PQ5klj9AfX3FrQPwS0XwvKh4Hu69LBg+7/recXNn5R2sAIchwzHI8m76XIQGrjjHFurKzy6iag",
+	"kRYdzOrEnHFdRK2spAnNb+RC+DUy7sdMMh6xjCbIJCBRVuP8pXeIAxMrb6p+8wDVsgsxm7GoI2YRBtRz",
+	"z22MijCwXHSH9qXHvRZnwSCdWlwsUAjw+UUs5hefRSYuu0CMJFDn2R3dbBWKCYNSCFccz3agIAzyLLY/",
+	"rB/SeN2MyAnCIMvlHDpdyI4y0E3XsYFlWM3Sz5xdAveK8i89p670To7cJuH+zYGD9Sq7dsadKrsWlCuI",
+	"qOrwT3+wH8jVgkULkoFEBDnlJM2t0t4nxyZSZ4g8FhyIyLViMSDQlLixyZNIXRK15NFCCs6+mK5Pa8Q0",
+	"zofDg+jD+6PD82Py8vT4+Mi86Y6GlT68j/XIS1VD8usK3dGoobm29+WWiCk6zFs+uB2OoQ15qq05ignh",
+	"vDKdupzqGG3p0iPCQIsVuksVOaa7aRsWkK1c7fGlY5Seqg1q+w5RgTtyfUvi5XPpcbfPFR3LvjB0H3cS",
+	"fs01tRuip94fvR27MDrPhUfndkfd9tGifTSOeXEqTaOCwTJFLu1yyBO4NiGJ+GmfvBWaKEDzs2aNuqZb",
+	"hoHD4LLEVBGv3As3EUE5SwUJbnNWU4PaVf6sia9VYzDD8NGCzuvCencA6f6B5FossUVUR1SDn6sMihkm",
+	"a1yWjoDqobJVtCOBxtjLB3tatOQlZ8Neo2kBgz295GwhcnTMAK5acfoZlQgFhPKYqOJbztmveQ0d6yTx",
+	"Q8bBK1HebYNyZYh4h1hb9Uh1RpgNRrvOU2FH7XCcZitC+/9gwkeJPakgLDOn2DqP2RXTC/Px2XDYUB+s",
+	"RzFFpxYahEwH4Q6SyhlxbUGVglJ03tDFAFsT/6m5zevluu/VhdCq0LwDWutArgv9t0jNC5DGppiAu+dc",
+	"OIV3rMyYVFU+X2MWhRTfdjRlPA+dwz0fDlvC36w1rCgBK3F5ZwptQ//ep2OhGhoLbUwLqhcFVxHxkriR",
+	"lrgwQyRBeNcd6SQ8bt1Oaa7QPUOoJglQpclwozrpkbaO/P4mabb4n58KpNXxAtcaOO7PGglqeXK9XyTi",
+	"xirevju/eP3uw9ujTqhbYFUw0WaRVC+qOmnbgN3+9LnlO7ujjYBCCff5Ui1obAZEba03Tp49MSdmRPaG",
+	"T8kNgXgOitwQLmLAf2jq+B0ocktuSUbncMJngtyQBVVv4Vq/R7kNPH6VSyWkaXTbrdtJhp6ujZvUWH4D",
+	"T3Yla7FUuknqaIqpppsm53mSIJQrgAkth93e2qhRbhcNtCaoRWE3MYg6JzB9jMtVskjbXJ6Cpaky+mqd",
+	"EWZvrT+4kkFGniiwjoiXNu/JrgRtx53Uzx1Vw02K23bqOl9F/4+vsKzUTSqJT/XdQ/g8z85MNMCbhSql",
+	"SWJYZMxyI1nwucu8q2Q++c6xQAd5tCKXrpL5s4vNjQcsyjW7hIsZZUku7fsVOnBlV7dwAG1UpGNmXOab",
+	"dXqaa5FSzSJiuzA+b2j2biTi0rburtwDNwPVrBzbtGHo+KkI4g3PnhaCpJQvSWy9xchpGSeUSHGFnksP",
+	"Y9BOowsDQA/CBb5Xu2WnWNfDrdEQT2yvvbaOyRqa23D6A/3PaA96B7Pnce9ZtE97P8Bfp70X8d5snx5M",
+	"n0XP422wpSCSXfGkv0PhNlVszqnOZbGZJXr65NhkXbo3S8QSJe/fnZ37tnBZ08ld6G7Mf+mZdfdQTobE",
+	"P50c4W93EnreaV99d85SUJqmGdpgY15+OPNQjsg4UAu6//zFf40DMhMu2ud8gQu4Jj++OXzVO/vxcP/5",
+	"CwRz7Bxw2g9tHqFv36LOZl+Mg/6Yn/oEBMGJOUFM8DFfb9btvWgiPgyuJNNQ7gz6ImVS3+KF1pkaDQaR",
+	"TPvubT8S6cAcj0HbBM4la8AxfPbXTTofzlon3i5O2Yyi7Oge0RrSTKtu6XIXR7Sjtx172VWyuNaDcf3i",
+	"WdDFIG1zL8i2P8dbT5BQpS/Aa9ItcM1npanO1YVXkOuH9EetM2Jb+POGnYhDeJ8MywiMZ3aR8VqgHTwF",
+	"IgGzMuJO6Dhc6ws30k5ozugyEbTDPDIYsqqNFlWggg6Ss8uqis8MeOxizHlkkwWQ/ClLOj2sDUI3ju+C",
+	"Amq7W0wWlqTagf42SmrEWy78U5fmjFJaMr08Q5qx5+IwY3+H5WGuF21cHWbMBIPdBJaDTuaCyJyTPqEZ",
+	"+wxL5T5P+uREo34oMlDonePaapuG+ZnyBZGACscc99u2IzZrHD8auhFXIE19z2jMCemZgoeRM0xG+GBf",
+	"G75VvDdP9oPJVC8+mCfjJvPy3L4yrNIkaxdBNXvAgl96h+9Pen+HZUkM1ODHeGWBSpAeU1Pz9NoT43//",
+	"fB604uriM3DClMpL1s98ODCT4pLFIENytRDKZpYpEiWUpbbsyr2x5I7Yp2rMrTiQLnvGPMHIv6Txvwwu",
+	"/mVXaT6GZHtsV/CMZ0OClgwuqwVmtXZmLmxo9z8kNjBhEO5iEKu62p3RwvlSW83q+4UNU8rp3Db0H802",
+	"Gu5ntCCzH+W+oeCyYWHGZ2JV/ptLPC8STwlVZNKMU09w85wa1yevTGacIhHlhKrPuEFkouFaDyJ1OQnr",
+	"3Zc0TSZjLmT9darmGY0+T7xfaXIYRZDpiQ8gusq30OByDppQMnk2fDGxnJSbMJ/ziUPMKDFCE5+XY07N",
+	"UAa8qV2UqVtZmjWi3JCgVHGYpxJB/qJ0bNL5JvMvLJsQGkVCWkIRVQB7xzwS+N5D2h9zW3xk1C2E9v2H",
+	"89LTWQHCzkcxr69MNcGj5ocKnQsbkV1kH+LspjzQwQ/XaGdAPOaCRzDCD0w6SlWlhqfQlkXOhbgr3YB+",
+	"v4mELKFLtHyLLiVYmN5kP4+IljkUAI65TWximsB1xiSqnodmGgmrcmRsFdAsLzCOO7m/P7FbS23SkkPQ",
+	"giXQSFQYc8aRVcxx08oBhj9M+ob8NdNGTXPG+eH7k6ASpQr2+kMXMuc0Y8EoOOgP+weBdUcZ9u9VuNFN",
+	"MO9Swd/bqDOhxHhaUOO0PciUKsDHDDTWmUoaaZBMoXn1xMVtcInObn5aDQ6fxFjAypR+6fXHag3px249",
+	"p2xSGtnhxqYr6za37/tm956l32Bj00aV1jZgYYXtFu1cceL2MAS3nxrFOPvD4Sq1s2g3qNQg3IbBwfDZ",
+	"5i7VzOTbMHi21TSNwiDTb29zv3ayn+l5sLlnI/Mcu+3/sLlbZxbrbRg832aVXenjRnPL05TKpTs3ZexR",
+	"0zkeGR8y/oSqr1AdB/mVEdIo7m1nwmbIyFAHt9nAffJOLwDr5PG9BJ1LVJ5seCCs6A5GBtGOskF0AReu",
+	"RFNzPgWvCcReprX4gAXsHbdVE0G18m25Gl+V4rhBUaHSoN29LWm3cBF/U6Q43IIU1ySdG2re3wbmFWmp",
+	"v5Pj0KTrrjNxG3o5NzAW12pxZ+fpnaE8NpaiIkpLoGmjbtt7XZTXelVYZAuo0BG9bWGkoEnBUf0xt64q",
+	"A0VxXESup+KasPKmAxNZRV2UxSFhWhkFjzyp5QOFpJYOFI55LR3If/bnzz8bSOKnOLiFAsHDKVDTJc56",
+	"xK8YHkH9xupiYy4hEpxDpA0P8GqTqYrwfjOvvnptKwJ2afVSO5dCzpIypVCBazGCM4Po0o1xP72g076r",
+	"Qbv2XosVkrCSzWpUfrOsniWRejprR0lv62IRR1eOJv9EgvDg6ye1O/SifKOXlNkoXp11uCbVY+1S9Nbz",
+	"ECSmwU2Z93hr+Qgeuw6OUopJQn3qKHZFOwfPnb22weWuecu+kMknhSVXCNLCCGHK8pW2TLXapIH95fKt",
+	"dS/tdpTK+zbuoRR+o4L12faCtV429TsRiUcbqK1bbeyUh6feB7Qz6bZo8m+gH4ggtzWR7kS4K4p4//CW",
+	"zTdP9ZtJdYW5lHfQ/UlF23MEjRzXeTgNvdtvhSIEXDPpsqvqCX91w8pqbzhaf5UpJD+YaR6SfT+QRTV8",
+	"hGJ2u/hGVZEz5r7y1OaLj3bYaZ+1aeNllSimOdrQ3ggwKv0UXCnGn4oB/AFMSUd5QpJoN6OypRAOFuUV",
+	"LZ1y1Thx7GVRvpLHVe/wBv8qrvsSSQyqCFpyuDKsBX3vbjJb8VP3yDBFPkOmVwpjd5XMb6kkOhC++wHv",
+	"Ku90kwQq5LMz4fpqudHNCj/iqW1QTNPhBmxoh8x6Omy0H7izWcgS2mTpBv9dGS5rZACzq4oL3/13dv8N",
+	"sfuSkmvku/ORceE2tQ2zd21b53Q9m7daJp6potgtA8lEvDqo5m+0+i1PUe1Wre/s/Q5hHlPf6LB4f97u",
+	"RxrErqx2NbVW0sRt9bG7SZhMQV8BcKKvxFrIGv4oNps9MFmG3Zc5ulqbNTfArq3b7B5Ui7sPeffjY68L",
+	"/O5DeMxgkkgzKmEjdXeeuznWdvyarFadjm3ySplLIQkt1X+f6GzIisQwY9zeDzShGfODOyO27x7xkmCf",
+	"7zLmruDe365NTo8xBdofROWKO3wKjbUdbP1K5eJwkeDCKwlRibD5YmOOEaEiB2V/OLSTM0k+M24hLUuv",
+	"+pGIMSXwXTm9YySUu7zPjEoFscGCBH8rzpjjQ3fGjDaJcvaOJp/EhhudwDXTS3dVUxU+8yBybWJZHREn",
+	"tyGuKKcden4Qd0OjWqv7xtWv5mdpVkF1eD3e5ToSZdVx7Ta7e/CQ/f3HXIS/xbUO/e8+SuVWtjZMVbIN",
+	"37p6y4ZnRe6b40VFAUsp5duK4s9llcvuIqp2z8+fWkmrFAv5zSgwuzof5yyf4iMWx3ICPM4EsynpCs+i",
+	"i0fayHCflNdrWcbM5rzqe7b1MyHxpXqNSgknU1a4m38u0t93dhNXbmPaPfWmeY3Vt2Ug/LEyZyolEG0K",
+	"rvKTwU15o/nasHcjBul6OW2iMGeLkq1EzFvkaYdwgLxcmoSN3cyF8nb2DjW8w6vj2tcdOt+V6PuFn9fS",
+	"Vtgtnf4G+qvt+/COrOk7Hdw3IOuZgPMPs3ilxMw7Hc9ZQqNaCch20dfzQkL6cIhNYRUp08YOOua2GJjQ",
+	"orq2mEGCAoRVmz8p5aucSVHl3GRaNob0kMT7YDJ5+AeXyd892i6AuRXnXSnVB2Vl9Uof4VFFblf9I0Ux",
+	"u/dmO/+1FhXfdqXoRtRNz2rdKL4zpWteR+j0dbeun/2tZEXjAtzvEuNePu+SBDuo6+70PLjxxGQ+2Cqw",
+	"1f66MzAlWkWhLxGS2ErfUnGlc4o1poooIcy/mVCKoRHfjm3ibM0i9nuQa9j5F4cqK1zrtN5YGt5xHPZ3",
+	"PQ7LjYHUo+qlDe6+aV9j/V2K/EZ6GxKqDYt6Iu06cJV6ckO51Uryj5+QPKsV0x8/IUHZW3AtpZu7Jkyd",
+	"7mgwSEREk4VQevR8ONwbDi73DD928zappuLULgsSfQJqeRaK20eb/VU+LR4Ni4Fri5XCE6O8zKp5Ysqh",
+	"C0S0B/81t5wLCyu8d7/xd//C0q3uHf7IRUo/fzmR9+vdfrr9/wEAqcptnEB1AAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
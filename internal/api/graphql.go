@@ -0,0 +1,15 @@
+package api
+
+import (
+	"net/http"
+)
+
+// Execute a GraphQL operation
+// (POST /graphql)
+func (s Server) ExecuteGraphQL(w http.ResponseWriter, r *http.Request) {
+	if s.graphql == nil {
+		_ = SendJSON(w, Error{Message: "graphql is not available"}, http.StatusServiceUnavailable)
+		return
+	}
+	s.graphql.ServeHTTP(w, r)
+}
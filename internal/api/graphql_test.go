@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/graphqlapi"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// graphQLError
+// Body of a GraphQL response holding one error of the given code
+func graphQLError(code string, message td.TestDeep) td.TestDeep {
+	return td.SuperJSONOf(`{"errors": [$1]}`, td.SuperMapOf(map[string]any{
+		"message":    message,
+		"extensions": map[string]any{"code": code},
+	}, nil))
+}
+
+func TestServer_ExecuteGraphQL(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore,
+				api.WithGraphQL(graphqlapi.New(logger, datastore, graphqlapi.WithComplexityLimit(100))),
+			), api.GorillaServerOptions{
+				BaseURL:    "/v1",
+				BaseRouter: mux.NewRouter(),
+			})
+			ta = tdhttp.NewTestAPI(t, h)
+
+			cursor string
+		)
+
+		for _, name := range []string{"gqlc", "gqla", "gqlb"} {
+			ta.Name("valid case -- create "+name).PostJSON("/v1/graphql", api.GraphQLRequest{
+				Query:     `mutation($input: BreedInput!) { createBreed(input: $input) { name species petSize } }`,
+				Variables: &map[string]any{"input": map[string]any{"name": name, "species": "CAT", "petSize": "SMALL", "averageFemaleAdultWeight": 1000}},
+			}).
+				CmpStatus(http.StatusOK).
+				CmpJSONBody(td.JSON(`{"data": {"createBreed": {"name": $1, "species": "CAT", "petSize": "SMALL"}}}`, name))
+		}
+
+		ta.Name("invalid case -- already exists").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `mutation { createBreed(input: {name: "gqla", species: DOG, petSize: TALL}) { name } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("ALREADY_EXISTS", td.Ignore()))
+
+		ta.Name("valid case -- update").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `mutation { updateBreed(input: {name: "gqlb", species: DOG, petSize: TALL, averageMaleAdultWeight: 2000}) { name species averageMaleAdultWeight } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"updateBreed": {"name": "gqlb", "species": "DOG", "averageMaleAdultWeight": 2000}}}`))
+
+		ta.Name("valid case -- first page").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(first: 2) { edges { cursor node { name } } pageInfo { hasNextPage endCursor } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"breeds": {
+				"edges": [{"cursor": NotEmpty(), "node": {"name": "gqla"}}, {"cursor": $1, "node": {"name": "gqlb"}}],
+				"pageInfo": {"hasNextPage": true, "endCursor": $1}
+			}}}`, td.Catch(&cursor, td.NotEmpty())))
+
+		ta.Name("valid case -- next page").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query:     `query($after: String) { breeds(first: 2, after: $after) { edges { node { name } } pageInfo { hasNextPage } } }`,
+			Variables: &map[string]any{"after": cursor},
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"breeds": {"edges": [{"node": {"name": "gqlc"}}], "pageInfo": {"hasNextPage": false}}}}`))
+
+		ta.Name("valid case -- filter").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(filter: {species: DOG}) { edges { node { name petSize } } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"breeds": {"edges": [{"node": {"name": "gqlb", "petSize": "TALL"}}]}}}`))
+
+		ta.Name("invalid case -- first out of bounds").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(first: 0) { edges { node { name } } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("BAD_USER_INPUT", td.Contains(graphqlapi.ErrInvalidFirst.Error())))
+
+		ta.Name("invalid case -- invalid cursor").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(after: "$$$") { edges { node { name } } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("BAD_USER_INPUT", td.Contains(graphqlapi.ErrInvalidCursor.Error())))
+
+		ta.Name("valid case -- breeds by name").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ a: breed(name: "gqla") { name } c: breed(name: "gqlc") { name } unknown: breed(name: "unknown") { name } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"a": {"name": "gqla"}, "c": {"name": "gqlc"}, "unknown": null}}`))
+
+		ta.Name("valid case -- delete").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `mutation { deleteBreed(name: "gqla") }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"deleteBreed": true}}`))
+
+		ta.Name("invalid case -- delete unknown").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `mutation { deleteBreed(name: "gqla") }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("NOT_FOUND", td.Ignore()))
+
+		ta.Name("invalid case -- too complex").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(first: 50) { edges { cursor node { name species } } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("COMPLEXITY_LIMIT_EXCEEDED", td.Contains("complexity 251")))
+
+		ta.Name("invalid case -- syntax error").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds(`,
+		}).
+			CmpStatus(http.StatusUnprocessableEntity).
+			CmpJSONBody(graphQLError("GRAPHQL_PARSE_FAILED", td.Ignore()))
+	})
+}
+
+func TestServer_ExecuteGraphQL_Auth(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		issuer, err := testutils.NewIssuer()
+		require.CmpNoError(err)
+		token := func(roles ...string) string {
+			res, err := issuer.Token("alice", roles...)
+			require.CmpNoError(err)
+			return "Bearer " + res
+		}
+		var (
+			ta    = tdhttp.NewTestAPI(t, newAuthHandler(logger, datastore, issuer, api.WithGraphQL(graphqlapi.New(logger, datastore))))
+			query = api.GraphQLRequest{Query: `{ breeds(filter: {includeDeleted: true}) { edges { node { name } } } }`}
+		)
+
+		ta.Name("invalid case -- read cannot list deleted").PostJSON("/v1/graphql", query, "Authorization", token("breeds:read")).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(graphQLError("FORBIDDEN", td.Contains("include_deleted requires the breeds:admin role")))
+		ta.Name("valid case -- admin lists deleted").PostJSON("/v1/graphql", query, "Authorization", token("breeds:admin")).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"data": {"breeds": {"edges": []}}}`))
+	})
+}
+
+func TestServer_ExecuteGraphQL_Unavailable(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		h := api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+			BaseURL:    "/v1",
+			BaseRouter: mux.NewRouter(),
+		})
+
+		tdhttp.NewTestAPI(t, h).Name("invalid case -- no graphql handler").PostJSON("/v1/graphql", api.GraphQLRequest{Query: `{ breed(name: "a") { name } }`}).
+			CmpStatus(http.StatusServiceUnavailable).
+			CmpJSONBody(api.Error{Message: "graphql is not available"})
+	})
+}
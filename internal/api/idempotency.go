@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+	"github.com/japhy-tech/backend-test/internal/logger"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	DefaultIdempotencyKeysTTL = 24 * time.Hour
+)
+
+// IdempotencyMiddleware
+// Execute the POST and PUT requests sent with an Idempotency-Key header once by client
+// and key: the retries get the stored response replayed until it expires after ttl. A
+// key reused for another request is refused with a 422, and the retries sent while the
+// request is in progress with a 409. The responses in error 5xx are not stored, for the
+// request to be retried.
+// It is meant to be given as a handler middleware of the generated router, after the
+// authentication
+func IdempotencyMiddleware(repo idempotency.Repository, ttl time.Duration) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPut) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrDomainValidation, err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			record, err := idempotency.NewFactory(idempotency.FactoryOpts{
+				Client:      idempotencyClient(r),
+				Key:         key,
+				RequestHash: idempotency.HashRequest(r.Method, r.URL.Path, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			}).Instantiate()
+			if err != nil {
+				HandleErrorResponse(w, err)
+				return
+			}
+
+			err = repo.CreateOne(r.Context(), record)
+			if errors.Is(err, domainerror.ErrResourceAlreadyExists) {
+				replay(w, r, repo, record)
+				return
+			}
+			if err != nil {
+				HandleErrorResponse(w, err)
+				return
+			}
+
+			recorder := &bodyRecorder{ResponseRecorder: httputil.NewResponseRecorder(w)}
+			next.ServeHTTP(recorder, r)
+
+			// The outcome is stored even when the client went away, for its retries
+			ctx := context.WithoutCancel(r.Context())
+			if recorder.Status() >= http.StatusInternalServerError {
+				err = repo.DeleteOne(ctx, record.Client(), record.Key())
+			} else {
+				err = repo.UpdateOne(ctx, record.Complete(recorder.Status(), recorder.Header().Get(ContentTypeHeader), recorder.body.Bytes()))
+			}
+			if err != nil {
+				logger.FromContext(ctx).Errorf("cannot store outcome of idempotency key %s: %s", key, err)
+			}
+		})
+	}
+}
+
+// replay
+// Answer a retry with the response stored for its key
+func replay(w http.ResponseWriter, r *http.Request, repo idempotency.Repository, retry *idempotency.Record) {
+	stored, err := repo.GetOne(r.Context(), retry.Client(), retry.Key())
+	if errors.Is(err, domainerror.ErrResourceNotFound) {
+		// The key was released or expired in the meantime
+		HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrResourceAlreadyExists, fmt.Errorf("idempotency key %s is being released, retry the request", retry.Key())))
+		return
+	}
+	if err != nil {
+		HandleErrorResponse(w, err)
+		return
+	}
+
+	if stored.RequestHash() != retry.RequestHash() {
+		HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrUnprocessableEntity, fmt.Errorf("idempotency key %s was used for another request", retry.Key())))
+		return
+	}
+	if !stored.Completed() {
+		HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrResourceAlreadyExists, fmt.Errorf("request with idempotency key %s is in progress", retry.Key())))
+		return
+	}
+
+	if stored.ContentType() != "" {
+		w.Header().Set(ContentTypeHeader, stored.ContentType())
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.Status())
+	_, _ = w.Write(stored.Body())
+}
+
+// idempotencyClient
+// Same client as the rate limiter, the keys of the authenticated callers being kept
+// apart from the anonymous ones
+func idempotencyClient(r *http.Request) string {
+	return rateLimitClient(r)
+}
+
+// bodyRecorder
+// Keep a copy of the response body to store it
+type bodyRecorder struct {
+	*httputil.ResponseRecorder
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseRecorder.Write(b)
+}
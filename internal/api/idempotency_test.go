@@ -0,0 +1,69 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+				BaseURL:     "/v1",
+				BaseRouter:  mux.NewRouter(),
+				Middlewares: []api.MiddlewareFunc{api.IdempotencyMiddleware(datastore.IdempotencyKeys(), time.Hour)},
+			})
+			ta    = tdhttp.NewTestAPI(t, h)
+			breed = api.Breed{
+				Name:                     "test",
+				Species:                  api.Species(values.Cat.String()),
+				PetSize:                  api.PetSize(values.Medium.String()),
+				AverageFemaleAdultWeight: common.ToPointer(1),
+				AverageMaleAdultWeight:   common.ToPointer(1),
+			}
+			key      = http.Header{api.IdempotencyKeyHeader: {"first"}}
+			replayed = http.CanonicalHeaderKey(api.IdempotentReplayedHeader)
+		)
+
+		ta.Name("valid case -- first request").PostJSON("/v1/breeds", breed, key).
+			CmpStatus(http.StatusCreated).
+			CmpHeader(td.Not(td.ContainsKey(replayed))).
+			CmpJSONBody(breed)
+		ta.Name("valid case -- retry is replayed").PostJSON("/v1/breeds", breed, key).
+			CmpStatus(http.StatusCreated).
+			CmpHeader(td.SuperMapOf(http.Header{replayed: {"true"}}, nil)).
+			CmpJSONBody(breed)
+
+		other := breed
+		other.Name = "other"
+		ta.Name("invalid case -- key reused for another request").PostJSON("/v1/breeds", other, key).
+			CmpStatus(http.StatusUnprocessableEntity).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.HasPrefix(domainerror.ErrUnprocessableEntity.Error())))
+
+		ta.Name("invalid case -- without key the request is executed again").PostJSON("/v1/breeds", breed).
+			CmpStatus(http.StatusConflict)
+
+		ta.Name("valid case -- failures are replayed too").PostJSON("/v1/breeds", breed, http.Header{api.IdempotencyKeyHeader: {"second"}}).
+			CmpStatus(http.StatusConflict)
+		ta.PostJSON("/v1/breeds", breed, http.Header{api.IdempotencyKeyHeader: {"second"}}).
+			CmpStatus(http.StatusConflict).
+			CmpHeader(td.SuperMapOf(http.Header{replayed: {"true"}}, nil))
+
+		ta.Name("valid case -- reads are not concerned").Get("/v1/breeds", key).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.Not(td.ContainsKey(replayed)))
+	})
+}
@@ -0,0 +1,84 @@
+package api_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			buf bytes.Buffer
+			r   = mux.NewRouter()
+			h   = api.HandlerFromMuxWithBaseURL(api.New(logger.Logger, datastore), r, "/v1")
+			ta  = tdhttp.NewTestAPI(t, h)
+		)
+		log, err := logger.New(&buf, logger.FormatJSON, "")
+		require.CmpNoError(err)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(logger.WithContext(r.Context(), log)))
+			})
+		})
+		r.Use(api.RequestContextMiddleware)
+		r.Use(api.AccessLogMiddleware)
+
+		ta.Get("/v1/breeds", http.Header{api.RequestIDHeader: {"first"}}).
+			CmpStatus(http.StatusOK)
+
+		// Every line of the request, the usecase ones included, carries its id
+		var lines []map[string]any
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var line map[string]any
+			require.CmpNoError(json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.Cmp(lines, td.All(
+			td.ArrayEach(td.SuperMapOf(map[string]any{"request_id": "first", "actor": api.AnonymousActor}, nil)),
+			td.SuperBagOf(
+				td.SuperMapOf(map[string]any{"usecase": "<LIST BREED>", "msg": "Usecase <LIST BREED> [SUCCEED]"}, nil),
+				td.SuperMapOf(map[string]any{
+					"msg":     "request",
+					"method":  http.MethodGet,
+					"path":    "/v1/breeds",
+					"status":  float64(http.StatusOK),
+					"bytes":   td.Gt(float64(0)),
+					"latency": td.NotEmpty(),
+				}, nil),
+			),
+		))
+	})
+}
+
+func TestWithActor(t *testing.T) {
+	var (
+		require = td.Require(t)
+		buf     bytes.Buffer
+	)
+	log, err := logger.New(&buf, logger.FormatJSON, "")
+	require.CmpNoError(err)
+
+	ctx := api.WithRequestID(logger.WithContext(context.Background(), log), "first")
+	ctx = api.WithActor(ctx, "back_office")
+	ctx = api.WithActor(ctx, "alice")
+	logger.FromContext(ctx).Info("authenticated")
+
+	require.Cmp(buf.String(), td.Not(td.Contains("back_office")), "invalid case -- replaced actor is not logged")
+	var line map[string]any
+	require.CmpNoError(json.Unmarshal(buf.Bytes(), &line))
+	require.Cmp(line, td.SuperMapOf(map[string]any{"request_id": "first", "actor": "alice"}, nil), "valid case -- actor replaced")
+}
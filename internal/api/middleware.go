@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+	"github.com/japhy-tech/backend-test/internal/jwtauth"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+)
+
+const (
+	RequestIDHeader          = "X-Request-ID"
+	ActorHeader              = "X-Actor"
+	APIKeyHeader             = "X-API-Key"
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+
+	AnonymousActor = "anonymous"
+)
+
+// RequestContextMiddleware
+// Store the request id and the actor of the request in its context, along with a
+// logger carrying them. The request id is generated when the client does not provide
+// one and is sent back in the response. The actor given by the client is replaced by
+// the principal once AuthMiddleware authenticates the request
+func RequestContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			actor = AnonymousActor
+		}
+
+		ctx := WithRequestID(r.Context(), requestID)
+		ctx = WithActor(ctx, actor)
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type requestLoggerKey struct{}
+
+// WithRequestID
+// Store the request id in the context, along with a logger carrying it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	log := logger.FromContext(ctx).With("request_id", requestID)
+	ctx = reqcontext.WithRequestID(ctx, requestID)
+	ctx = context.WithValue(ctx, requestLoggerKey{}, log)
+	return logger.WithContext(ctx, log)
+}
+
+// WithActor
+// Store the actor in the context, along with a logger carrying it and the request id.
+// It replaces the actor stored before, e.g. the claimed one once the request is
+// authenticated
+func WithActor(ctx context.Context, actor string) context.Context {
+	log, ok := ctx.Value(requestLoggerKey{}).(*charmLog.Logger)
+	if !ok {
+		log = logger.FromContext(ctx)
+	}
+	ctx = reqcontext.WithActor(ctx, actor)
+	return logger.WithContext(ctx, log.With("actor", actor))
+}
+
+// AccessLogMiddleware
+// Log every request once it is handled, with its status, the size of the response
+// and its latency. It must be used after RequestContextMiddleware to be correlated
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start    = time.Now()
+			recorder = httputil.NewResponseRecorder(w)
+		)
+		next.ServeHTTP(recorder, r)
+
+		log := logger.FromContext(r.Context()).Info
+		if recorder.Status() >= http.StatusInternalServerError {
+			log = logger.FromContext(r.Context()).Error
+		}
+		log("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.Status(),
+			"bytes", recorder.Bytes(),
+			"latency", time.Since(start),
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		)
+	})
+}
+
+// Authenticator
+// Identify the caller of a request from its credentials. ok is false when the
+// request does not carry the kind of credentials handled by the authenticator
+type Authenticator func(r *http.Request) (principal auth.Principal, ok bool, err error)
+
+// APIKeyAuthenticator
+// Authenticate the requests with the api key of their X-API-Key header
+func APIKeyAuthenticator(repo apikeys.Repository) Authenticator {
+	return func(r *http.Request) (auth.Principal, bool, error) {
+		key := r.Header.Get(APIKeyHeader)
+		if key == "" {
+			return auth.Principal{}, false, nil
+		}
+		found, err := repo.GetOneByHash(r.Context(), apikeys.HashKey(key))
+		if errors.Is(err, domainerror.ErrResourceNotFound) {
+			return auth.Principal{}, true, domainerror.WrapError(domainerror.ErrUnauthenticated, errors.New("invalid api key"))
+		}
+		if err != nil {
+			return auth.Principal{}, true, err
+		}
+		if found.Revoked() {
+			return auth.Principal{}, true, domainerror.WrapError(domainerror.ErrUnauthenticated, errors.New("api key is revoked"))
+		}
+		return found.Principal(), true, nil
+	}
+}
+
+// BearerAuthenticator
+// Authenticate the requests with the token of their Authorization header
+func BearerAuthenticator(verifier *jwtauth.Verifier) Authenticator {
+	return func(r *http.Request) (auth.Principal, bool, error) {
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return auth.Principal{}, false, nil
+		}
+		principal, err := verifier.Verify(r.Context(), token)
+		return principal, true, err
+	}
+}
+
+// AuthMiddleware
+// Authenticate the requests of the operations having a security requirement with
+// the first authenticator finding credentials in them, and store their principal
+// in the request context. What a principal may do is checked by the usecases.
+// It is meant to be given as a handler middleware of the generated router
+func AuthMiddleware(authenticators ...Authenticator) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, apiKey := r.Context().Value(ApiKeyAuthScopes).([]string)
+			_, bearer := r.Context().Value(BearerAuthScopes).([]string)
+			if !apiKey && !bearer {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for _, authenticate := range authenticators {
+				principal, ok, err := authenticate(r)
+				if !ok {
+					continue
+				}
+				if err != nil {
+					HandleErrorResponse(w, err)
+					return
+				}
+				// The actor claimed by the client is only trusted without credentials
+				ctx := reqcontext.WithPrincipal(r.Context(), principal)
+				next.ServeHTTP(w, r.WithContext(WithActor(ctx, principal.ID())))
+				return
+			}
+			HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrUnauthenticated, errors.New("credentials are required")))
+		})
+	}
+}
+
+// RateLimitMiddleware
+// Limit the requests of each client, identified by its principal or else by its ip,
+// with the read or the write budget according to the usecase of the operation, the
+// routes being served under baseURL. The RateLimit-* headers are sent back, along
+// with Retry-After once the client is limited. Requests are let through when the
+// limiter fails.
+// It is meant to be given as a handler middleware of the generated router, run
+// once the requests are authenticated (see AuthMiddleware)
+func RateLimitMiddleware(limiter *ratelimit.Limiter, baseURL string) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := ratelimit.Write
+			if readOnlyOperation(r, baseURL) {
+				class = ratelimit.Read
+			}
+
+			res, err := limiter.Take(r.Context(), rateLimitClient(r), class)
+			if err != nil {
+				logger.FromContext(r.Context()).Errorf("cannot rate limit request: %s", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(res.Limit))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(res.Remaining))
+			w.Header().Set(RateLimitResetHeader, strconv.Itoa(ceilSeconds(res.Reset)))
+			w.Header().Set(RateLimitPolicyHeader, limiter.Budget(class).String())
+			if !res.Allowed {
+				w.Header().Set(RetryAfterHeader, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrTooManyRequests, fmt.Errorf("%s budget exceeded", class)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitClient
+// The credentials are only trusted once authenticated: a request which is anonymous,
+// or whose operation does not check them, is identified by its ip
+func rateLimitClient(r *http.Request) string {
+	if principal, ok := reqcontext.Principal(r.Context()); ok {
+		return "principal:" + principal.ID()
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,69 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestContentNegotiation(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+				BaseURL:    "/v1",
+				BaseRouter: mux.NewRouter(),
+			})
+			ta   = tdhttp.NewTestAPI(t, h)
+			body = api.Breed{
+				Name:    "negotiated",
+				Species: api.Cat,
+				PetSize: api.Small,
+			}
+		)
+
+		ta.Name("invalid case -- not acceptable before executing the usecase").
+			PostJSON("/v1/breeds", body, http.Header{api.AcceptHeader: {"application/xml"}}).
+			CmpStatus(http.StatusNotAcceptable).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.HasPrefix(domainerror.ErrNotAcceptable.Error())))
+		ta.Get("/v1/breeds/name/negotiated").
+			CmpStatus(http.StatusNotFound)
+
+		ta.Name("valid case -- created as yaml").
+			PostJSON("/v1/breeds", body, http.Header{api.AcceptHeader: {"application/yaml"}}).
+			CmpStatus(http.StatusCreated).
+			CmpHeader(td.SuperMapOf(http.Header{
+				api.ContentTypeHeader: {"application/yaml"},
+				api.VaryHeader:        {api.AcceptHeader},
+			}, nil)).
+			CmpBody(td.Contains("name: negotiated\n"))
+
+		ta.Name("valid case -- json by default").Get("/v1/breeds/name/negotiated").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{api.ContentTypeHeader: {"application/json"}}, nil)).
+			CmpJSONBody(td.SuperJSONOf(`{"name": "negotiated"}`))
+		ta.Name("valid case -- list as csv").Get("/v1/breeds?species=cat", http.Header{api.AcceptHeader: {"text/csv, application/json;q=0.9"}}).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{api.ContentTypeHeader: {"text/csv"}}, nil)).
+			CmpBody("average_female_adult_weight,average_male_adult_weight,name,pet_size,species\n0,0,negotiated,small,cat\n")
+
+		var etag string
+		ta.Name("valid case -- one etag by representation").Get("/v1/breeds/name/negotiated", http.Header{api.AcceptHeader: {"application/msgpack"}}).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{api.ContentTypeHeader: {"application/msgpack"}}, td.MapEntries{
+				http.CanonicalHeaderKey(api.ETagHeader): td.Bag(td.Catch(&etag, td.NotEmpty())),
+			}))
+		ta.Get("/v1/breeds/name/negotiated", http.Header{api.AcceptHeader: {"application/msgpack"}, api.IfNoneMatchHeader: {etag}}).
+			CmpStatus(http.StatusNotModified)
+		ta.Get("/v1/breeds/name/negotiated", http.Header{api.IfNoneMatchHeader: {etag}}).
+			CmpStatus(http.StatusOK)
+	})
+}
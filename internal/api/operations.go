@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// operations
+// Usecase executed by each operation, by method and path relative to the base url.
+// Every route of the generated router but the GraphQL one must have its entry
+var operations = map[string]usecases.UseCaseInfo{
+	"GET /breeds":                                                 {Action: usecases.ActionList, Name: usecases.BreedUsecase},
+	"POST /breeds":                                                {Action: usecases.ActionCreate, Name: usecases.BreedUsecase},
+	"GET /breeds/events":                                          {Action: usecases.ActionList, Name: usecases.BreedUsecase},
+	"DELETE /breeds/name/{breed_name}":                            {Action: usecases.ActionDelete, Name: usecases.BreedUsecase},
+	"GET /breeds/name/{breed_name}":                               {Action: usecases.ActionRetrieve, Name: usecases.BreedUsecase},
+	"PUT /breeds/name/{breed_name}":                               {Action: usecases.ActionUpdate, Name: usecases.BreedUsecase},
+	"GET /breeds/name/{breed_name}/history":                       {Action: usecases.ActionHistory, Name: usecases.BreedUsecase},
+	"POST /breeds/name/{breed_name}/restore":                      {Action: usecases.ActionRestore, Name: usecases.BreedUsecase},
+	"GET /breeds/name/{breed_name}/versions":                      {Action: usecases.ActionListVersions, Name: usecases.BreedUsecase},
+	"GET /breeds/name/{breed_name}/versions/diff":                 {Action: usecases.ActionDiff, Name: usecases.BreedUsecase},
+	"GET /webhooks":                                               {Action: usecases.ActionList, Name: usecases.WebhookUsecase},
+	"POST /webhooks":                                              {Action: usecases.ActionCreate, Name: usecases.WebhookUsecase},
+	"DELETE /webhooks/{webhook_id}":                               {Action: usecases.ActionDelete, Name: usecases.WebhookUsecase},
+	"GET /webhooks/{webhook_id}":                                  {Action: usecases.ActionRetrieve, Name: usecases.WebhookUsecase},
+	"PUT /webhooks/{webhook_id}":                                  {Action: usecases.ActionUpdate, Name: usecases.WebhookUsecase},
+	"GET /webhooks/{webhook_id}/deliveries":                       {Action: usecases.ActionListDeliveries, Name: usecases.WebhookUsecase},
+	"POST /webhooks/{webhook_id}/deliveries/{delivery_id}/replay": {Action: usecases.ActionReplay, Name: usecases.WebhookUsecase},
+}
+
+// graphqlOperation
+// Operation executing GraphQL documents, which are classified by their kind
+const graphqlOperation = "POST /graphql"
+
+// OperationUsecase
+// Usecase executed by the operation matched by the request, the routes being served
+// under baseURL. It must be called once the route is matched, e.g. from a handler middleware
+func OperationUsecase(r *http.Request, baseURL string) (usecases.UseCaseInfo, bool) {
+	info, ok := operations[operation(r, baseURL)]
+	return info, ok
+}
+
+// operation
+// Method and path relative to baseURL of the route matched by the request
+func operation(r *http.Request, baseURL string) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil || !strings.HasPrefix(tpl, baseURL) {
+		return ""
+	}
+	return r.Method + " " + strings.TrimPrefix(tpl, baseURL)
+}
+
+// readOnlyOperation
+// Tell whether the operation matched by the request changes nothing. The operations
+// without usecase are classified by their method
+func readOnlyOperation(r *http.Request, baseURL string) bool {
+	op := operation(r, baseURL)
+	if op == graphqlOperation {
+		return readOnlyGraphQL(r)
+	}
+	if info, ok := operations[op]; ok {
+		return info.ReadOnly()
+	}
+	return r.Method == http.MethodGet
+}
+
+// readOnlyGraphQL
+// Tell whether the GraphQL document of the request is a query. The body is left for
+// the handler, which refuses the documents which cannot be parsed
+func readOnlyGraphQL(r *http.Request) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var params struct {
+		Query         string `json:"query"`
+		OperationName string `json:"operationName"`
+	}
+	if err := json.Unmarshal(body, &params); err != nil {
+		return false
+	}
+	doc, err := parser.ParseQuery(&ast.Source{Input: params.Query})
+	if err != nil {
+		return false
+	}
+	op := doc.Operations.ForName(params.OperationName)
+	return op != nil && op.Operation == ast.Query
+}
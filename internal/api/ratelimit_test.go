@@ -0,0 +1,179 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/graphqlapi"
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	apikeysUsecase "github.com/japhy-tech/backend-test/internal/usecases/apikeys"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newRateLimitLimiter() *ratelimit.Limiter {
+	return ratelimit.New(
+		ratelimit.Budget{Limit: 2, Period: time.Minute},
+		ratelimit.Budget{Limit: 1, Period: time.Minute},
+	)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+				BaseURL:    "/v1",
+				BaseRouter: mux.NewRouter(),
+				Middlewares: []api.MiddlewareFunc{
+					api.RateLimitMiddleware(newRateLimitLimiter(), "/v1"),
+					api.AuthMiddleware(api.APIKeyAuthenticator(datastore.APIKeys())),
+				},
+			})
+			ta     = tdhttp.NewTestAPI(t, h)
+			keys   = map[string]http.Header{}
+			create = usecases.New(&apikeysUsecase.CreateOne{}, datastore)
+		)
+		for _, name := range []string{"first", "second"} {
+			res, err := create.Handle(ctx, apikeysUsecase.CreateOneOpts{Name: name, Scopes: []string{"admin"}})
+			require.CmpNoError(err)
+			keys[name] = http.Header{api.APIKeyHeader: {res.Key}}
+		}
+
+		ta.Name("valid case -- read budget").Get("/v1/breeds", keys["first"]).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{
+				http.CanonicalHeaderKey(api.RateLimitLimitHeader):     {"2"},
+				http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"1"},
+				http.CanonicalHeaderKey(api.RateLimitResetHeader):     {"30"},
+				http.CanonicalHeaderKey(api.RateLimitPolicyHeader):    {"2;w=60"},
+			}, nil))
+		ta.Get("/v1/breeds/name/unknown", keys["first"]).
+			CmpStatus(http.StatusNotFound).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"0"}}, nil))
+		ta.Name("invalid case -- read budget exceeded").Get("/v1/breeds", keys["first"]).
+			CmpStatus(http.StatusTooManyRequests).
+			CmpHeader(td.SuperMapOf(http.Header{
+				http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"0"},
+				http.CanonicalHeaderKey(api.RetryAfterHeader):         {"30"},
+			}, nil)).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.HasPrefix(domainerror.ErrTooManyRequests.Error())))
+
+		ta.Name("valid case -- write budget is distinct").Delete("/v1/breeds/name/unknown", nil, keys["first"]).
+			CmpStatus(http.StatusNotFound).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitLimitHeader): {"1"}, http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"0"}}, nil))
+		ta.Name("invalid case -- write budget exceeded").Delete("/v1/breeds/name/unknown", nil, keys["first"]).
+			CmpStatus(http.StatusTooManyRequests).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RetryAfterHeader): {"60"}}, nil))
+
+		ta.Name("valid case -- budgets are distinct by principal").Get("/v1/breeds", keys["second"]).
+			CmpStatus(http.StatusOK)
+		ta.Name("invalid case -- unknown keys are not limited apart").Get("/v1/breeds", api.APIKeyHeader, "bk_unknown").
+			CmpStatus(http.StatusUnauthorized).
+			CmpHeader(td.Not(td.ContainsKey(http.CanonicalHeaderKey(api.RateLimitLimitHeader))))
+	})
+}
+
+func TestRateLimitMiddleware_Anonymous(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+				BaseURL:     "/v1",
+				BaseRouter:  mux.NewRouter(),
+				Middlewares: []api.MiddlewareFunc{api.RateLimitMiddleware(newRateLimitLimiter(), "/v1")},
+			})
+			ta = tdhttp.NewTestAPI(t, h)
+		)
+
+		ta.Name("valid case -- by ip").Get("/v1/breeds", api.APIKeyHeader, "bk_first").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"1"}}, nil))
+		ta.Name("valid case -- unchecked keys share the ip budget").Get("/v1/breeds", api.APIKeyHeader, "bk_second").
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitRemainingHeader): {"0"}}, nil))
+		ta.Name("invalid case -- ip budget exceeded").Get("/v1/breeds", api.APIKeyHeader, "bk_third").
+			CmpStatus(http.StatusTooManyRequests)
+	})
+}
+
+func TestRateLimitMiddleware_GraphQL(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore,
+				api.WithGraphQL(graphqlapi.New(logger, datastore)),
+			), api.GorillaServerOptions{
+				BaseURL:     "/v1",
+				BaseRouter:  mux.NewRouter(),
+				Middlewares: []api.MiddlewareFunc{api.RateLimitMiddleware(newRateLimitLimiter(), "/v1")},
+			})
+			ta = tdhttp.NewTestAPI(t, h)
+		)
+
+		ta.Name("valid case -- queries are reads").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `{ breeds { edges { node { name } } } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitLimitHeader): {"2"}}, nil)).
+			CmpJSONBody(td.SuperJSONOf(`{"data": {"breeds": {"edges": []}}}`))
+		ta.Name("valid case -- mutations are writes").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query: `mutation { createBreed(input: {name: "gqlrate", species: DOG, petSize: TALL}) { name } }`,
+		}).
+			CmpStatus(http.StatusOK).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitLimitHeader): {"1"}}, nil))
+		ta.Name("valid case -- named operation").PostJSON("/v1/graphql", api.GraphQLRequest{
+			Query:         `query list { breeds { edges { node { name } } } } mutation remove { deleteBreed(name: "gqlrate") }`,
+			OperationName: common.ToPointer("remove"),
+		}).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RateLimitLimitHeader): {"1"}}, nil))
+	})
+}
+
+func TestOperationUsecase(t *testing.T) {
+	var (
+		require = td.Require(t)
+		router  = mux.NewRouter()
+		matched = map[string]bool{}
+		h       = api.HandlerWithOptions(nil, api.GorillaServerOptions{
+			BaseURL:    "/v1",
+			BaseRouter: router,
+			Middlewares: []api.MiddlewareFunc{func(http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					tpl, _ := mux.CurrentRoute(r).GetPathTemplate()
+					_, matched[r.Method+" "+tpl] = api.OperationUsecase(r, "/v1")
+					w.WriteHeader(http.StatusNoContent)
+				})
+			}},
+		})
+		vars = regexp.MustCompile(`\{[^}]+\}`)
+	)
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		require.CmpNoError(err)
+		methods, err := route.GetMethods()
+		require.CmpNoError(err)
+		for _, method := range methods {
+			// With the required query parameters, checked before the middlewares
+			req, err := http.NewRequest(method, vars.ReplaceAllString(tpl, "1")+"?from=1&to=2", nil)
+			require.CmpNoError(err)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if method+" "+tpl == "POST /v1/graphql" {
+				continue
+			}
+			require.True(matched[method+" "+tpl], "valid case -- usecase of %s %s", method, tpl)
+		}
+		return nil
+	})
+	require.CmpNoError(err)
+}
@@ -6,12 +6,16 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	charmLog "github.com/charmbracelet/log"
 	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
 	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/encoders"
 	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/sse"
 	"github.com/japhy-tech/backend-test/internal/usecases"
 	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
 )
@@ -21,29 +25,65 @@ import (
 type Server struct {
 	logger    *charmLog.Logger
 	datastore gateways.IDatastore
+	// usecaseOpts configure every usecase executed by the server
+	usecaseOpts []usecases.Option
+	broker      *sse.Broker
+	// cacheControl of the cacheable operations, by method and path relative to the base url
+	cacheControl map[string]string
+	// encoders the responses are negotiated in
+	encoders *encoders.Registry
+	// graphql executes the GraphQL operations
+	graphql http.Handler
 }
 
 type Response[T any] struct {
 	Status int
 	Val    T
+	// Validators make the response cacheable, answering the conditional requests
+	Validators *Validators
 }
 
 // List breeds
 // (GET /breeds)
 func (s Server) ListBreeds(w http.ResponseWriter, r *http.Request, params ListBreedsParams) {
-	EndpointDecorator(w, r, func(ctx context.Context) (*Response[[]Breed], error) {
-		res, err := usecases.New(&breedsUsecase.List{}, s.datastore).Handle(ctx, breedsUsecase.ListOpts{
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[any], error) {
+		opts := breedsUsecase.ListOpts{
 			Species:             (*string)(params.Species),
 			AverageFemaleWeight: params.AverageFemaleAdultWeight,
 			AverageMaleWeight:   params.AverageMaleAdultWeight,
 			PetSize:             (*string)(params.PetSize),
-		})
+			IncludeDeleted:      params.IncludeDeleted != nil && *params.IncludeDeleted,
+			AsOf:                params.AsOf,
+		}
+		if params.Fields != nil {
+			opts.Fields = *params.Fields
+		}
+		if params.Include != nil {
+			opts.Include = *params.Include
+		}
+		res, err := usecases.New(&breedsUsecase.List{}, s.datastore, s.usecaseOpts...).Handle(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
-		return &Response[[]Breed]{
-			Val:    common.Map(res, func(val *breeds.Breed) Breed { return BreedToJson(val) }),
+
+		var lastModified time.Time
+		for _, val := range res {
+			if val.UpdatedAt().After(lastModified) {
+				lastModified = val.UpdatedAt()
+			}
+		}
+
+		var val any = common.Map(res, func(val *breeds.Breed) Breed { return BreedToJson(val) })
+		if len(opts.Fields) > 0 {
+			val = common.Map(res, func(val *breeds.Breed) SparseBreed { return BreedToSparseJson(val, opts.Fields) })
+		}
+		return &Response[any]{
+			Val:    val,
 			Status: http.StatusOK,
+			Validators: &Validators{
+				LastModified: lastModified,
+				CacheControl: s.cacheControl["GET /breeds"],
+			},
 		}, nil
 	})
 }
@@ -51,13 +91,13 @@ func (s Server) ListBreeds(w http.ResponseWriter, r *http.Request, params ListBr
 // Create one breed
 // (POST /breeds)
 func (s Server) CreateOneBreed(w http.ResponseWriter, r *http.Request) {
-	EndpointDecorator(w, r, func(ctx context.Context) (*Response[Breed], error) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Breed], error) {
 		body, err := Bind[Breed](r)
 		if err != nil {
 			return nil, err
 		}
 
-		res, err := usecases.New(&breedsUsecase.CreateOne{}, s.datastore).Handle(ctx, breeds.FactoryOpts{
+		res, err := usecases.New(&breedsUsecase.CreateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, breeds.FactoryOpts{
 			Name:                body.Name,
 			Species:             string(body.Species),
 			PetSize:             string(body.PetSize),
@@ -77,7 +117,7 @@ func (s Server) CreateOneBreed(w http.ResponseWriter, r *http.Request) {
 // Delete a given breed by its name
 // (DELETE /breeds/name/{breed_name})
 func (s Server) DeleteBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
-	err := usecases.NewSimple(&breedsUsecase.DeleteOneByName{}, s.datastore).Handle(r.Context(), breedName)
+	err := usecases.NewSimple(&breedsUsecase.DeleteOneByName{}, s.datastore, s.usecaseOpts...).Handle(r.Context(), breedName)
 	if err != nil {
 		HandleErrorResponse(w, err)
 		return
@@ -87,9 +127,21 @@ func (s Server) DeleteBreedByName(w http.ResponseWriter, r *http.Request, breedN
 
 // Retrieve a given breed by its name
 // (GET /breeds/name/{breed_name})
-func (s Server) GetBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
-	EndpointDecorator(w, r, func(ctx context.Context) (*Response[Breed], error) {
-		res, err := usecases.New(&breedsUsecase.GetOneByName{}, s.datastore).Handle(ctx, breedName)
+func (s Server) GetBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName, params GetBreedByNameParams) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Breed], error) {
+		var (
+			res *breeds.Breed
+			err error
+		)
+
+		if params.AsOf != nil {
+			res, err = usecases.New(&breedsUsecase.GetOneByNameAsOf{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedsUsecase.GetOneByNameAsOfOpts{
+				Name: breedName,
+				AsOf: *params.AsOf,
+			})
+		} else {
+			res, err = usecases.New(&breedsUsecase.GetOneByName{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedName)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -97,6 +149,10 @@ func (s Server) GetBreedByName(w http.ResponseWriter, r *http.Request, breedName
 		return &Response[Breeds]{
 			Val:    BreedToJson(res),
 			Status: http.StatusOK,
+			Validators: &Validators{
+				LastModified: res.UpdatedAt(),
+				CacheControl: s.cacheControl["GET /breeds/name/{breed_name}"],
+			},
 		}, nil
 	})
 }
@@ -104,7 +160,7 @@ func (s Server) GetBreedByName(w http.ResponseWriter, r *http.Request, breedName
 // Update or create one breed
 // (PUT /breeds/name/{breed_name})
 func (s Server) CreateOrUpdateBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
-	EndpointDecorator(w, r, func(ctx context.Context) (*Response[Breed], error) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Breed], error) {
 		body, err := Bind[Breed](r)
 		if err != nil {
 			return nil, err
@@ -118,14 +174,14 @@ func (s Server) CreateOrUpdateBreedByName(w http.ResponseWriter, r *http.Request
 			AverageMaleWeight:   body.AverageMaleAdultWeight,
 		}
 
-		res, err := usecases.New(&breedsUsecase.CreateOne{}, s.datastore).Handle(ctx, opts)
+		res, err := usecases.New(&breedsUsecase.CreateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, opts)
 		if err == nil {
 			return &Response[Breeds]{
 				Val:    BreedToJson(res),
 				Status: http.StatusCreated,
 			}, nil
 		}
-		res, err = usecases.New(&breedsUsecase.UpdateOne{}, s.datastore).Handle(ctx, opts)
+		res, err = usecases.New(&breedsUsecase.UpdateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -137,16 +193,144 @@ func (s Server) CreateOrUpdateBreedByName(w http.ResponseWriter, r *http.Request
 	})
 }
 
-func New(logger *charmLog.Logger, datastore gateways.IDatastore) *Server {
-	return &Server{
-		logger:    logger,
-		datastore: datastore,
+// Restore a deleted breed
+// (POST /breeds/name/{breed_name}/restore)
+func (s Server) RestoreBreedByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Breed], error) {
+		res, err := usecases.New(&breedsUsecase.RestoreOneByName{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response[Breed]{
+			Val:    BreedToJson(res),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// List the versions of a given breed
+// (GET /breeds/name/{breed_name}/versions)
+func (s Server) ListBreedVersionsByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[[]BreedVersion], error) {
+		res, err := usecases.New(&breedsUsecase.ListVersions{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response[[]BreedVersion]{
+			Val:    common.Map(res, func(val *breeds.Version) BreedVersion { return BreedVersionToJson(val) }),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// Compare two versions of a given breed
+// (GET /breeds/name/{breed_name}/versions/diff)
+func (s Server) DiffBreedVersionsByName(w http.ResponseWriter, r *http.Request, breedName BreedName, params DiffBreedVersionsByNameParams) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[BreedDiff], error) {
+		res, err := usecases.New(&breedsUsecase.DiffVersions{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedsUsecase.DiffVersionsOpts{
+			Name: breedName,
+			From: params.From,
+			To:   params.To,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response[BreedDiff]{
+			Val: BreedDiff{
+				From: params.From,
+				To:   params.To,
+				Changes: common.Map(res, func(val breeds.FieldChange) FieldChange {
+					return FieldChange{Field: val.Field, From: val.From, To: val.To}
+				}),
+			},
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// Retrieve the history of a given breed
+// (GET /breeds/name/{breed_name}/history)
+func (s Server) GetBreedHistoryByName(w http.ResponseWriter, r *http.Request, breedName BreedName) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[BreedHistory], error) {
+		res, err := usecases.New(&breedsUsecase.History{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response[BreedHistory]{
+			Val:    common.Map(res, func(val *audit.Entry) AuditEntry { return AuditEntryToJson(val) }),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// ServerOption
+// Configure the Server built with New
+type ServerOption func(*Server)
+
+// WithUsecaseOptions
+// Build the usecases with the given options, e.g. the interceptors executing them
+func WithUsecaseOptions(opts ...usecases.Option) ServerOption {
+	return func(s *Server) {
+		s.usecaseOpts = append(s.usecaseOpts, opts...)
+	}
+}
+
+// WithBroker
+// Serve the breeds changes stream from the given broker
+func WithBroker(broker *sse.Broker) ServerOption {
+	return func(s *Server) {
+		s.broker = broker
+	}
+}
+
+// WithCacheControl
+// Send the given Cache-Control with the responses of a cacheable operation, formatted
+// as "METHOD /path" relative to the base url, e.g. "GET /breeds". DefaultCacheControl
+// is sent otherwise
+func WithCacheControl(operation, value string) ServerOption {
+	return func(s *Server) {
+		s.cacheControl[operation] = value
+	}
+}
+
+// WithEncoders
+// Negotiate the responses among the given encoders instead of encoders.Default
+func WithEncoders(registry *encoders.Registry) ServerOption {
+	return func(s *Server) {
+		s.encoders = registry
+	}
+}
+
+// WithGraphQL
+// Execute the GraphQL operations with the given handler (see graphqlapi.New)
+func WithGraphQL(handler http.Handler) ServerOption {
+	return func(s *Server) {
+		s.graphql = handler
+	}
+}
+
+func New(logger *charmLog.Logger, datastore gateways.IDatastore, opts ...ServerOption) *Server {
+	s := &Server{
+		logger:       logger,
+		datastore:    datastore,
+		cacheControl: map[string]string{},
+		encoders:     encoders.Default(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func Bind[T any](r *http.Request) (T, error) {
 	var body T
-	err := json.NewDecoder(r.Body).Decode(&body)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&body)
 	if err != nil {
 		return body, domainerror.WrapError(domainerror.ErrDomainValidation, err)
 	}
@@ -160,6 +344,11 @@ func HandleErrorResponse(w http.ResponseWriter, err error) {
 		domainerror.ErrResourceAlreadyExists.Error(): http.StatusConflict,
 		domainerror.ErrNothingTodo.Error():           http.StatusNoContent,
 		domainerror.ErrResourceNotFound.Error():      http.StatusNotFound,
+		domainerror.ErrUnauthenticated.Error():       http.StatusUnauthorized,
+		domainerror.ErrForbidden.Error():             http.StatusForbidden,
+		domainerror.ErrTooManyRequests.Error():       http.StatusTooManyRequests,
+		domainerror.ErrNotAcceptable.Error():         http.StatusNotAcceptable,
+		domainerror.ErrUnprocessableEntity.Error():   http.StatusUnprocessableEntity,
 	}
 
 	if strings.Contains(err.Error(), "EOF") {
@@ -189,14 +378,81 @@ func BreedToJson(domain *breeds.Breed) Breed {
 		PetSize:                  PetSize(domain.PetSize().String()),
 		AverageFemaleAdultWeight: common.ToPointer(domain.AverageFemaleWeight()),
 		AverageMaleAdultWeight:   common.ToPointer(domain.AverageMaleWeight()),
+		DeletedAt:                domain.DeletedAt(),
+	}
+}
+
+// SparseBreed
+// Breed restricted to the fields requested by the client
+type SparseBreed map[string]any
+
+// BreedToSparseJson
+// Convert the breed keeping its name and the given fields only, which must be
+// valid breeds.Field
+func BreedToSparseJson(domain *breeds.Breed, fields []string) SparseBreed {
+	full := BreedToJson(domain)
+	res := SparseBreed{string(breeds.FieldName): full.Name}
+	for _, field := range fields {
+		switch breeds.Field(field) {
+		case breeds.FieldSpecies:
+			res[field] = full.Species
+		case breeds.FieldPetSize:
+			res[field] = full.PetSize
+		case breeds.FieldAverageFemaleWeight:
+			res[field] = full.AverageFemaleAdultWeight
+		case breeds.FieldAverageMaleWeight:
+			res[field] = full.AverageMaleAdultWeight
+		case breeds.FieldDeletedAt:
+			if full.DeletedAt != nil {
+				res[field] = full.DeletedAt
+			}
+		}
 	}
+	return res
 }
 
-func EndpointDecorator[Output any](w http.ResponseWriter, r *http.Request, fn func(context.Context) (*Response[Output], error)) {
+func BreedVersionToJson(domain *breeds.Version) BreedVersion {
+	return BreedVersion{
+		Version:   domain.Number(),
+		ValidFrom: domain.ValidFrom(),
+		ValidTo:   domain.ValidTo(),
+		Breed:     BreedToJson(domain.Breed()),
+	}
+}
+
+func AuditEntryToJson(domain *audit.Entry) AuditEntry {
+	res := AuditEntry{
+		BreedName: domain.BreedName().String(),
+		Operation: AuditEntryOperation(domain.Operation().String()),
+		Usecase:   domain.Usecase(),
+		Actor:     domain.Actor(),
+		RequestId: domain.RequestID(),
+		CreatedAt: domain.CreatedAt(),
+	}
+	if domain.Before() != nil {
+		res.Before = common.ToPointer(BreedToJson(domain.Before()))
+	}
+	if domain.After() != nil {
+		res.After = common.ToPointer(BreedToJson(domain.After()))
+	}
+	return res
+}
+
+func EndpointDecorator[Output any](w http.ResponseWriter, r *http.Request, registry *encoders.Registry, fn func(context.Context) (*Response[Output], error)) {
+	// The representation is negotiated before the usecase is executed, not to
+	// execute it for nothing
+	enc, err := registry.Negotiate(r.Header.Get(AcceptHeader))
+	if err != nil {
+		HandleErrorResponse(w, err)
+		return
+	}
+
 	res, err := fn(r.Context())
 	if err != nil {
 		HandleErrorResponse(w, err)
-	} else {
-		SendJSON(w, res.Val, res.Status)
+		return
+	}
+	if err := Send(w, r, enc, res.Val, res.Status, res.Validators); err != nil {
+		HandleErrorResponse(w, domainerror.WrapError(domainerror.ErrInternalError, err))
 	}
 }
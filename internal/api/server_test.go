@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	charmLog "github.com/charmbracelet/log"
 	"github.com/gorilla/mux"
@@ -576,3 +578,218 @@ func TestServer_ListBreeds(t *testing.T) {
 		}
 	})
 }
+
+func TestServer_ListBreeds_Fields(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			r  = mux.NewRouter()
+			h  = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), r, "/v1")
+			ta = tdhttp.NewTestAPI(t, h)
+		)
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test_dog",
+			Species:             values.Dog.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(10),
+			AverageMaleWeight:   common.ToPointer(3),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().CreateOne(ctx, b)
+		require.CmpNoError(err)
+
+		ta.Name("valid case -- name and species").Get("/v1/breeds?fields=name,species").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[{"name": "test_dog", "species": "dog"}]`))
+		ta.Name("valid case -- name always sent").Get("/v1/breeds?fields=average_male_adult_weight&species=dog").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[{"name": "test_dog", "average_male_adult_weight": 3}]`))
+		ta.Name("invalid case -- unknown field").Get("/v1/breeds?fields=name,color").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.Contains(breeds.ErrInvalidField.Error())))
+		ta.Name("invalid case -- unknown relation").Get("/v1/breeds?include=aliases").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.Contains(breeds.ErrInvalidRelation.Error())))
+	})
+}
+
+func TestServer_GetBreedHistoryByName(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			r     = mux.NewRouter()
+			h     = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), r, "/v1")
+			ta    = tdhttp.NewTestAPI(t, h)
+			breed = api.Breed{
+				Name:                     "test",
+				Species:                  api.Species(values.Cat.String()),
+				PetSize:                  api.PetSize(values.Medium.String()),
+				AverageFemaleAdultWeight: common.ToPointer(1),
+				AverageMaleAdultWeight:   common.ToPointer(1),
+			}
+		)
+		r.Use(api.RequestContextMiddleware)
+
+		ta.PostJSON("/v1/breeds", breed, http.Header{api.ActorHeader: {"back_office"}, api.RequestIDHeader: {"first"}}).
+			CmpStatus(http.StatusCreated).
+			CmpHeader(td.SuperMapOf(http.Header{http.CanonicalHeaderKey(api.RequestIDHeader): {"first"}}, nil))
+		ta.Delete("/v1/breeds/name/test", nil).
+			CmpStatus(http.StatusNoContent).
+			CmpHeader(td.ContainsKey(http.CanonicalHeaderKey(api.RequestIDHeader)))
+
+		ta.Name("valid case").Get("/v1/breeds/name/test/history").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[
+				{
+					"breed_name": "test",
+					"operation":  "create",
+					"usecase":    $createUsecase,
+					"actor":      "back_office",
+					"request_id": "first",
+					"created_at": $createdAt,
+					"after":      $breed,
+				},
+				{
+					"breed_name": "test",
+					"operation":  "delete",
+					"usecase":    $deleteUsecase,
+					"actor":      $anonymous,
+					"request_id": $requestID,
+					"created_at": $createdAt,
+					"before":     $breed,
+					"after":      $deletedBreed,
+				},
+			]`,
+				td.Tag("createUsecase", breedUsecases.CreateOne{}.Info().String()),
+				td.Tag("deleteUsecase", breedUsecases.DeleteOneByName{}.Info().String()),
+				td.Tag("anonymous", api.AnonymousActor),
+				td.Tag("requestID", td.NotEmpty()),
+				td.Tag("createdAt", td.Smuggle(parseTime, td.Ignore())),
+				td.Tag("breed", breed),
+				td.Tag("deletedBreed", td.SuperJSONOf(`{"name": "test", "deleted_at": $1}`, td.NotEmpty())),
+			))
+
+		ta.Name("valid case -- no history").Get("/v1/breeds/name/not_found/history").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody([]api.AuditEntry{})
+
+		ta.Name("invalid case -- invalid name").Get("/v1/breeds/name/o/history").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $message}`, td.Tag("message", td.Contains(values.ErrNameToShort.Error()))))
+	})
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func TestServer_RestoreBreedByName(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			r     = mux.NewRouter()
+			h     = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), r, "/v1")
+			ta    = tdhttp.NewTestAPI(t, h)
+			breed = api.Breed{
+				Name:                     "test",
+				Species:                  api.Species(values.Cat.String()),
+				PetSize:                  api.PetSize(values.Medium.String()),
+				AverageFemaleAdultWeight: common.ToPointer(1),
+				AverageMaleAdultWeight:   common.ToPointer(1),
+			}
+		)
+
+		ta.PostJSON("/v1/breeds", breed).CmpStatus(http.StatusCreated)
+		ta.Delete("/v1/breeds/name/test", nil).CmpStatus(http.StatusNoContent)
+
+		ta.Name("deleted breed is hidden").Get("/v1/breeds").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody([]api.Breed{})
+		ta.Name("deleted breed is listed with include_deleted").Get("/v1/breeds?include_deleted=true").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[$1]`, td.SuperJSONOf(`{"name": "test", "deleted_at": $1}`, td.NotEmpty())))
+		ta.Name("deleted breed is not found").Get("/v1/breeds/name/test").
+			CmpStatus(http.StatusNotFound)
+
+		ta.Name("valid case").Post("/v1/breeds/name/test/restore", nil).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(breed)
+		ta.Name("invalid case -- not deleted").Post("/v1/breeds/name/test/restore", nil).
+			CmpStatus(http.StatusNoContent)
+		ta.Name("invalid case -- not found").Post("/v1/breeds/name/not_found/restore", nil).
+			CmpStatus(http.StatusNotFound).
+			CmpJSONBody(td.JSON(`{"message": $message}`, td.Tag("message", td.Contains(domainerror.ErrResourceNotFound.Error()))))
+
+		ta.Name("restored breed is retrieved").Get("/v1/breeds/name/test").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(breed)
+	})
+}
+
+func TestServer_BreedVersions(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			r     = mux.NewRouter()
+			h     = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), r, "/v1")
+			ta    = tdhttp.NewTestAPI(t, h)
+			breed = api.Breed{
+				Name:                     "test",
+				Species:                  api.Species(values.Cat.String()),
+				PetSize:                  api.PetSize(values.Medium.String()),
+				AverageFemaleAdultWeight: common.ToPointer(1),
+				AverageMaleAdultWeight:   common.ToPointer(1),
+			}
+			updated = api.Breed{
+				Name:                     "test",
+				Species:                  api.Species(values.Cat.String()),
+				PetSize:                  api.PetSize(values.Tall.String()),
+				AverageFemaleAdultWeight: common.ToPointer(1),
+				AverageMaleAdultWeight:   common.ToPointer(2),
+			}
+		)
+
+		ta.PostJSON("/v1/breeds", breed).CmpStatus(http.StatusCreated)
+		ta.PutJSON("/v1/breeds/name/test", updated).CmpStatus(http.StatusOK)
+
+		versions, err := datastore.Breeds().ListVersions(ctx, "test")
+		require.CmpNoError(err)
+		require.Cmp(versions, td.Len(2))
+		current := url.QueryEscape(versions[1].ValidFrom().Format(time.RFC3339))
+		past := url.QueryEscape(versions[0].ValidFrom().Add(-time.Hour).Format(time.RFC3339))
+
+		ta.Name("list versions").Get("/v1/breeds/name/test/versions").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[
+				{"version": 1, "valid_from": $1, "valid_to": $1, "breed": $2},
+				{"version": 2, "valid_from": $1, "breed": $3},
+			]`, td.NotEmpty(), breed, updated))
+
+		ta.Name("get as of current version").Get("/v1/breeds/name/test?as_of=" + current).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(updated)
+		ta.Name("get as of before creation").Get("/v1/breeds/name/test?as_of=" + past).
+			CmpStatus(http.StatusNotFound)
+		ta.Name("get as of invalid date").Get("/v1/breeds/name/test?as_of=invalid").
+			CmpStatus(http.StatusBadRequest)
+
+		ta.Name("list as of current version").Get("/v1/breeds?species=cat&as_of=" + current).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody([]api.Breed{updated})
+		ta.Name("list as of before creation").Get("/v1/breeds?as_of=" + past).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody([]api.Breed{})
+
+		ta.Name("diff versions").Get("/v1/breeds/name/test/versions/diff?from=1&to=2").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{
+				"from": 1,
+				"to": 2,
+				"changes": [
+					{"field": "pet_size", "from": "medium", "to": "tall"},
+					{"field": "average_male_adult_weight", "from": 1, "to": 2},
+				]
+			}`))
+		ta.Name("diff unknown version").Get("/v1/breeds/name/test/versions/diff?from=1&to=3").
+			CmpStatus(http.StatusNotFound)
+		ta.Name("diff without to").Get("/v1/breeds/name/test/versions/diff?from=1").
+			CmpStatus(http.StatusBadRequest)
+	})
+}
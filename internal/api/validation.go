@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+	"github.com/japhy-tech/backend-test/internal/logger"
+)
+
+var ErrInvalidRequest = errors.New("request does not match the specification")
+
+// ResponseReport
+// Called with the responses not matching the specification, e.g. to fail a test
+type ResponseReport func(r *http.Request, err error)
+
+type ValidationOption func(*validationConfig)
+
+type validationConfig struct {
+	report ResponseReport
+}
+
+// WithResponseValidation
+// Validate the JSON responses as well, reporting the violations to report once
+// they are sent. The responses are copied to be validated: it is meant for the
+// tests, or for a staging environment
+func WithResponseValidation(report ResponseReport) ValidationOption {
+	return func(c *validationConfig) {
+		c.report = report
+	}
+}
+
+// LogResponseViolations
+// ResponseReport logging the violations as errors
+func LogResponseViolations(r *http.Request, err error) {
+	logger.FromContext(r.Context()).Error("response does not match the specification", "method", r.Method, "path", r.URL.Path, "error", err)
+}
+
+// ValidationMiddleware
+// Validate the requests against the specification served under baseURL, e.g. from
+// GetSwagger: parameters, bodies and their patterns, bounds, enums and unknown
+// properties. Requests not matching it get a 400 listing the fields in error.
+// Authentication is left to AuthMiddleware, and the defaults of the specification
+// are not set in the requests.
+// It is meant to be given as a handler middleware of the generated router
+func ValidationMiddleware(spec *openapi3.T, baseURL string, opts ...ValidationOption) (MiddlewareFunc, error) {
+	var cfg validationConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// The routes are matched on the path only, whatever the host serving them
+	spec.Servers = openapi3.Servers{{URL: baseURL}}
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		SkipSettingDefaults: true,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// Left to the router, which knows the operations better
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				_ = SendJSON(w, Error{
+					Message: domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidRequest).Error(),
+					Fields:  fieldErrors(err),
+				}, http.StatusBadRequest)
+				return
+			}
+
+			if cfg.report == nil || streams(route) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			recorder := &bodyRecorder{ResponseRecorder: httputil.NewResponseRecorder(w)}
+			next.ServeHTTP(recorder, r)
+
+			// The other media types are renderings of the JSON responses
+			if !strings.HasPrefix(recorder.Header().Get(ContentTypeHeader), "application/json") {
+				return
+			}
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 recorder.Status(),
+				Header:                 recorder.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+				Options:                options,
+			})
+			if err != nil {
+				cfg.report(r, err)
+			}
+		})
+	}, nil
+}
+
+// streams
+// Whether the operation streams its response, which is never complete
+func streams(route *routers.Route) bool {
+	for _, res := range route.Operation.Responses.Map() {
+		if res.Value != nil && res.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldErrors
+// Violations of a request, one by parameter or body property in error
+func fieldErrors(err error) *[]FieldError {
+	var res []FieldError
+
+	// The errors of a parameter or of the body are themselves gathered below
+	if multi, ok := err.(openapi3.MultiError); ok {
+		for _, val := range multi {
+			res = append(res, *fieldErrors(val)...)
+		}
+		return &res
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return &[]FieldError{{Field: "request", Message: err.Error()}}
+	}
+	field := "body"
+	if reqErr.Parameter != nil {
+		field = reqErr.Parameter.Name
+	}
+
+	schemaErrs := openapi3.MultiError{reqErr.Err}
+	errors.As(reqErr.Err, &schemaErrs)
+	for _, val := range schemaErrs {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(val, &schemaErr) {
+			message := reqErr.Error()
+			if val != nil {
+				message = val.Error()
+			}
+			res = append(res, FieldError{Field: field, Message: message})
+			continue
+		}
+		name := field
+		if path := schemaErr.JSONPointer(); reqErr.Parameter == nil && len(path) > 0 {
+			name = strings.Join(path, ".")
+		}
+		res = append(res, FieldError{Field: name, Message: schemaErr.Reason})
+	}
+	return &res
+}
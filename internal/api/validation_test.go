@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// failResponse
+// Fail the test on every response not matching the specification
+func failResponse(t *testing.T) api.ResponseReport {
+	return func(r *http.Request, err error) {
+		t.Errorf("response of %s %s does not match the specification: %s", r.Method, r.URL.Path, err)
+	}
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		spec, err := api.GetSwagger()
+		require.CmpNoError(err)
+		validation, err := api.ValidationMiddleware(spec, "/v1", api.WithResponseValidation(failResponse(t)))
+		require.CmpNoError(err)
+
+		var (
+			h = api.HandlerWithOptions(api.New(logger, datastore), api.GorillaServerOptions{
+				BaseURL:     "/v1",
+				BaseRouter:  mux.NewRouter(),
+				Middlewares: []api.MiddlewareFunc{validation},
+			})
+			ta      = tdhttp.NewTestAPI(t, h)
+			message = domainerror.WrapError(domainerror.ErrDomainValidation, api.ErrInvalidRequest).Error()
+		)
+
+		ta.Name("valid case -- create").PostJSON("/v1/breeds", map[string]any{
+			"name":                      "valid",
+			"species":                   "cat",
+			"pet_size":                  "small",
+			"average_male_adult_weight": 1000,
+		}).
+			CmpStatus(http.StatusCreated)
+		ta.Name("valid case -- get").Get("/v1/breeds/name/valid").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- list").Get("/v1/breeds?species=cat&fields=name,species").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- not found").Get("/v1/breeds/name/unknown").
+			CmpStatus(http.StatusNotFound)
+		ta.Name("valid case -- update").PutJSON("/v1/breeds/name/valid", map[string]any{
+			"name":     "valid",
+			"species":  "dog",
+			"pet_size": "tall",
+		}).
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- versions").Get("/v1/breeds/name/valid/versions").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- diff").Get("/v1/breeds/name/valid/versions/diff?from=1&to=2").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- history").Get("/v1/breeds/name/valid/history").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- delete").Delete("/v1/breeds/name/valid", nil).
+			CmpStatus(http.StatusNoContent)
+		ta.Name("valid case -- list deleted").Get("/v1/breeds?include_deleted=true").
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- restore").Post("/v1/breeds/name/valid/restore", nil).
+			CmpStatus(http.StatusOK)
+		ta.Name("valid case -- create webhook").PostJSON("/v1/webhooks", map[string]any{
+			"url":         "https://crm.example.com/hooks/breeds",
+			"secret":      "0123456789abcdef",
+			"event_types": []string{"breed.created"},
+		}).
+			CmpStatus(http.StatusCreated)
+		ta.Name("valid case -- list webhooks").Get("/v1/webhooks").
+			CmpStatus(http.StatusOK)
+
+		ta.Name("invalid case -- unknown property").PostJSON("/v1/breeds", map[string]any{
+			"name":     "unknown",
+			"species":  "cat",
+			"pet_size": "small",
+			"color":    "black",
+		}).
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": [{"field": "body", "message": Contains("color")}]}`, message))
+		ta.Name("invalid case -- negative weight and unknown enum").PutJSON("/v1/breeds/name/valid", map[string]any{
+			"name":                      "valid",
+			"species":                   "bird",
+			"pet_size":                  "small",
+			"average_male_adult_weight": -1,
+		}).
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": Bag(
+				{"field": "species", "message": Contains("allowed values")},
+				{"field": "average_male_adult_weight", "message": Contains("at least 0")},
+			)}`, message))
+		ta.Name("invalid case -- read only property").PostJSON("/v1/breeds", map[string]any{
+			"name":       "read_only",
+			"species":    "cat",
+			"pet_size":   "small",
+			"deleted_at": "2026-01-01T00:00:00Z",
+		}).
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": [{"field": "body", "message": Contains("readOnly property \"deleted_at\"")}]}`, message))
+		ta.Name("invalid case -- missing body").Post("/v1/breeds", nil, api.ContentTypeHeader, "application/json").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": [{"field": "body", "message": NotEmpty()}]}`, message))
+		ta.Name("invalid case -- path pattern").Get("/v1/breeds/name/Not_Valid").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": [{"field": "breed_name", "message": Contains("regular expression")}]}`, message))
+		ta.Name("invalid case -- query parameters").Get("/v1/breeds?pet_size=huge&average_female_adult_weight=-1").
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1, "fields": Bag(
+				{"field": "pet_size", "message": Contains("allowed values")},
+				{"field": "average_female_adult_weight", "message": Contains("at least 0")},
+			)}`, message))
+	})
+}
+
+func TestValidationMiddleware_Responses(t *testing.T) {
+	var (
+		require  = td.Require(t)
+		reported []error
+	)
+
+	spec, err := api.GetSwagger()
+	require.CmpNoError(err)
+	validation, err := api.ValidationMiddleware(spec, "/v1", api.WithResponseValidation(func(_ *http.Request, err error) {
+		reported = append(reported, err)
+	}))
+	require.CmpNoError(err)
+
+	h := validation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = api.SendJSON(w, map[string]any{"name": "cat", "species": "bird", "pet_size": "small"}, http.StatusOK)
+	}))
+	tdhttp.NewTestAPI(t, h).Name("invalid case -- response is still sent").Get("/v1/breeds/name/cat").
+		CmpStatus(http.StatusOK).
+		CmpJSONBody(td.SuperMapOf(map[string]any{"species": "bird"}, nil))
+	require.Cmp(reported, td.Len(1), "invalid case -- response violation is reported")
+	require.Cmp(reported[0], td.Contains("species"))
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	webhooksUsecase "github.com/japhy-tech/backend-test/internal/usecases/webhooks"
+)
+
+// List webhooks
+// (GET /webhooks)
+func (s Server) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[[]Webhook], error) {
+		res, err := usecases.New(&webhooksUsecase.List{}, s.datastore, s.usecaseOpts...).Handle(ctx, struct{}{})
+		if err != nil {
+			return nil, err
+		}
+		return &Response[[]Webhook]{
+			Val:    common.Map(res, func(val *webhooks.Webhook) Webhook { return WebhookToJson(val) }),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// Create one webhook
+// (POST /webhooks)
+func (s Server) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Webhook], error) {
+		body, err := Bind[Webhook](r)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := usecases.New(&webhooksUsecase.CreateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, webhooksUsecase.CreateOneOpts{
+			URL:        body.Url,
+			Secret:     common.FromPointer(body.Secret),
+			EventTypes: common.Map(body.EventTypes, func(val BreedEvent) string { return string(val) }),
+			Enabled:    body.Enabled,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &Response[Webhook]{
+			Val:    WebhookToJson(res),
+			Status: http.StatusCreated,
+		}, nil
+	})
+}
+
+// Delete a given webhook
+// (DELETE /webhooks/{webhook_id})
+func (s Server) DeleteWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	err := usecases.NewSimple(&webhooksUsecase.DeleteOneByID{}, s.datastore, s.usecaseOpts...).Handle(r.Context(), webhookId)
+	if err != nil {
+		HandleErrorResponse(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Retrieve a given webhook by its id
+// (GET /webhooks/{webhook_id})
+func (s Server) GetWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Webhook], error) {
+		res, err := usecases.New(&webhooksUsecase.GetOneByID{}, s.datastore, s.usecaseOpts...).Handle(ctx, webhookId)
+		if err != nil {
+			return nil, err
+		}
+		return &Response[Webhook]{
+			Val:    WebhookToJson(res),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// Update a given webhook
+// (PUT /webhooks/{webhook_id})
+func (s Server) UpdateWebhookByID(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[Webhook], error) {
+		body, err := Bind[Webhook](r)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := usecases.New(&webhooksUsecase.UpdateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, webhooksUsecase.UpdateOneOpts{
+			ID:         webhookId,
+			URL:        body.Url,
+			Secret:     common.FromPointer(body.Secret),
+			EventTypes: common.Map(body.EventTypes, func(val BreedEvent) string { return string(val) }),
+			Enabled:    body.Enabled == nil || *body.Enabled,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &Response[Webhook]{
+			Val:    WebhookToJson(res),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// List the deliveries of a given webhook
+// (GET /webhooks/{webhook_id}/deliveries)
+func (s Server) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[[]WebhookDelivery], error) {
+		res, err := usecases.New(&webhooksUsecase.ListDeliveries{}, s.datastore, s.usecaseOpts...).Handle(ctx, webhookId)
+		if err != nil {
+			return nil, err
+		}
+		return &Response[[]WebhookDelivery]{
+			Val:    common.Map(res, func(val *webhooks.Delivery) WebhookDelivery { return WebhookDeliveryToJson(val) }),
+			Status: http.StatusOK,
+		}, nil
+	})
+}
+
+// Replay a delivery
+// (POST /webhooks/{webhook_id}/deliveries/{delivery_id}/replay)
+func (s Server) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookID, deliveryId int64) {
+	EndpointDecorator(w, r, s.encoders, func(ctx context.Context) (*Response[WebhookDelivery], error) {
+		res, err := usecases.New(&webhooksUsecase.ReplayDelivery{}, s.datastore, s.usecaseOpts...).Handle(ctx, webhooksUsecase.ReplayDeliveryOpts{
+			WebhookID:  webhookId,
+			DeliveryID: deliveryId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &Response[WebhookDelivery]{
+			Val:    WebhookDeliveryToJson(res),
+			Status: http.StatusAccepted,
+		}, nil
+	})
+}
+
+// WebhookToJson
+// The secret is never sent back
+func WebhookToJson(domain *webhooks.Webhook) Webhook {
+	return Webhook{
+		Id:                  common.ToPointer(domain.ID()),
+		Url:                 domain.URL().String(),
+		EventTypes:          common.Map(domain.EventTypes(), func(val string) BreedEvent { return BreedEvent(val) }),
+		Enabled:             common.ToPointer(domain.Enabled()),
+		ConsecutiveFailures: common.ToPointer(domain.ConsecutiveFailures()),
+		DisabledAt:          domain.DisabledAt(),
+		CreatedAt:           common.ToPointer(domain.CreatedAt()),
+	}
+}
+
+func WebhookDeliveryToJson(domain *webhooks.Delivery) WebhookDelivery {
+	res := WebhookDelivery{
+		Id:             domain.ID(),
+		EventId:        domain.EventID(),
+		EventName:      BreedEvent(domain.EventName()),
+		Status:         WebhookDeliveryStatus(domain.Status().String()),
+		Attempts:       domain.Attempts(),
+		LastStatusCode: domain.LastStatusCode(),
+		NextAttemptAt:  domain.NextAttemptAt(),
+		CreatedAt:      domain.CreatedAt(),
+		DeliveredAt:    domain.DeliveredAt(),
+		Payload:        map[string]interface{}{},
+	}
+	if domain.LastError() != "" {
+		res.LastError = common.ToPointer(domain.LastError())
+	}
+	// Payloads are written by the outbox, they are valid json objects
+	_ = json.Unmarshal(domain.Payload(), &res.Payload)
+	return res
+}
@@ -0,0 +1,101 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestServer_Webhooks(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			r  = mux.NewRouter()
+			h  = api.HandlerFromMuxWithBaseURL(api.New(logger, datastore), r, "/v1")
+			ta = tdhttp.NewTestAPI(t, h)
+			id string
+		)
+
+		ta.Name("invalid case -- missing secret").PostJSON("/v1/webhooks", api.Webhook{
+			Url:        "https://example.com/hooks",
+			EventTypes: []api.BreedEvent{api.BreedCreated},
+		}).
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.Contains(values.ErrWebhookSecretToShort.Error())))
+		ta.Name("invalid case -- unknown event type").PostJSON("/v1/webhooks", api.Webhook{
+			Url:        "https://example.com/hooks",
+			Secret:     common.ToPointer("0123456789abcdef"),
+			EventTypes: []api.BreedEvent{"breed.renamed"},
+		}).
+			CmpStatus(http.StatusBadRequest).
+			CmpJSONBody(td.JSON(`{"message": $1}`, td.Contains(webhooks.ErrInvalidEventType.Error())))
+
+		ta.Name("valid case -- secret is not sent back").PostJSON("/v1/webhooks", api.Webhook{
+			Url:        "https://example.com/hooks",
+			Secret:     common.ToPointer("0123456789abcdef"),
+			EventTypes: []api.BreedEvent{api.BreedCreated},
+		}).
+			CmpStatus(http.StatusCreated).
+			CmpJSONBody(td.JSON(`{
+				"id": $id,
+				"url": "https://example.com/hooks",
+				"event_types": ["breed.created"],
+				"enabled": true,
+				"consecutive_failures": 0,
+				"created_at": $created_at
+			}`, td.Tag("id", td.Catch(&id, td.NotEmpty())), td.Tag("created_at", td.NotEmpty())))
+
+		ta.Name("list").Get("/v1/webhooks").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[$1]`, td.SuperJSONOf(`{"id": $1}`, id)))
+
+		ta.Name("update keeps the secret").PutJSON("/v1/webhooks/"+id, api.Webhook{
+			Url:        "https://example.com/other",
+			EventTypes: []api.BreedEvent{api.BreedCreated, api.BreedDeleted},
+		}).
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.SuperJSONOf(`{"url": "https://example.com/other", "event_types": ["breed.created", "breed.deleted"]}`))
+		found, err := datastore.Webhooks().GetOneByID(ctx, id)
+		require.CmpNoError(err)
+		require.Cmp(found.Secret().String(), "0123456789abcdef")
+
+		_, err = datastore.Webhooks().Enqueue(ctx, 1, breeds.EventBreedCreated, []byte(`{"event": "breed.created"}`))
+		require.CmpNoError(err)
+		var deliveryID int64
+		ta.Name("deliveries").Get("/v1/webhooks/" + id + "/deliveries").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`[$1]`, td.SuperJSONOf(`{
+				"id": $id,
+				"event_id": 1,
+				"event_name": "breed.created",
+				"status": "pending",
+				"attempts": 0,
+				"payload": {"event": "breed.created"}
+			}`, td.Tag("id", td.Catch(&deliveryID, td.NotZero())))))
+		ta.Name("replay -- already pending").Post(fmt.Sprintf("/v1/webhooks/%s/deliveries/%d/replay", id, deliveryID), nil).
+			CmpStatus(http.StatusNoContent)
+		require.CmpNoError(datastore.Webhooks().RecordSuccess(ctx, deliveryID, http.StatusOK, found.CreatedAt()))
+		ta.Name("replay").Post(fmt.Sprintf("/v1/webhooks/%s/deliveries/%d/replay", id, deliveryID), nil).
+			CmpStatus(http.StatusAccepted).
+			CmpJSONBody(td.SuperJSONOf(`{"status": "pending", "attempts": 1}`))
+		ta.Name("replay -- not found").Post(fmt.Sprintf("/v1/webhooks/%s/deliveries/%d/replay", id, deliveryID+1), nil).
+			CmpStatus(http.StatusNotFound)
+
+		ta.Name("delete").Delete("/v1/webhooks/"+id, nil).
+			CmpStatus(http.StatusNoContent)
+		ta.Name("deleted webhook is not found").Get("/v1/webhooks/" + id).
+			CmpStatus(http.StatusNotFound)
+	})
+}
@@ -3,3 +3,11 @@ package common
 func ToPointer[T any](val T) *T {
 	return &val
 }
+
+func FromPointer[T any](val *T) T {
+	var res T
+	if val != nil {
+		res = *val
+	}
+	return res
+}
@@ -0,0 +1,162 @@
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	AcceptEncodingHeader  = "Accept-Encoding"
+	ContentEncodingHeader = "Content-Encoding"
+
+	// DefaultMinSize is the size under which the responses are not worth compressing
+	DefaultMinSize = 1024
+)
+
+// Compressor
+// Writer of a content coding, reused between the responses
+type Compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Encoding
+// Content coding the responses can be compressed with
+type Encoding struct {
+	name string
+	pool sync.Pool
+}
+
+func NewEncoding(name string, newCompressor func() Compressor) *Encoding {
+	return &Encoding{
+		name: name,
+		pool: sync.Pool{New: func() any { return newCompressor() }},
+	}
+}
+
+// Name
+// Token of the encoding in the Accept-Encoding and Content-Encoding headers
+func (e *Encoding) Name() string {
+	return e.name
+}
+
+func (e *Encoding) get(w io.Writer) Compressor {
+	c := e.pool.Get().(Compressor)
+	c.Reset(w)
+	return c
+}
+
+func (e *Encoding) put(c Compressor) {
+	c.Reset(nil)
+	e.pool.Put(c)
+}
+
+var (
+	Brotli = NewEncoding("br", func() Compressor {
+		return brotli.NewWriter(nil)
+	})
+	Zstd = NewEncoding("zstd", func() Compressor {
+		// The options are valid, NewWriter cannot fail
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	})
+	Gzip = NewEncoding("gzip", func() Compressor {
+		return gzip.NewWriter(nil)
+	})
+)
+
+// Compression
+// Compress the responses with the encoding preferred by the client
+type Compression struct {
+	encodings []*Encoding
+	minSize   int
+}
+
+type Option func(*Compression)
+
+// WithEncodings
+// Encodings the responses can be compressed with, by order of preference of the
+// server. Brotli, Zstd and Gzip by default
+func WithEncodings(encodings ...*Encoding) Option {
+	return func(c *Compression) {
+		c.encodings = encodings
+	}
+}
+
+// WithMinSize
+// Send the responses smaller than size uncompressed
+func WithMinSize(size int) Option {
+	return func(c *Compression) {
+		c.minSize = size
+	}
+}
+
+func New(opts ...Option) *Compression {
+	c := &Compression{
+		encodings: []*Encoding{Brotli, Zstd, Gzip},
+		minSize:   DefaultMinSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Negotiate
+// Encoding of the highest quality for the Accept-Encoding header, ties being broken
+// by the order of preference of the server. Nil when the response must be sent as is
+func (c *Compression) Negotiate(acceptEncoding string) *Encoding {
+	var (
+		qualities = map[string]float64{}
+		wildcard  float64
+	)
+	for _, pref := range httputil.ParsePreferences(acceptEncoding) {
+		if pref.Value == "*" {
+			wildcard = pref.Quality
+		} else {
+			qualities[pref.Value] = pref.Quality
+		}
+	}
+
+	var (
+		best        *Encoding
+		bestQuality float64
+	)
+	for _, enc := range c.encodings {
+		q, ok := qualities[enc.name]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQuality {
+			best, bestQuality = enc, q
+		}
+	}
+	return best
+}
+
+// Middleware
+// Compress the responses of at least the minimum size, unless they are already encoded
+// or streamed as events. Their strong ETag is weakened, as the compressed bytes are not
+// the ones it was computed from
+func (c *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", AcceptEncodingHeader)
+
+		enc := c.Negotiate(r.Header.Get(AcceptEncodingHeader))
+		if enc == nil || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &responseWriter{ResponseWriter: w, encoding: enc, minSize: c.minSize, status: http.StatusOK}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
@@ -0,0 +1,113 @@
+package compression_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/japhy-tech/backend-test/internal/compression"
+	"github.com/klauspost/compress/zstd"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestCompression_Negotiate(t *testing.T) {
+	c := compression.New()
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           *compression.Encoding
+	}{
+		{name: "valid case -- no preference", acceptEncoding: "", want: nil},
+		{name: "valid case -- single", acceptEncoding: "gzip", want: compression.Gzip},
+		{name: "valid case -- ties by preference of the server", acceptEncoding: "gzip, deflate, br, zstd", want: compression.Brotli},
+		{name: "valid case -- highest quality", acceptEncoding: "br;q=0.5, zstd;q=0.8, gzip", want: compression.Gzip},
+		{name: "valid case -- wildcard", acceptEncoding: "*", want: compression.Brotli},
+		{name: "valid case -- wildcard except refused", acceptEncoding: "*, br;q=0", want: compression.Zstd},
+		{name: "invalid case -- unknown", acceptEncoding: "deflate", want: nil},
+		{name: "invalid case -- identity only", acceptEncoding: "identity", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.Cmp(t, c.Negotiate(tt.acceptEncoding), td.Shallow(tt.want))
+		})
+	}
+}
+
+func TestCompression_Middleware(t *testing.T) {
+	var (
+		large = strings.Repeat("breeds ", 200)
+		small = "breeds"
+	)
+	handler := func(body string, headers http.Header, status int) http.Handler {
+		return compression.New(compression.WithMinSize(100)).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, val := range headers {
+				w.Header()[key] = val
+			}
+			w.WriteHeader(status)
+			// Written in pieces, the first one below the minimum size
+			_, _ = io.WriteString(w, body[:len(body)/2])
+			_, _ = io.WriteString(w, body[len(body)/2:])
+		}))
+	}
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"br":   func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		"zstd": func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+
+	for name, decode := range decoders {
+		t.Run("valid case -- "+name, func(t *testing.T) {
+			require := td.Require(t)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(compression.AcceptEncodingHeader, name)
+			rec := httptest.NewRecorder()
+			handler(large, http.Header{"Etag": {`"abc"`}, "Content-Length": {"1400"}}, http.StatusCreated).ServeHTTP(rec, req)
+
+			require.Cmp(rec.Code, http.StatusCreated)
+			require.Cmp(rec.Header().Get(compression.ContentEncodingHeader), name)
+			require.Cmp(rec.Header().Get("Vary"), compression.AcceptEncodingHeader)
+			require.Cmp(rec.Header().Get("ETag"), `W/"abc"`)
+			require.Cmp(rec.Header().Get("Content-Length"), "")
+
+			r, err := decode(rec.Body)
+			require.CmpNoError(err)
+			got, err := io.ReadAll(r)
+			require.CmpNoError(err)
+			require.Cmp(string(got), large)
+		})
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		headers        http.Header
+		status         int
+	}{
+		{name: "valid case -- not accepted", body: large, status: http.StatusOK},
+		{name: "valid case -- below the minimum size", acceptEncoding: "gzip", body: small, status: http.StatusOK},
+		{name: "valid case -- already encoded", acceptEncoding: "gzip", body: large, headers: http.Header{"Content-Encoding": {"br"}}, status: http.StatusOK},
+		{name: "valid case -- events stream", acceptEncoding: "gzip", body: large, headers: http.Header{"Content-Type": {"text/event-stream"}}, status: http.StatusOK},
+		{name: "valid case -- not modified", acceptEncoding: "gzip", body: "", status: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := td.Require(t)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(compression.AcceptEncodingHeader, tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler(tt.body, tt.headers, tt.status).ServeHTTP(rec, req)
+
+			require.Cmp(rec.Code, tt.status)
+			require.Cmp(rec.Header().Get(compression.ContentEncodingHeader), tt.headers.Get("Content-Encoding"))
+			require.Cmp(rec.Body.String(), tt.body)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package compression
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// responseWriter
+// Buffer the beginning of the response until it is known to reach the minimum size,
+// then compress it or send it as is
+type responseWriter struct {
+	http.ResponseWriter
+	encoding *Encoding
+	minSize  int
+
+	status     int
+	buf        []byte
+	committed  bool
+	compressor Compressor
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.committed {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	// The informational responses are sent right away
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.committed {
+		if !w.compressible() {
+			if err := w.commit(false); err != nil {
+				return 0, err
+			}
+		} else {
+			w.buf = append(w.buf, p...)
+			if len(w.buf) < w.minSize {
+				return len(p), nil
+			}
+			return len(p), w.commit(true)
+		}
+	}
+	if w.compressor != nil {
+		return w.compressor.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush
+// Send what was written so far, which is compressed only when it reached the minimum size
+func (w *responseWriter) Flush() {
+	if !w.committed {
+		if err := w.commit(w.compressible() && len(w.buf) >= w.minSize); err != nil {
+			return
+		}
+	}
+	if w.compressor != nil {
+		if err := w.compressor.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible
+// Whether the response can be compressed, according to its status and headers
+func (w *responseWriter) compressible() bool {
+	if w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+	h := w.Header()
+	if h.Get(ContentEncodingHeader) != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType != "text/event-stream"
+}
+
+// commit
+// Send the status, the headers and the buffered beginning of the response
+func (w *responseWriter) commit(compress bool) error {
+	w.committed = true
+
+	buf := w.buf
+	w.buf = nil
+	if compress {
+		h := w.Header()
+		h.Set(ContentEncodingHeader, w.encoding.Name())
+		h.Del("Content-Length")
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.compressor = w.encoding.get(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.compressor != nil {
+		_, err := w.compressor.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close
+// Send the rest of the response, once the handler returned
+func (w *responseWriter) close() {
+	if !w.committed {
+		if err := w.commit(false); err != nil {
+			return
+		}
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+		w.encoding.put(w.compressor)
+		w.compressor = nil
+	}
+}
@@ -0,0 +1,176 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/relay"
+)
+
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	TimestampHeader  = "X-Webhook-Timestamp"
+	DeliveryIDHeader = "X-Webhook-Delivery"
+
+	DefaultBatchSize    = 100
+	DefaultMaxAttempts  = 8
+	DefaultDisableAfter = 20
+)
+
+// Dispatcher
+// Send the pending webhook deliveries. A failed delivery is retried with an
+// exponential backoff until it reaches the maximum attempts, and a webhook
+// failing too many deliveries in a row is disabled
+type Dispatcher struct {
+	repository   webhooks.Repository
+	client       *http.Client
+	logger       *charmLog.Logger
+	batchSize    int
+	maxAttempts  int
+	disableAfter int
+	backoff      relay.Backoff
+	now          func() time.Time
+}
+
+type Option func(*Dispatcher)
+
+func WithClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+func WithBatchSize(size int) Option {
+	return func(d *Dispatcher) {
+		d.batchSize = size
+	}
+}
+
+// WithMaxAttempts
+// Give up a delivery after attempts failures
+func WithMaxAttempts(attempts int) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithDisableAfter
+// Disable a webhook after failures consecutive failed attempts
+func WithDisableAfter(failures int) Option {
+	return func(d *Dispatcher) {
+		d.disableAfter = failures
+	}
+}
+
+func WithBackoff(backoff relay.Backoff) Option {
+	return func(d *Dispatcher) {
+		d.backoff = backoff
+	}
+}
+
+func New(repository webhooks.Repository, logger *charmLog.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		repository:   repository,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		logger:       logger,
+		batchSize:    DefaultBatchSize,
+		maxAttempts:  DefaultMaxAttempts,
+		disableAfter: DefaultDisableAfter,
+		backoff:      relay.ExponentialBackoff(relay.DefaultBackoffMin, relay.DefaultBackoffMax),
+		now:          func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// DispatchPending
+// Send one batch of due deliveries and return how many succeeded. A webhook
+// failure is recorded on the delivery: the returned error is about the storage itself
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.ListPendingDeliveries(ctx, d.now(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	found := make(map[string]*webhooks.Webhook)
+	for _, delivery := range deliveries {
+		hook, ok := found[delivery.WebhookID()]
+		if !ok {
+			if hook, err = d.repository.GetOneByID(ctx, delivery.WebhookID()); err != nil {
+				return succeeded, err
+			}
+			found[hook.ID()] = hook
+		}
+		// Disabled while dispatching this batch
+		if !hook.Enabled() {
+			continue
+		}
+
+		statusCode, err := d.send(ctx, hook, delivery)
+		if err == nil {
+			if err := d.repository.RecordSuccess(ctx, delivery.ID(), statusCode, d.now()); err != nil {
+				return succeeded, err
+			}
+			succeeded++
+			continue
+		}
+
+		failure := webhooks.DeliveryFailure{StatusCode: statusCode, Error: err.Error()}
+		if delivery.Attempts()+1 < d.maxAttempts {
+			next := d.now().Add(d.backoff(delivery.Attempts() + 1))
+			failure.NextAttemptAt = &next
+		}
+		d.logger.Warnf("Webhook %s delivery %d failed: %s", hook.ID(), delivery.ID(), err)
+
+		disabled, err := d.repository.RecordFailure(ctx, delivery.ID(), failure, d.disableAfter)
+		if err != nil {
+			return succeeded, err
+		}
+		if disabled {
+			d.logger.Warnf("Webhook %s disabled after %d consecutive failures", hook.ID(), d.disableAfter)
+			if found[hook.ID()], err = d.repository.GetOneByID(ctx, hook.ID()); err != nil {
+				return succeeded, err
+			}
+		}
+	}
+	return succeeded, nil
+}
+
+// send
+// POST the delivery payload, signed with the webhook secret. Any non 2xx answer is a failure
+func (d *Dispatcher) send(ctx context.Context, hook *webhooks.Webhook, delivery *webhooks.Delivery) (int, error) {
+	timestamp := d.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL().String(), bytes.NewReader(delivery.Payload()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(relay.EventNameHeader, delivery.EventName())
+	req.Header.Set(relay.EventIDHeader, strconv.FormatInt(delivery.EventID(), 10))
+	req.Header.Set(DeliveryIDHeader, strconv.FormatInt(delivery.ID(), 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, webhooks.Sign(hook.Secret().String(), timestamp, delivery.Payload()))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("webhook answered %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
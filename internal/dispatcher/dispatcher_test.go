@@ -0,0 +1,145 @@
+package dispatcher_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/dispatcher"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver
+// Local webhook endpoint answering the given statuses in turn, then 204
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	verified int
+	bodies   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	timestamp, _ := strconv.ParseInt(req.Header.Get(dispatcher.TimestampHeader), 10, 64)
+	if webhooks.VerifySignature(secret, timestamp, body, req.Header.Get(dispatcher.SignatureHeader)) {
+		r.verified++
+	}
+	r.bodies = append(r.bodies, string(body))
+
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func createWebhook(ctx context.Context, require *td.T, datastore gateways.IDatastore, url string) *webhooks.Webhook {
+	w, err := webhooks.NewFactory(webhooks.FactoryOpts{
+		ID:         uuid.NewString(),
+		URL:        url,
+		Secret:     secret,
+		EventTypes: []string{breeds.EventBreedCreated},
+		Enabled:    true,
+		CreatedAt:  time.Now(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	res, err := datastore.Webhooks().CreateOne(ctx, w)
+	require.CmpNoError(err)
+	return res
+}
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			rcv      = &receiver{statuses: []int{http.StatusInternalServerError}}
+			srv      = httptest.NewServer(rcv)
+			enqueuer = dispatcher.NewEnqueuer(datastore.Webhooks())
+			d        = dispatcher.New(datastore.Webhooks(), logger, dispatcher.WithBackoff(func(int) time.Duration { return 0 }))
+		)
+		defer srv.Close()
+
+		hook := createWebhook(ctx, require, datastore, srv.URL)
+		msg := outbox.NewMessage(1, breeds.EventBreedCreated, "test", []byte(`{"event":"breed.created"}`), time.Now(), 0)
+		require.CmpNoError(enqueuer.Send(ctx, msg))
+
+		succeeded, err := d.DispatchPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(succeeded, 0)
+
+		succeeded, err = d.DispatchPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(succeeded, 1)
+
+		require.Cmp(rcv.verified, 2)
+		require.Cmp(rcv.bodies, []string{`{"event": "breed.created"}`, `{"event": "breed.created"}`})
+
+		log, err := datastore.Webhooks().ListDeliveries(ctx, hook.ID())
+		require.CmpNoError(err)
+		require.Len(log, 1)
+		require.Cmp(log[0].Status(), webhooks.DeliverySucceeded)
+		require.Cmp(log[0].Attempts(), 2)
+		require.Cmp(log[0].LastStatusCode(), http.StatusNoContent)
+
+		found, err := datastore.Webhooks().GetOneByID(ctx, hook.ID())
+		require.CmpNoError(err)
+		require.Cmp(found.ConsecutiveFailures(), 0)
+	})
+}
+
+func TestDispatcher_DispatchPending_Disable(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			rcv = &receiver{statuses: []int{http.StatusGone, http.StatusGone, http.StatusGone}}
+			srv = httptest.NewServer(rcv)
+			d   = dispatcher.New(datastore.Webhooks(), logger,
+				dispatcher.WithBackoff(func(int) time.Duration { return 0 }),
+				dispatcher.WithMaxAttempts(2),
+				dispatcher.WithDisableAfter(3),
+			)
+		)
+		defer srv.Close()
+
+		hook := createWebhook(ctx, require, datastore, srv.URL)
+		for _, id := range []int64{1, 2} {
+			_, err := datastore.Webhooks().Enqueue(ctx, id, breeds.EventBreedCreated, []byte(`{}`))
+			require.CmpNoError(err)
+		}
+
+		// Both deliveries fail once, then the first one is given up on its second
+		// failure, which is the third in a row and disables the webhook
+		for i := 0; i < 3; i++ {
+			_, err := d.DispatchPending(ctx)
+			require.CmpNoError(err)
+		}
+
+		found, err := datastore.Webhooks().GetOneByID(ctx, hook.ID())
+		require.CmpNoError(err)
+		require.False(found.Enabled())
+
+		log, err := datastore.Webhooks().ListDeliveries(ctx, hook.ID())
+		require.CmpNoError(err)
+		require.Cmp(log[0].Status(), webhooks.DeliveryPending)
+		require.Cmp(log[0].Attempts(), 1)
+		require.Cmp(log[1].Status(), webhooks.DeliveryFailed)
+		require.Cmp(log[1].Attempts(), 2)
+		require.Cmp(log[1].LastStatusCode(), http.StatusGone)
+		require.Len(rcv.bodies, 3)
+	})
+}
@@ -0,0 +1,25 @@
+package dispatcher
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+)
+
+// Enqueuer
+// relay.Sink turning every outbox message into deliveries for the webhooks subscribing to it
+type Enqueuer struct {
+	repository webhooks.Repository
+}
+
+func NewEnqueuer(repository webhooks.Repository) *Enqueuer {
+	return &Enqueuer{
+		repository: repository,
+	}
+}
+
+func (e *Enqueuer) Send(ctx context.Context, msg *outbox.Message) error {
+	_, err := e.repository.Enqueue(ctx, msg.ID(), msg.EventName(), msg.Payload())
+	return err
+}
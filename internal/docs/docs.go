@@ -0,0 +1,131 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ForwardedProtoHeader = "X-Forwarded-Proto"
+	ForwardedHostHeader  = "X-Forwarded-Host"
+)
+
+var ErrInvalidSpec = errors.New("specification must be a YAML document")
+
+// Handler
+// Serve the OpenAPI specification, as YAML and as JSON, and the Swagger UI page
+// rendering it. The servers of the specification are replaced by the url the
+// request was sent to, for the page to try the operations wherever the service
+// runs
+type Handler struct {
+	// spec is the YAML document as written, its order and comments being kept
+	spec *yaml.Node
+	// generic is the same document decoded for its JSON rendering
+	generic  map[string]any
+	assets   fs.FS
+	basePath string
+}
+
+// New
+// Handler of the spec, whose operations are served under basePath, e.g. /v1. The
+// assets of the page are served from the root of assets
+func New(spec []byte, assets fs.FS, basePath string) (*Handler, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, errors.Join(ErrInvalidSpec, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, ErrInvalidSpec
+	}
+	var generic map[string]any
+	if err := doc.Decode(&generic); err != nil {
+		return nil, errors.Join(ErrInvalidSpec, err)
+	}
+	return &Handler{
+		spec:     &doc,
+		generic:  generic,
+		assets:   assets,
+		basePath: basePath,
+	}, nil
+}
+
+// UI
+// Page and assets of Swagger UI, to be mounted with the prefix stripped
+func (h *Handler) UI() http.Handler {
+	return http.FileServer(http.FS(h.assets))
+}
+
+// YAML
+// Specification as written, with the servers of the request
+func (h *Handler) YAML(w http.ResponseWriter, r *http.Request) {
+	root := *h.spec.Content[0]
+	root.Content = nil
+	servers := false
+	// The nodes of the document are shared by the requests: only the copies are changed
+	for i := 0; i+1 < len(h.spec.Content[0].Content); i += 2 {
+		key, val := h.spec.Content[0].Content[i], h.spec.Content[0].Content[i+1]
+		if key.Value == "servers" {
+			val = h.serversNode(r)
+			servers = true
+		}
+		root.Content = append(root.Content, key, val)
+	}
+	if !servers {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "servers"}, h.serversNode(r))
+	}
+
+	res, err := yaml.Marshal(&root)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(res)
+}
+
+// JSON
+// Specification rendered as JSON, with the servers of the request
+func (h *Handler) JSON(w http.ResponseWriter, r *http.Request) {
+	doc := make(map[string]any, len(h.generic))
+	for key, val := range h.generic {
+		doc[key] = val
+	}
+	doc["servers"] = []map[string]string{{"url": h.serverURL(r)}}
+
+	res, err := json.Marshal(doc)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(res)
+}
+
+func (h *Handler) serversNode(r *http.Request) *yaml.Node {
+	var res yaml.Node
+	// Encoding a plain value cannot fail
+	_ = res.Encode([]map[string]string{{"url": h.serverURL(r)}})
+	return &res
+}
+
+// serverURL
+// Url the operations of the request are served at, as seen by the client when the
+// service is behind a proxy setting the X-Forwarded-* headers
+func (h *Handler) serverURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get(ForwardedProtoHeader); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := r.Host
+	if val := r.Header.Get(ForwardedHostHeader); val != "" {
+		host = val
+	}
+	return scheme + "://" + host + h.basePath
+}
@@ -0,0 +1,62 @@
+package docs_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	apispec "github.com/japhy-tech/backend-test/api"
+	"github.com/japhy-tech/backend-test/internal/docs"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHandler(t *testing.T) {
+	require := td.Require(t)
+
+	h, err := docs.New(apispec.Swagger, apispec.Docs, "/v1")
+	require.CmpNoError(err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/openapi.json", h.JSON)
+	r.HandleFunc("/v1/docs/swagger.yml", h.YAML)
+	r.PathPrefix("/v1/docs/").Handler(http.StripPrefix("/v1/docs/", h.UI()))
+	ta := tdhttp.NewTestAPI(t, r)
+
+	ta.Name("valid case -- page is self hosted").Get("/v1/docs/").
+		CmpStatus(http.StatusOK).
+		CmpBody(td.All(td.Contains("swagger-ui/swagger-ui-bundle.js"), td.Not(td.Contains("unpkg.com"))))
+	ta.Name("valid case -- assets").Get("/v1/docs/swagger-ui/swagger-ui.css").
+		CmpStatus(http.StatusOK).
+		CmpHeader(td.SuperMapOf(http.Header{"Content-Type": {"text/css; charset=utf-8"}}, nil))
+
+	ta.Name("valid case -- json servers of the request").Get("http://api.example.com/v1/openapi.json").
+		CmpStatus(http.StatusOK).
+		CmpJSONBody(td.SuperJSONOf(`{
+			"openapi": "3.0.3",
+			"servers": [{"url": "http://api.example.com/v1"}],
+			"paths": SuperMapOf({"/breeds": NotEmpty()})
+		}`))
+	ta.Name("valid case -- json servers behind a proxy").Get("/v1/openapi.json", http.Header{
+		docs.ForwardedProtoHeader: {"https"},
+		docs.ForwardedHostHeader:  {"breeds.example.com"},
+	}).
+		CmpStatus(http.StatusOK).
+		CmpJSONBody(td.SuperJSONOf(`{"servers": [{"url": "https://breeds.example.com/v1"}]}`))
+
+	ta.Name("valid case -- yaml servers of the request").Get("http://localhost:50010/v1/docs/swagger.yml").
+		CmpStatus(http.StatusOK).
+		CmpHeader(td.SuperMapOf(http.Header{"Content-Type": {"application/yaml"}}, nil)).
+		CmpBody(td.Smuggle(func(body []byte) (map[string]any, error) {
+			var res map[string]any
+			return res, yaml.Unmarshal(body, &res)
+		}, td.SuperMapOf(map[string]any{
+			"openapi": "3.0.3",
+			"servers": []any{map[string]any{"url": "http://localhost:50010/v1"}},
+			"paths":   td.NotEmpty(),
+		}, nil)))
+
+	_, err = docs.New([]byte("- not a document"), apispec.Docs, "/v1")
+	require.CmpErrorIs(err, docs.ErrInvalidSpec, "invalid case -- spec is not a mapping")
+}
@@ -0,0 +1,67 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+)
+
+// APIKey
+// Credential of a client of the API. Only the hash of the key is kept, the key
+// itself is shown once when it is created
+type APIKey struct {
+	id        string
+	name      string
+	hash      string
+	scopes    []auth.Scope
+	createdAt time.Time
+	revokedAt *time.Time
+}
+
+func (k APIKey) ID() string {
+	return k.id
+}
+
+// Name
+// Unique label telling who the key was given to
+func (k APIKey) Name() string {
+	return k.name
+}
+
+// Hash
+// SHA-256 of the key (see HashKey)
+func (k APIKey) Hash() string {
+	return k.hash
+}
+
+func (k APIKey) Scopes() []auth.Scope {
+	return k.scopes
+}
+
+func (k APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// RevokedAt
+// When the key was revoked. Nil while it can be used
+func (k APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+func (k APIKey) Revoked() bool {
+	return k.revokedAt != nil
+}
+
+// Principal
+// Identity of the requests authenticated with the key. The key scopes apply to
+// the breeds, and admin keys manage the webhooks as well
+func (k APIKey) Principal() auth.Principal {
+	roles := []auth.Role{}
+	for _, val := range k.scopes {
+		roles = append(roles, auth.NewRole(auth.ResourceBreeds, val))
+		if val == auth.ScopeAdmin {
+			roles = append(roles, auth.NewRole(auth.ResourceWebhooks, val))
+		}
+	}
+	return auth.NewPrincipal(PrincipalPrefix+k.name, roles...)
+}
@@ -0,0 +1,71 @@
+package apikeys
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+const (
+	MaxNameLength = 100
+)
+
+var (
+	ErrInvalidName = errors.New("api key name must be between 1 and 100 characters")
+	ErrNoScope     = errors.New("api key must have at least one scope")
+	ErrNoHash      = errors.New("api key hash is required")
+)
+
+type FactoryOpts struct {
+	ID        string
+	Name      string
+	Hash      string
+	Scopes    []string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type Factory struct {
+	FactoryOpts
+}
+
+func NewFactory(opts FactoryOpts) *Factory {
+	return &Factory{
+		FactoryOpts: opts,
+	}
+}
+
+func (f Factory) Instantiate() (*APIKey, error) {
+	if len(f.Name) == 0 || len(f.Name) > MaxNameLength {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidName)
+	}
+	if f.Hash == "" {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrNoHash)
+	}
+	if len(f.Scopes) == 0 {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrNoScope)
+	}
+
+	scopes := []auth.Scope{}
+	for _, val := range f.Scopes {
+		scope, err := auth.ScopeFromString(val)
+		if err != nil {
+			return nil, domainerror.WrapError(domainerror.ErrDomainValidation, err)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &APIKey{
+		id:        f.ID,
+		name:      f.Name,
+		hash:      f.Hash,
+		scopes:    scopes,
+		createdAt: f.CreatedAt,
+		revokedAt: f.RevokedAt,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package apikeys_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestFactory_Instantiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        apikeys.FactoryOpts
+		want        []auth.Scope
+		wantErr     error
+		errContains string
+	}{
+		{
+			name: "valid case -- duplicated scopes",
+			opts: apikeys.FactoryOpts{
+				Name:   "backoffice",
+				Hash:   apikeys.HashKey("key"),
+				Scopes: []string{"read", "WRITE", "read"},
+			},
+			want: []auth.Scope{auth.ScopeRead, auth.ScopeWrite},
+		},
+		{
+			name: "invalid case -- no scope",
+			opts: apikeys.FactoryOpts{
+				Name: "backoffice",
+				Hash: apikeys.HashKey("key"),
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: apikeys.ErrNoScope.Error(),
+		},
+		{
+			name: "invalid case -- unknown scope",
+			opts: apikeys.FactoryOpts{
+				Name:   "backoffice",
+				Hash:   apikeys.HashKey("key"),
+				Scopes: []string{"root"},
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: auth.ErrInvalidScope.Error(),
+		},
+		{
+			name: "invalid case -- name too long",
+			opts: apikeys.FactoryOpts{
+				Name:   strings.Repeat("a", apikeys.MaxNameLength+1),
+				Hash:   apikeys.HashKey("key"),
+				Scopes: []string{"read"},
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: apikeys.ErrInvalidName.Error(),
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := apikeys.NewFactory(tt.opts).Instantiate()
+			require.CmpErrorIs(err, tt.wantErr)
+
+			if tt.wantErr != nil {
+				require.Contains(err.Error(), tt.errContains)
+			} else {
+				require.Cmp(res.Scopes(), tt.want)
+				require.Cmp(res.Principal().ID(), "apikey:backoffice")
+				require.True(res.Principal().HasRole(auth.NewRole(auth.ResourceBreeds, auth.ScopeRead)))
+				require.False(res.Principal().HasRole(auth.NewRole(auth.ResourceBreeds, auth.ScopeAdmin)))
+				require.False(res.Principal().HasRole(auth.NewRole(auth.ResourceWebhooks, auth.ScopeRead)))
+			}
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	require := td.Require(t)
+
+	key, hash, err := apikeys.GenerateKey()
+	require.CmpNoError(err)
+	require.HasPrefix(key, apikeys.KeyPrefix)
+	require.Cmp(hash, apikeys.HashKey(key))
+	require.Len(hash, 64)
+
+	other, _, err := apikeys.GenerateKey()
+	require.CmpNoError(err)
+	require.Not(other, key)
+}
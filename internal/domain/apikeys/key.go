@@ -0,0 +1,36 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// KeyPrefix makes the keys easy to recognize, e.g. by secret scanners
+	KeyPrefix = "bk_"
+	// PrincipalPrefix is prepended to the key name to build the principal id
+	PrincipalPrefix = "apikey:"
+
+	keyBytes = 32
+)
+
+// GenerateKey
+// Return a new random key along with its hash
+func GenerateKey() (string, string, error) {
+	buf := make([]byte, keyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("cannot generate api key: %w", err)
+	}
+	key := KeyPrefix + hex.EncodeToString(buf)
+	return key, HashKey(key), nil
+}
+
+// HashKey
+// SHA-256 of the key, hex encoded. The keys are random enough for a fast hash
+// to be safe, and it lets them be looked up by their hash
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
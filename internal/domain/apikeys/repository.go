@@ -0,0 +1,18 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	CreateOne(context.Context, *APIKey) (*APIKey, error)
+	GetOneByName(ctx context.Context, name string) (*APIKey, error)
+	// GetOneByHash
+	// Find the key matching a hash, whether it is revoked or not
+	GetOneByHash(ctx context.Context, hash string) (*APIKey, error)
+	List(context.Context) ([]*APIKey, error)
+	// RevokeOne
+	// Prevent a key from being used anymore. Revoking a revoked key does nothing
+	RevokeOne(ctx context.Context, name string, at time.Time) error
+}
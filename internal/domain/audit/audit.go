@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+)
+
+// Entry
+// Record of one mutation done on a breed
+type Entry struct {
+	breedName values.BreedName
+	operation Operation
+	usecase   string
+	actor     string
+	requestID string
+	before    *breeds.Breed
+	after     *breeds.Breed
+	createdAt time.Time
+}
+
+func (e Entry) BreedName() values.BreedName {
+	return e.breedName
+}
+
+func (e Entry) Operation() Operation {
+	return e.operation
+}
+
+func (e Entry) Usecase() string {
+	return e.usecase
+}
+
+func (e Entry) Actor() string {
+	return e.actor
+}
+
+func (e Entry) RequestID() string {
+	return e.requestID
+}
+
+// Before
+// State of the breed before the mutation. Nil on creation
+func (e Entry) Before() *breeds.Breed {
+	return e.before
+}
+
+// After
+// State of the breed after the mutation. Nil on purge
+func (e Entry) After() *breeds.Breed {
+	return e.after
+}
+
+func (e Entry) CreatedAt() time.Time {
+	return e.createdAt
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+type FactoryOpts struct {
+	BreedName string
+	Operation string
+	Usecase   string
+	Actor     string
+	RequestID string
+	Before    *breeds.Breed
+	After     *breeds.Breed
+	CreatedAt time.Time
+}
+
+type Factory struct {
+	FactoryOpts
+}
+
+func NewFactory(opts FactoryOpts) *Factory {
+	return &Factory{
+		FactoryOpts: opts,
+	}
+}
+
+func (f Factory) Instantiate() (*Entry, error) {
+	operation, err := OperationFromString(f.Operation)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidOperation)
+	}
+
+	if err := values.Verify(values.BreedName(f.BreedName)); err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		breedName: values.BreedName(f.BreedName),
+		operation: operation,
+		usecase:   f.Usecase,
+		actor:     f.Actor,
+		requestID: f.RequestID,
+		before:    f.Before,
+		after:     f.After,
+		createdAt: f.CreatedAt,
+	}, nil
+}
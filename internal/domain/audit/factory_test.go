@@ -0,0 +1,79 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestFactory_Instantiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        audit.FactoryOpts
+		want        audit.Operation
+		wantErr     error
+		errContains string
+	}{
+		{
+			name: "valid case -- create",
+			opts: audit.FactoryOpts{
+				BreedName: "test",
+				Operation: audit.OperationCreate.String(),
+				Usecase:   "<CREATE BREED>",
+				Actor:     "back_office",
+				RequestID: "id",
+				CreatedAt: time.Now(),
+			},
+			want: audit.OperationCreate,
+		},
+		{
+			name: "valid case -- delete uppercase",
+			opts: audit.FactoryOpts{
+				BreedName: "test",
+				Operation: "DELETE",
+			},
+			want: audit.OperationDelete,
+		},
+		{
+			name: "invalid case -- invalid operation",
+			opts: audit.FactoryOpts{
+				BreedName: "test",
+				Operation: "invalid",
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: audit.ErrInvalidOperation.Error(),
+		},
+		{
+			name: "invalid case -- invalid breed name",
+			opts: audit.FactoryOpts{
+				BreedName: "test invalid",
+				Operation: audit.OperationUpdate.String(),
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: values.ErrNameInvalid.Error(),
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := audit.NewFactory(tt.opts).Instantiate()
+			if tt.wantErr != nil {
+				require.CmpErrorIs(err, tt.wantErr)
+				require.Contains(err, tt.errContains)
+			} else {
+				require.CmpNoError(err)
+				require.Cmp(got.BreedName().String(), tt.opts.BreedName)
+				require.Cmp(got.Operation(), tt.want)
+				require.Cmp(got.Usecase(), tt.opts.Usecase)
+				require.Cmp(got.Actor(), tt.opts.Actor)
+				require.Cmp(got.RequestID(), tt.opts.RequestID)
+				require.Cmp(got.CreatedAt(), tt.opts.CreatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"errors"
+	"strings"
+)
+
+type Operation int
+
+const (
+	OperationCreate Operation = iota
+	OperationUpdate
+	OperationDelete
+	OperationRestore
+	OperationPurge
+)
+
+var (
+	ErrInvalidOperation = errors.New("audit operation must be one of the following values: [create, update, delete, restore, purge]")
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OperationCreate:
+		return "create"
+	case OperationUpdate:
+		return "update"
+	case OperationDelete:
+		return "delete"
+	case OperationRestore:
+		return "restore"
+	case OperationPurge:
+		return "purge"
+	default:
+		return ""
+	}
+}
+
+func OperationFromString(s string) (Operation, error) {
+	switch strings.ToLower(s) {
+	case "create":
+		return OperationCreate, nil
+	case "update":
+		return OperationUpdate, nil
+	case "delete":
+		return OperationDelete, nil
+	case "restore":
+		return OperationRestore, nil
+	case "purge":
+		return OperationPurge, nil
+	default:
+		return -1, ErrInvalidOperation
+	}
+}
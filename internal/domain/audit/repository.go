@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+)
+
+// Repository
+// Entries are written by the breeds repository along with each mutation,
+// so only reads are exposed here
+type Repository interface {
+	ListByBreedName(context.Context, values.BreedName) ([]*Entry, error)
+}
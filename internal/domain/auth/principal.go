@@ -0,0 +1,34 @@
+package auth
+
+// Principal
+// Authenticated caller of the API
+type Principal struct {
+	id    string
+	roles []Role
+}
+
+func NewPrincipal(id string, roles ...Role) Principal {
+	return Principal{
+		id:    id,
+		roles: roles,
+	}
+}
+
+func (p Principal) ID() string {
+	return p.id
+}
+
+func (p Principal) Roles() []Role {
+	return p.roles
+}
+
+// HasRole
+// Tell whether one of the principal roles grants the required one
+func (p Principal) HasRole(required Role) bool {
+	for _, val := range p.roles {
+		if val.Grants(required) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ResourceBreeds   = "breeds"
+	ResourceWebhooks = "webhooks"
+	ResourceAPIKeys  = "apikeys"
+)
+
+var (
+	ErrInvalidRole = errors.New("role must be formatted as <resource>:<read|write|admin>")
+)
+
+// Role
+// Scope granted on one kind of resource, written as <resource>:<scope>, e.g. breeds:admin
+type Role struct {
+	resource string
+	scope    Scope
+}
+
+func NewRole(resource string, scope Scope) Role {
+	return Role{
+		resource: resource,
+		scope:    scope,
+	}
+}
+
+func RoleFromString(s string) (Role, error) {
+	resource, scope, ok := strings.Cut(s, ":")
+	if !ok || resource == "" {
+		return Role{}, fmt.Errorf("%w, got %s", ErrInvalidRole, s)
+	}
+	val, err := ScopeFromString(scope)
+	if err != nil {
+		return Role{}, fmt.Errorf("%w, got %s", ErrInvalidRole, s)
+	}
+	return NewRole(strings.ToLower(resource), val), nil
+}
+
+func (r Role) Resource() string {
+	return r.resource
+}
+
+func (r Role) Scope() Scope {
+	return r.scope
+}
+
+func (r Role) String() string {
+	return r.resource + ":" + r.scope.String()
+}
+
+// Grants
+// Tell whether the role is enough for an operation requiring the given one
+func (r Role) Grants(required Role) bool {
+	return r.resource == required.resource && r.scope.Grants(required.scope)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+)
+
+// Scope
+// Level of access granted to a principal. Each scope includes the lower ones:
+// admin grants write which grants read
+type Scope int
+
+const (
+	ScopeRead Scope = iota
+	ScopeWrite
+	ScopeAdmin
+)
+
+var (
+	ErrInvalidScope = errors.New("scope must be one of the following values: [read, write, admin]")
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeWrite:
+		return "write"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return ""
+	}
+}
+
+func ScopeFromString(s string) (Scope, error) {
+	switch strings.ToLower(s) {
+	case "read":
+		return ScopeRead, nil
+	case "write":
+		return ScopeWrite, nil
+	case "admin":
+		return ScopeAdmin, nil
+	default:
+		return -1, ErrInvalidScope
+	}
+}
+
+// Grants
+// Tell whether the scope is enough for an operation requiring the given one
+func (s Scope) Grants(required Scope) bool {
+	return s >= required
+}
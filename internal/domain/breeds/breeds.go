@@ -1,6 +1,11 @@
 package breeds
 
-import "github.com/japhy-tech/backend-test/internal/domain/values"
+import (
+	"slices"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+)
 
 type Breed struct {
 	name                values.BreedName
@@ -8,6 +13,10 @@ type Breed struct {
 	petSize             values.PetSize
 	averageFemaleWeight int
 	averageMaleWeight   int
+	deletedAt           *time.Time
+	updatedAt           time.Time
+	// fields read from the storage, nil when all of them were
+	fields []Field
 }
 
 func (b Breed) Name() values.BreedName {
@@ -29,3 +38,31 @@ func (b Breed) AverageFemaleWeight() int {
 func (b Breed) AverageMaleWeight() int {
 	return b.averageMaleWeight
 }
+
+// DeletedAt
+// Date of the soft deletion. Nil if the breed is not deleted
+func (b Breed) DeletedAt() *time.Time {
+	return b.deletedAt
+}
+
+func (b Breed) IsDeleted() bool {
+	return b.deletedAt != nil
+}
+
+// UpdatedAt
+// Date of the last change of the breed, its deletion included
+func (b Breed) UpdatedAt() time.Time {
+	return b.updatedAt
+}
+
+// Has
+// Whether the field was read, the other ones holding their zero value
+func (b Breed) Has(field Field) bool {
+	return b.fields == nil || slices.Contains(b.fields, field)
+}
+
+// Fields
+// Fields read from the storage, nil when all of them were
+func (b Breed) Fields() []Field {
+	return b.fields
+}
@@ -0,0 +1,31 @@
+package breeds
+
+// FieldChange
+// Value of one breed attribute in two states of the same breed
+type FieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// Diff
+// List the attributes which differ between two states of a breed.
+// Fields are named as in the api
+func Diff(from, to *Breed) []FieldChange {
+	var (
+		res    = []FieldChange{}
+		fields = []FieldChange{
+			{Field: string(FieldSpecies), From: from.Species().String(), To: to.Species().String()},
+			{Field: string(FieldPetSize), From: from.PetSize().String(), To: to.PetSize().String()},
+			{Field: string(FieldAverageMaleWeight), From: from.AverageMaleWeight(), To: to.AverageMaleWeight()},
+			{Field: string(FieldAverageFemaleWeight), From: from.AverageFemaleWeight(), To: to.AverageFemaleWeight()},
+		}
+	)
+
+	for _, val := range fields {
+		if val.From != val.To {
+			res = append(res, val)
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package breeds_test
+
+import (
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestDiff(t *testing.T) {
+	base := breeds.FactoryOpts{
+		Name:                "test",
+		Species:             values.Cat.String(),
+		PetSize:             values.Small.String(),
+		AverageFemaleWeight: common.ToPointer(1000),
+		AverageMaleWeight:   common.ToPointer(2000),
+	}
+
+	tests := []struct {
+		name string
+		to   breeds.FactoryOpts
+		want []breeds.FieldChange
+	}{
+		{
+			name: "valid case -- no change",
+			to:   base,
+			want: []breeds.FieldChange{},
+		},
+		{
+			name: "valid case -- species and weight",
+			to: breeds.FactoryOpts{
+				Name:                "test",
+				Species:             values.Dog.String(),
+				PetSize:             values.Small.String(),
+				AverageFemaleWeight: common.ToPointer(1500),
+				AverageMaleWeight:   common.ToPointer(2000),
+			},
+			want: []breeds.FieldChange{
+				{Field: "species", From: "cat", To: "dog"},
+				{Field: "average_female_adult_weight", From: 1000, To: 1500},
+			},
+		},
+		{
+			name: "valid case -- every field",
+			to: breeds.FactoryOpts{
+				Name:    "test",
+				Species: values.Dog.String(),
+				PetSize: values.Tall.String(),
+			},
+			want: []breeds.FieldChange{
+				{Field: "species", From: "cat", To: "dog"},
+				{Field: "pet_size", From: "small", To: "tall"},
+				{Field: "average_male_adult_weight", From: 2000, To: 0},
+				{Field: "average_female_adult_weight", From: 1000, To: 0},
+			},
+		},
+	}
+
+	require := td.Require(t)
+	from, err := breeds.NewFactory(base).Instantiate()
+	require.CmpNoError(err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to, err := breeds.NewFactory(tt.to).Instantiate()
+			require.CmpNoError(err)
+			require.Cmp(breeds.Diff(from, to), tt.want)
+		})
+	}
+}
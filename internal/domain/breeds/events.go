@@ -0,0 +1,211 @@
+package breeds
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+const (
+	EventBreedCreated  = "breed.created"
+	EventBreedUpdated  = "breed.updated"
+	EventBreedDeleted  = "breed.deleted"
+	EventBreedRestored = "breed.restored"
+	EventBreedPurged   = "breed.purged"
+)
+
+// EventNames
+// Every event which can be raised on a breed
+var EventNames = []string{EventBreedCreated, EventBreedUpdated, EventBreedDeleted, EventBreedRestored, EventBreedPurged}
+
+// Event
+// Implemented by every event raised on a breed
+type Event interface {
+	Name() string
+	OccurredAt() time.Time
+	BreedName() values.BreedName
+	// Breed
+	// State of the breed once the event occurred. For a deletion or a purge, the
+	// deleted breed
+	Breed() *Breed
+}
+
+// NewEventAt
+// Event of the given name raised on a breed at occurredAt, e.g. once decoded. before
+// is the state of the breed before an update, the other events ignore it
+func NewEventAt(name string, before, after *Breed, occurredAt time.Time) (Event, error) {
+	switch name {
+	case EventBreedCreated:
+		return BreedCreated{breed: after, occurredAt: occurredAt}, nil
+	case EventBreedUpdated:
+		if before == nil {
+			return nil, domainerror.WrapError(domainerror.ErrDomainValidation, errors.New("updated breed without its previous state"))
+		}
+		return BreedUpdated{before: before, after: after, changes: Diff(before, after), occurredAt: occurredAt}, nil
+	case EventBreedDeleted:
+		return BreedDeleted{breed: after, occurredAt: occurredAt}, nil
+	case EventBreedRestored:
+		return BreedRestored{breed: after, occurredAt: occurredAt}, nil
+	case EventBreedPurged:
+		return BreedPurged{breed: after, occurredAt: occurredAt}, nil
+	default:
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, fmt.Errorf("unknown breed event %s", name))
+	}
+}
+
+type BreedCreated struct {
+	breed      *Breed
+	occurredAt time.Time
+}
+
+func NewBreedCreated(breed *Breed) BreedCreated {
+	return BreedCreated{
+		breed:      breed,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e BreedCreated) Name() string {
+	return EventBreedCreated
+}
+
+func (e BreedCreated) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e BreedCreated) BreedName() values.BreedName {
+	return e.breed.Name()
+}
+
+func (e BreedCreated) Breed() *Breed {
+	return e.breed
+}
+
+type BreedUpdated struct {
+	before     *Breed
+	after      *Breed
+	changes    []FieldChange
+	occurredAt time.Time
+}
+
+func NewBreedUpdated(before, after *Breed) BreedUpdated {
+	return BreedUpdated{
+		before:     before,
+		after:      after,
+		changes:    Diff(before, after),
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e BreedUpdated) Name() string {
+	return EventBreedUpdated
+}
+
+func (e BreedUpdated) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e BreedUpdated) BreedName() values.BreedName {
+	return e.after.Name()
+}
+
+func (e BreedUpdated) Breed() *Breed {
+	return e.after
+}
+
+func (e BreedUpdated) Before() *Breed {
+	return e.before
+}
+
+// Changes
+// Fields which differ between the breed before and after the update
+func (e BreedUpdated) Changes() []FieldChange {
+	return e.changes
+}
+
+type BreedDeleted struct {
+	breed      *Breed
+	occurredAt time.Time
+}
+
+func NewBreedDeleted(breed *Breed) BreedDeleted {
+	return BreedDeleted{
+		breed:      breed,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e BreedDeleted) Name() string {
+	return EventBreedDeleted
+}
+
+func (e BreedDeleted) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e BreedDeleted) BreedName() values.BreedName {
+	return e.breed.Name()
+}
+
+func (e BreedDeleted) Breed() *Breed {
+	return e.breed
+}
+
+type BreedRestored struct {
+	breed      *Breed
+	occurredAt time.Time
+}
+
+func NewBreedRestored(breed *Breed) BreedRestored {
+	return BreedRestored{
+		breed:      breed,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e BreedRestored) Name() string {
+	return EventBreedRestored
+}
+
+func (e BreedRestored) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e BreedRestored) BreedName() values.BreedName {
+	return e.breed.Name()
+}
+
+func (e BreedRestored) Breed() *Breed {
+	return e.breed
+}
+
+type BreedPurged struct {
+	breed      *Breed
+	occurredAt time.Time
+}
+
+func NewBreedPurged(breed *Breed) BreedPurged {
+	return BreedPurged{
+		breed:      breed,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+func (e BreedPurged) Name() string {
+	return EventBreedPurged
+}
+
+func (e BreedPurged) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e BreedPurged) BreedName() values.BreedName {
+	return e.breed.Name()
+}
+
+func (e BreedPurged) Breed() *Breed {
+	return e.breed
+}
@@ -1,6 +1,9 @@
 package breeds
 
 import (
+	"slices"
+	"time"
+
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 	"github.com/japhy-tech/backend-test/internal/domainerror"
 )
@@ -11,6 +14,12 @@ type FactoryOpts struct {
 	PetSize             string
 	AverageFemaleWeight *int
 	AverageMaleWeight   *int
+	DeletedAt           *time.Time
+	// UpdatedAt is the date of the last change, zero for the breeds not stored yet
+	UpdatedAt time.Time
+	// Fields read from the storage, all of them when empty. The other ones are left
+	// to their zero value and are not validated
+	Fields []Field
 }
 
 type Factory struct {
@@ -32,14 +41,35 @@ func (f Factory) SetID(id int) *Factory {
 }
 
 func (f Factory) Instantiate() (*Breed, error) {
-	species, err := values.SpeciesFromString(f.Species)
-	if err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies)
+	var fields []Field
+	if len(f.Fields) > 0 {
+		fields = slices.Clone(AlwaysRead)
+		for _, field := range f.Fields {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	has := func(field Field) bool {
+		return fields == nil || slices.Contains(fields, field)
 	}
 
-	petSize, err := values.PetSizeFromString(f.PetSize)
-	if err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidPetSize)
+	var (
+		species values.Species
+		petSize values.PetSize
+		err     error
+	)
+	if has(FieldSpecies) {
+		species, err = values.SpeciesFromString(f.Species)
+		if err != nil {
+			return nil, domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies)
+		}
+	}
+	if has(FieldPetSize) {
+		petSize, err = values.PetSizeFromString(f.PetSize)
+		if err != nil {
+			return nil, domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidPetSize)
+		}
 	}
 
 	if err := values.Verify(values.BreedName(f.Name)); err != nil {
@@ -60,7 +90,10 @@ func (f Factory) Instantiate() (*Breed, error) {
 			}
 			return 0
 		}(),
-		petSize: petSize,
-		species: species,
+		petSize:   petSize,
+		species:   species,
+		deletedAt: f.DeletedAt,
+		updatedAt: f.UpdatedAt,
+		fields:    fields,
 	}, nil
 }
@@ -133,3 +133,50 @@ func TestFactory_Instantiate(t *testing.T) {
 		})
 	}
 }
+
+func TestFactory_Instantiate_Fields(t *testing.T) {
+	require := td.Require(t)
+
+	got, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "test",
+		Species: values.Dog.String(),
+		Fields:  []breeds.Field{breeds.FieldSpecies},
+	}).Instantiate()
+	require.CmpNoError(err, "the pet size is not validated when it is not read")
+	require.Cmp(got.Species(), values.Dog)
+	require.True(got.Has(breeds.FieldName))
+	require.True(got.Has(breeds.FieldSpecies))
+	require.False(got.Has(breeds.FieldPetSize))
+	require.Cmp(got.Fields(), []breeds.Field{breeds.FieldName, breeds.FieldDeletedAt, breeds.FieldSpecies})
+
+	_, err = breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "test",
+		Species: "invalid",
+		Fields:  []breeds.Field{breeds.FieldSpecies},
+	}).Instantiate()
+	require.CmpErrorIs(err, domainerror.ErrDomainValidation)
+
+	got, err = breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "test",
+		Species: values.Dog.String(),
+		PetSize: values.Small.String(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	require.True(got.Has(breeds.FieldPetSize))
+	require.Nil(got.Fields())
+}
+
+func TestFieldFromString(t *testing.T) {
+	require := td.Require(t)
+
+	got, err := breeds.FieldFromString("pet_size")
+	require.CmpNoError(err)
+	require.Cmp(got, breeds.FieldPetSize)
+
+	_, err = breeds.FieldFromString("unknown")
+	require.CmpErrorIs(err, domainerror.ErrDomainValidation)
+
+	_, err = breeds.RelationFromString("aliases")
+	require.CmpErrorIs(err, domainerror.ErrDomainValidation)
+	require.Contains(err, breeds.ErrInvalidRelation.Error())
+}
@@ -0,0 +1,54 @@
+package breeds
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// Field
+// Attribute of a breed the reads can be restricted to (see ListOpts.Fields)
+type Field string
+
+const (
+	FieldName                Field = "name"
+	FieldSpecies             Field = "species"
+	FieldPetSize             Field = "pet_size"
+	FieldAverageFemaleWeight Field = "average_female_adult_weight"
+	FieldAverageMaleWeight   Field = "average_male_adult_weight"
+	FieldDeletedAt           Field = "deleted_at"
+)
+
+var (
+	// Fields of the breeds, by order of declaration
+	Fields = []Field{FieldName, FieldSpecies, FieldPetSize, FieldAverageFemaleWeight, FieldAverageMaleWeight, FieldDeletedAt}
+	// AlwaysRead are the fields read whatever the restriction, the update date included
+	AlwaysRead = []Field{FieldName, FieldDeletedAt}
+
+	ErrInvalidField    = errors.New("field must be one of the following values: [name, species, pet_size, average_female_adult_weight, average_male_adult_weight, deleted_at]")
+	ErrInvalidRelation = errors.New("relation cannot be included")
+)
+
+func FieldFromString(s string) (Field, error) {
+	if !slices.Contains(Fields, Field(s)) {
+		return "", domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidField)
+	}
+	return Field(s), nil
+}
+
+// Relation
+// Data related to the breeds, loaded along them when included (see ListOpts.Include)
+type Relation string
+
+// Relations which can be included. The breeds have none yet: the aliases and the
+// nutrition profiles will be added here
+var Relations []Relation
+
+func RelationFromString(s string) (Relation, error) {
+	if !slices.Contains(Relations, Relation(s)) {
+		return "", domainerror.WrapError(domainerror.ErrDomainValidation, fmt.Errorf("%w: %s", ErrInvalidRelation, s))
+	}
+	return Relation(s), nil
+}
@@ -2,6 +2,7 @@ package breeds
 
 import (
 	"context"
+	"time"
 
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 )
@@ -12,13 +13,49 @@ type ListOpts struct {
 	AverageMaleWeight   *int
 	PetSize             *values.PetSize
 	NameIn              []string
+	IncludeDeleted      bool
+	// NameAfter
+	// List the breeds whose name sorts after this one, to page through the breeds.
+	// They are sorted by name when it or Limit is set
+	NameAfter *values.BreedName
+	// Limit
+	// List at most this number of breeds, all of them when zero
+	Limit int
+	// Fields
+	// Read only these fields of the breeds, besides AlwaysRead, all of them when empty.
+	// The past states of the catalog are read whole
+	Fields []Field
+	// Include
+	// Load these relations along the breeds
+	Include []Relation
 }
 
-type Repository interface {
+// Reader
+// Read operations shared by the current catalog and its past states (see Repository.AsOf)
+type Reader interface {
 	GetOneByName(context.Context, values.BreedName) (*Breed, error)
+	List(context.Context, ListOpts) ([]*Breed, error)
+}
+
+type Repository interface {
+	Reader
 	CreateOne(context.Context, *Breed) (*Breed, error)
 	UpdateOne(context.Context, *Breed) (*Breed, error)
+	// DeleteOneByName
+	// Soft delete the breed. It is ignored by every read until it is restored or purged
 	DeleteOneByName(context.Context, values.BreedName) error
-	List(context.Context, ListOpts) ([]*Breed, error)
+	RestoreOneByName(context.Context, values.BreedName) (*Breed, error)
+	// PurgeDeleted
+	// Permanently remove the breeds deleted at or before the given date and return how many were removed
+	PurgeDeleted(context.Context, time.Time) (int, error)
+	// AsOf
+	// Read the catalog as it was at the given date. Deleted breeds are never returned
+	AsOf(time.Time) Reader
+	// ListVersions
+	// List every version of a breed from the oldest to the newest
+	ListVersions(context.Context, values.BreedName) ([]*Version, error)
 	CreateSeveral(context.Context, []*Breed) ([]*Breed, error)
+	// CountBySpecies
+	// Count the breeds which are not deleted, by species
+	CountBySpecies(context.Context) (map[values.Species]int, error)
 }
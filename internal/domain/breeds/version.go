@@ -0,0 +1,41 @@
+package breeds
+
+import "time"
+
+// Version
+// State of a breed during [ValidFrom, ValidTo). ValidTo is nil for the current version
+type Version struct {
+	breed     *Breed
+	number    int
+	validFrom time.Time
+	validTo   *time.Time
+}
+
+func NewVersion(breed *Breed, number int, validFrom time.Time, validTo *time.Time) *Version {
+	return &Version{
+		breed:     breed,
+		number:    number,
+		validFrom: validFrom,
+		validTo:   validTo,
+	}
+}
+
+func (v Version) Breed() *Breed {
+	return v.breed
+}
+
+func (v Version) Number() int {
+	return v.number
+}
+
+func (v Version) ValidFrom() time.Time {
+	return v.validFrom
+}
+
+func (v Version) ValidTo() *time.Time {
+	return v.validTo
+}
+
+func (v Version) IsCurrent() bool {
+	return v.validTo == nil
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Event
+// Something which happened in the domain. Name identifies the kind of event (ex: breed.created)
+type Event interface {
+	Name() string
+	OccurredAt() time.Time
+}
+
+// Publisher
+// Dispatch events to whoever is interested in them. Publishing never fails
+// the caller: delivery errors are the publisher concern
+type Publisher interface {
+	Publish(context.Context, ...Event)
+}
+
+type Subscriber interface {
+	Handle(context.Context, Event) error
+}
+
+// SubscriberFunc
+// Use a simple function as a Subscriber
+type SubscriberFunc func(context.Context, Event) error
+
+func (f SubscriberFunc) Handle(ctx context.Context, evt Event) error {
+	return f(ctx, evt)
+}
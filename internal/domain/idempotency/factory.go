@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+const (
+	MaxKeyLength = 255
+)
+
+var (
+	ErrInvalidKey   = errors.New("idempotency key must be between 1 and 255 characters")
+	ErrNoClient     = errors.New("idempotency key client is required")
+	ErrNoHash       = errors.New("idempotency key request hash is required")
+	ErrInvalidDates = errors.New("idempotency key must expire after its creation")
+)
+
+type FactoryOpts struct {
+	Client      string
+	Key         string
+	RequestHash string
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+type Factory struct {
+	FactoryOpts
+}
+
+func NewFactory(opts FactoryOpts) *Factory {
+	return &Factory{
+		FactoryOpts: opts,
+	}
+}
+
+func (f Factory) Instantiate() (*Record, error) {
+	if len(f.Key) == 0 || len(f.Key) > MaxKeyLength {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidKey)
+	}
+	if f.Client == "" {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrNoClient)
+	}
+	if f.RequestHash == "" {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrNoHash)
+	}
+	if !f.ExpiresAt.After(f.CreatedAt) {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidDates)
+	}
+
+	return &Record{
+		client:      f.Client,
+		key:         f.Key,
+		requestHash: f.RequestHash,
+		status:      f.Status,
+		contentType: f.ContentType,
+		body:        f.Body,
+		createdAt:   f.CreatedAt,
+		expiresAt:   f.ExpiresAt,
+	}, nil
+}
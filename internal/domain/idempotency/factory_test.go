@@ -0,0 +1,110 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestFactory_Instantiate(t *testing.T) {
+	now := time.Now()
+	valid := idempotency.FactoryOpts{
+		Client:      "principal:1",
+		Key:         "key",
+		RequestHash: idempotency.HashRequest(http.MethodPost, "/v1/breeds", nil),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	with := func(fn func(*idempotency.FactoryOpts)) idempotency.FactoryOpts {
+		opts := valid
+		fn(&opts)
+		return opts
+	}
+
+	tests := []struct {
+		name    string
+		opts    idempotency.FactoryOpts
+		wantErr error
+	}{
+		{
+			name: "valid case",
+			opts: valid,
+		},
+		{
+			name:    "invalid case -- empty key",
+			opts:    with(func(o *idempotency.FactoryOpts) { o.Key = "" }),
+			wantErr: idempotency.ErrInvalidKey,
+		},
+		{
+			name:    "invalid case -- key too long",
+			opts:    with(func(o *idempotency.FactoryOpts) { o.Key = strings.Repeat("k", idempotency.MaxKeyLength+1) }),
+			wantErr: idempotency.ErrInvalidKey,
+		},
+		{
+			name:    "invalid case -- no client",
+			opts:    with(func(o *idempotency.FactoryOpts) { o.Client = "" }),
+			wantErr: idempotency.ErrNoClient,
+		},
+		{
+			name:    "invalid case -- no request hash",
+			opts:    with(func(o *idempotency.FactoryOpts) { o.RequestHash = "" }),
+			wantErr: idempotency.ErrNoHash,
+		},
+		{
+			name:    "invalid case -- expires before its creation",
+			opts:    with(func(o *idempotency.FactoryOpts) { o.ExpiresAt = now }),
+			wantErr: idempotency.ErrInvalidDates,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := td.Require(t)
+
+			res, err := idempotency.NewFactory(tt.opts).Instantiate()
+			if tt.wantErr != nil {
+				require.CmpErrorIs(err, domainerror.ErrDomainValidation)
+				require.Contains(err.Error(), tt.wantErr.Error())
+				return
+			}
+			require.CmpNoError(err)
+			require.False(res.Completed())
+			require.Cmp(res.Key(), tt.opts.Key)
+		})
+	}
+}
+
+func TestRecord_Complete(t *testing.T) {
+	require := td.Require(t)
+	now := time.Now()
+
+	record, err := idempotency.NewFactory(idempotency.FactoryOpts{
+		Client:      "principal:1",
+		Key:         "key",
+		RequestHash: idempotency.HashRequest(http.MethodPost, "/v1/breeds", nil),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}).Instantiate()
+	require.CmpNoError(err)
+
+	completed := record.Complete(http.StatusCreated, "application/json", []byte(`{}`))
+	require.True(completed.Completed())
+	require.Cmp(completed.Status(), http.StatusCreated)
+	require.Cmp(completed.Body(), []byte(`{}`))
+	require.False(record.Completed(), "the record completed is a copy")
+}
+
+func TestHashRequest(t *testing.T) {
+	require := td.Require(t)
+
+	hash := idempotency.HashRequest(http.MethodPost, "/v1/breeds", []byte(`{"name":"test"}`))
+	require.Len(hash, 64)
+	require.Cmp(idempotency.HashRequest(http.MethodPost, "/v1/breeds", []byte(`{"name":"test"}`)), hash)
+	require.Not(idempotency.HashRequest(http.MethodPut, "/v1/breeds", []byte(`{"name":"test"}`)), hash)
+	require.Not(idempotency.HashRequest(http.MethodPost, "/v1/breeds/name/test", []byte(`{"name":"test"}`)), hash)
+	require.Not(idempotency.HashRequest(http.MethodPost, "/v1/breeds", []byte(`{"name":"other"}`)), hash)
+}
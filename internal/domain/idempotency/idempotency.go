@@ -0,0 +1,82 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Record
+// Request sent by a client with an idempotency key, along with its response once it
+// is completed. The retries of the same request get the response replayed until the
+// record expires
+type Record struct {
+	client      string
+	key         string
+	requestHash string
+	status      int
+	contentType string
+	body        []byte
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+// Client
+// Identity of the caller which sent the key, keys being unique by client
+func (r Record) Client() string {
+	return r.client
+}
+
+func (r Record) Key() string {
+	return r.key
+}
+
+// RequestHash
+// Hash of the request sent with the key (see HashRequest)
+func (r Record) RequestHash() string {
+	return r.requestHash
+}
+
+// Status
+// Status of the response, 0 while the request is in progress
+func (r Record) Status() int {
+	return r.status
+}
+
+func (r Record) ContentType() string {
+	return r.contentType
+}
+
+func (r Record) Body() []byte {
+	return r.body
+}
+
+func (r Record) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r Record) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+func (r Record) Completed() bool {
+	return r.status != 0
+}
+
+// Complete
+// Record the response of the request
+func (r Record) Complete(status int, contentType string, body []byte) *Record {
+	r.status = status
+	r.contentType = contentType
+	r.body = body
+	return &r
+}
+
+// HashRequest
+// SHA-256 of what identifies a request: its method, its path and its body
+func HashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// CreateOne
+	// Reserve the key of the record for its client. It fails with ErrResourceAlreadyExists
+	// while the client holds an unexpired record with the same key
+	CreateOne(context.Context, *Record) error
+	// GetOne
+	// Find the record of a key which is not expired
+	GetOne(ctx context.Context, client, key string) (*Record, error)
+	// UpdateOne
+	// Store the response of a record
+	UpdateOne(context.Context, *Record) error
+	// DeleteOne
+	// Release a key, for the request to be executed again
+	DeleteOne(ctx context.Context, client, key string) error
+	// PurgeExpired
+	// Remove the records expired at the given date and return how many were removed
+	PurgeExpired(context.Context, time.Time) (int, error)
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// Envelope
+// Wire format of a breed event sent outside of the service
+type Envelope struct {
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	BreedName  string          `json:"breed_name"`
+	Breed      BreedPayload    `json:"breed"`
+	Changes    []ChangePayload `json:"changes,omitempty"`
+}
+
+type BreedPayload struct {
+	Name                     string `json:"name"`
+	Species                  string `json:"species"`
+	PetSize                  string `json:"pet_size"`
+	AverageMaleAdultWeight   int    `json:"average_male_adult_weight"`
+	AverageFemaleAdultWeight int    `json:"average_female_adult_weight"`
+}
+
+type ChangePayload struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+func NewEnvelope(evt breeds.Event) Envelope {
+	b := evt.Breed()
+	res := Envelope{
+		Event:      evt.Name(),
+		OccurredAt: evt.OccurredAt(),
+		BreedName:  evt.BreedName().String(),
+		Breed: BreedPayload{
+			Name:                     b.Name().String(),
+			Species:                  b.Species().String(),
+			PetSize:                  b.PetSize().String(),
+			AverageMaleAdultWeight:   b.AverageMaleWeight(),
+			AverageFemaleAdultWeight: b.AverageFemaleWeight(),
+		},
+	}
+	if updated, ok := evt.(breeds.BreedUpdated); ok {
+		for _, val := range updated.Changes() {
+			res.Changes = append(res.Changes, ChangePayload{Field: val.Field, From: val.From, To: val.To})
+		}
+	}
+	return res
+}
+
+// Encode
+// Serialize a breed event to its json Envelope
+func Encode(evt breeds.Event) ([]byte, error) {
+	raw, err := json.Marshal(NewEnvelope(evt))
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return raw, nil
+}
+
+// Decode
+// Deserialize a breed event from its json Envelope
+func Decode(payload []byte) (breeds.Event, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return envelope.ToDomain()
+}
+
+// ToDomain
+// Event of the envelope. The breed before an update is rebuilt from the changes
+func (e Envelope) ToDomain() (breeds.Event, error) {
+	opts := breeds.FactoryOpts{
+		Name:                e.Breed.Name,
+		Species:             e.Breed.Species,
+		PetSize:             e.Breed.PetSize,
+		AverageMaleWeight:   &e.Breed.AverageMaleAdultWeight,
+		AverageFemaleWeight: &e.Breed.AverageFemaleAdultWeight,
+	}
+	after, err := breeds.NewFactory(opts).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+
+	var before *breeds.Breed
+	if e.Event == breeds.EventBreedUpdated {
+		for _, val := range e.Changes {
+			val.revert(&opts)
+		}
+		if before, err = breeds.NewFactory(opts).Instantiate(); err != nil {
+			return nil, err
+		}
+	}
+	return breeds.NewEventAt(e.Event, before, after, e.OccurredAt)
+}
+
+// revert
+// Set the field of the change back to its previous value
+func (c ChangePayload) revert(opts *breeds.FactoryOpts) {
+	switch breeds.Field(c.Field) {
+	case breeds.FieldSpecies:
+		opts.Species = fmt.Sprint(c.From)
+	case breeds.FieldPetSize:
+		opts.PetSize = fmt.Sprint(c.From)
+	case breeds.FieldAverageMaleWeight:
+		opts.AverageMaleWeight = weight(c.From)
+	case breeds.FieldAverageFemaleWeight:
+		opts.AverageFemaleWeight = weight(c.From)
+	}
+}
+
+// weight
+// Weights are decoded from json as float64
+func weight(val interface{}) *int {
+	var res int
+	switch v := val.(type) {
+	case int:
+		res = v
+	case float64:
+		res = int(v)
+	}
+	return &res
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+)
+
+// Message
+// Breed event waiting in the outbox to be relayed outside of the service.
+// Payload is the json encoded Envelope of the event
+type Message struct {
+	id         int64
+	eventName  string
+	breedName  values.BreedName
+	payload    []byte
+	occurredAt time.Time
+	attempts   int
+}
+
+func NewMessage(id int64, eventName string, breedName values.BreedName, payload []byte, occurredAt time.Time, attempts int) *Message {
+	return &Message{
+		id:         id,
+		eventName:  eventName,
+		breedName:  breedName,
+		payload:    payload,
+		occurredAt: occurredAt,
+		attempts:   attempts,
+	}
+}
+
+func (m Message) ID() int64 {
+	return m.id
+}
+
+func (m Message) EventName() string {
+	return m.eventName
+}
+
+func (m Message) BreedName() values.BreedName {
+	return m.breedName
+}
+
+func (m Message) Payload() []byte {
+	return m.payload
+}
+
+func (m Message) OccurredAt() time.Time {
+	return m.occurredAt
+}
+
+// Attempts
+// Number of failed deliveries so far
+func (m Message) Attempts() int {
+	return m.attempts
+}
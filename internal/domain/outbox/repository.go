@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// ListPending
+	// Undelivered messages whose next attempt is due at now, oldest first.
+	// A message is held back while an older message of the same breed is undelivered
+	ListPending(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed
+	// Count one more failed attempt and schedule the next one
+	MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, cause error) error
+	// ListSince
+	// Messages newer than afterID, delivered or not, oldest first
+	ListSince(ctx context.Context, afterID int64, limit int) ([]*Message, error)
+	// LastID
+	// Id of the newest message, 0 if there is none
+	LastID(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,28 @@
+package values
+
+import "errors"
+
+type WebhookSecret string
+
+var (
+	ErrWebhookSecretToShort = errors.New("webhook secret must have at least 16 characters")
+	ErrWebhookSecretToLong  = errors.New("webhook secret must have maximum 255 characters")
+)
+
+// Validate
+// Implements values.Validator interface
+func (w WebhookSecret) Validate() error {
+	l := len(w)
+
+	if l < 16 {
+		return ErrWebhookSecretToShort
+	}
+	if l > 255 {
+		return ErrWebhookSecretToLong
+	}
+	return nil
+}
+
+func (w WebhookSecret) String() string {
+	return string(w)
+}
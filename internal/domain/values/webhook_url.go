@@ -0,0 +1,31 @@
+package values
+
+import (
+	"errors"
+	"net/url"
+)
+
+type WebhookURL string
+
+var (
+	ErrWebhookURLInvalid = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookURLToLong  = errors.New("webhook url must have maximum 2048 characters")
+)
+
+// Validate
+// Implements values.Validator interface
+func (w WebhookURL) Validate() error {
+	if len(w) > 2048 {
+		return ErrWebhookURLToLong
+	}
+
+	u, err := url.Parse(w.String())
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrWebhookURLInvalid
+	}
+	return nil
+}
+
+func (w WebhookURL) String() string {
+	return string(w)
+}
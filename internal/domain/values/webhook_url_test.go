@@ -0,0 +1,80 @@
+package values_test
+
+import (
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestWebhookURL_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       values.WebhookURL
+		wantErr error
+	}{
+		{
+			name: "valid case -- https",
+			u:    "https://example.com/hooks/breeds",
+		},
+		{
+			name: "valid case -- http with port",
+			u:    "http://localhost:8080",
+		},
+		{
+			name:    "invalid case -- relative",
+			u:       "/hooks/breeds",
+			wantErr: values.ErrWebhookURLInvalid,
+		},
+		{
+			name:    "invalid case -- unsupported scheme",
+			u:       "ftp://example.com",
+			wantErr: values.ErrWebhookURLInvalid,
+		},
+		{
+			name:    "invalid case -- too long",
+			u:       values.WebhookURL("https://example.com/" + common.GenString(2048)),
+			wantErr: values.ErrWebhookURLToLong,
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.u.Validate()
+			require.CmpErrorIs(err, tt.wantErr)
+		})
+	}
+}
+
+func TestWebhookSecret_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       values.WebhookSecret
+		wantErr error
+	}{
+		{
+			name: "valid case",
+			s:    "0123456789abcdef",
+		},
+		{
+			name:    "invalid case -- too short",
+			s:       "secret",
+			wantErr: values.ErrWebhookSecretToShort,
+		},
+		{
+			name:    "invalid case -- too long",
+			s:       values.WebhookSecret(common.GenString(256)),
+			wantErr: values.ErrWebhookSecretToLong,
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.s.Validate()
+			require.CmpErrorIs(err, tt.wantErr)
+		})
+	}
+}
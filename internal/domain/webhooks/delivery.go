@@ -0,0 +1,122 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// Delivery
+// One breed event to send to one webhook, and the outcome of its last attempt.
+// EventID is the id of the event in the outbox
+type Delivery struct {
+	id             int64
+	webhookID      string
+	eventID        int64
+	eventName      string
+	payload        []byte
+	status         DeliveryStatus
+	attempts       int
+	lastStatusCode int
+	lastError      string
+	nextAttemptAt  time.Time
+	createdAt      time.Time
+	deliveredAt    *time.Time
+}
+
+func (d Delivery) ID() int64 {
+	return d.id
+}
+
+func (d Delivery) WebhookID() string {
+	return d.webhookID
+}
+
+func (d Delivery) EventID() int64 {
+	return d.eventID
+}
+
+func (d Delivery) EventName() string {
+	return d.eventName
+}
+
+func (d Delivery) Payload() []byte {
+	return d.payload
+}
+
+func (d Delivery) Status() DeliveryStatus {
+	return d.status
+}
+
+func (d Delivery) Attempts() int {
+	return d.attempts
+}
+
+// LastStatusCode
+// Http status answered by the webhook on the last attempt. 0 if it could not be reached
+func (d Delivery) LastStatusCode() int {
+	return d.lastStatusCode
+}
+
+func (d Delivery) LastError() string {
+	return d.lastError
+}
+
+func (d Delivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+func (d Delivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d Delivery) DeliveredAt() *time.Time {
+	return d.deliveredAt
+}
+
+type DeliveryFactoryOpts struct {
+	ID             int64
+	WebhookID      string
+	EventID        int64
+	EventName      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+type DeliveryFactory struct {
+	DeliveryFactoryOpts
+}
+
+func NewDeliveryFactory(opts DeliveryFactoryOpts) *DeliveryFactory {
+	return &DeliveryFactory{
+		DeliveryFactoryOpts: opts,
+	}
+}
+
+func (f DeliveryFactory) Instantiate() (*Delivery, error) {
+	status, err := DeliveryStatusFromString(f.Status)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidDeliveryStatus)
+	}
+
+	return &Delivery{
+		id:             f.ID,
+		webhookID:      f.WebhookID,
+		eventID:        f.EventID,
+		eventName:      f.EventName,
+		payload:        f.Payload,
+		status:         status,
+		attempts:       f.Attempts,
+		lastStatusCode: f.LastStatusCode,
+		lastError:      f.LastError,
+		nextAttemptAt:  f.NextAttemptAt,
+		createdAt:      f.CreatedAt,
+		deliveredAt:    f.DeliveredAt,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"errors"
+	"strings"
+)
+
+type DeliveryStatus int
+
+const (
+	DeliveryPending DeliveryStatus = iota
+	DeliverySucceeded
+	DeliveryFailed
+)
+
+var (
+	ErrInvalidDeliveryStatus = errors.New("delivery status must be one of the following values: [pending, succeeded, failed]")
+)
+
+func (d DeliveryStatus) String() string {
+	switch d {
+	case DeliveryPending:
+		return "pending"
+	case DeliverySucceeded:
+		return "succeeded"
+	case DeliveryFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+func DeliveryStatusFromString(s string) (DeliveryStatus, error) {
+	switch strings.ToLower(s) {
+	case "pending":
+		return DeliveryPending, nil
+	case "succeeded":
+		return DeliverySucceeded, nil
+	case "failed":
+		return DeliveryFailed, nil
+	default:
+		return -1, ErrInvalidDeliveryStatus
+	}
+}
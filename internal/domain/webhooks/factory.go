@@ -0,0 +1,69 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+var (
+	ErrNoEventType      = errors.New("webhook must subscribe to at least one event type")
+	ErrInvalidEventType = fmt.Errorf("webhook event types must be among the following values: [%s]", strings.Join(breeds.EventNames, ", "))
+)
+
+type FactoryOpts struct {
+	ID                  string
+	URL                 string
+	Secret              string
+	EventTypes          []string
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+}
+
+type Factory struct {
+	FactoryOpts
+}
+
+func NewFactory(opts FactoryOpts) *Factory {
+	return &Factory{
+		FactoryOpts: opts,
+	}
+}
+
+func (f Factory) Instantiate() (*Webhook, error) {
+	if err := values.Verify(values.WebhookURL(f.URL), values.WebhookSecret(f.Secret)); err != nil {
+		return nil, err
+	}
+
+	if len(f.EventTypes) == 0 {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrNoEventType)
+	}
+	eventTypes := []string{}
+	for _, val := range f.EventTypes {
+		if !slices.Contains(breeds.EventNames, val) {
+			return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidEventType)
+		}
+		if !slices.Contains(eventTypes, val) {
+			eventTypes = append(eventTypes, val)
+		}
+	}
+
+	return &Webhook{
+		id:                  f.ID,
+		url:                 values.WebhookURL(f.URL),
+		secret:              values.WebhookSecret(f.Secret),
+		eventTypes:          eventTypes,
+		enabled:             f.Enabled,
+		consecutiveFailures: f.ConsecutiveFailures,
+		disabledAt:          f.DisabledAt,
+		createdAt:           f.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestFactory_Instantiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        webhooks.FactoryOpts
+		want        []string
+		wantErr     error
+		errContains string
+	}{
+		{
+			name: "valid case -- duplicated event types",
+			opts: webhooks.FactoryOpts{
+				URL:        "https://example.com/hooks",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{breeds.EventBreedCreated, breeds.EventBreedDeleted, breeds.EventBreedCreated},
+			},
+			want: []string{breeds.EventBreedCreated, breeds.EventBreedDeleted},
+		},
+		{
+			name: "invalid case -- no event type",
+			opts: webhooks.FactoryOpts{
+				URL:    "https://example.com/hooks",
+				Secret: "0123456789abcdef",
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: webhooks.ErrNoEventType.Error(),
+		},
+		{
+			name: "invalid case -- unknown event type",
+			opts: webhooks.FactoryOpts{
+				URL:        "https://example.com/hooks",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"breed.renamed"},
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: webhooks.ErrInvalidEventType.Error(),
+		},
+		{
+			name: "invalid case -- invalid url and secret",
+			opts: webhooks.FactoryOpts{
+				URL:        "example.com",
+				Secret:     "secret",
+				EventTypes: []string{breeds.EventBreedCreated},
+			},
+			wantErr:     domainerror.ErrDomainValidation,
+			errContains: values.ErrWebhookSecretToShort.Error(),
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := webhooks.NewFactory(tt.opts).Instantiate()
+			require.CmpErrorIs(err, tt.wantErr)
+
+			if tt.wantErr != nil {
+				require.Contains(err.Error(), tt.errContains)
+			} else {
+				require.Cmp(res.EventTypes(), tt.want)
+				require.True(res.Subscribes(breeds.EventBreedDeleted))
+				require.False(res.Subscribes(breeds.EventBreedUpdated))
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+// DeliveryFailure
+// Outcome of a failed delivery attempt. The delivery is given up when NextAttemptAt is nil
+type DeliveryFailure struct {
+	StatusCode    int
+	Error         string
+	NextAttemptAt *time.Time
+}
+
+type Repository interface {
+	CreateOne(context.Context, *Webhook) (*Webhook, error)
+	GetOneByID(ctx context.Context, id string) (*Webhook, error)
+	List(context.Context) ([]*Webhook, error)
+	UpdateOne(context.Context, *Webhook) (*Webhook, error)
+	DeleteOneByID(ctx context.Context, id string) error
+
+	// Enqueue
+	// Create one pending delivery of an event per enabled webhook subscribing to it.
+	// Enqueuing the same event twice does nothing the second time
+	Enqueue(ctx context.Context, eventID int64, eventName string, payload []byte) (int, error)
+	// ListDeliveries
+	// Delivery log of a webhook, from the newest to the oldest
+	ListDeliveries(ctx context.Context, webhookID string) ([]*Delivery, error)
+	// ListPendingDeliveries
+	// Pending deliveries of enabled webhooks whose next attempt is due at now, oldest first
+	ListPendingDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+	RecordSuccess(ctx context.Context, deliveryID int64, statusCode int, at time.Time) error
+	// RecordFailure
+	// Count one more failed attempt for the delivery and its webhook. The webhook is
+	// disabled once it reaches disableAfter consecutive failures, which is reported
+	RecordFailure(ctx context.Context, deliveryID int64, failure DeliveryFailure, disableAfter int) (bool, error)
+	// Replay
+	// Schedule a delivery which is not pending anymore to be sent again now
+	Replay(ctx context.Context, webhookID string, deliveryID int64) (*Delivery, error)
+}
@@ -0,0 +1,18 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"breed.created"}`)
+	signature := webhooks.Sign("0123456789abcdef", 1700000000, body)
+
+	td.Cmp(t, signature, td.Re(`^sha256=[0-9a-f]{64}$`))
+	td.CmpTrue(t, webhooks.VerifySignature("0123456789abcdef", 1700000000, body, signature))
+	td.CmpFalse(t, webhooks.VerifySignature("0123456789abcdef", 1700000001, body, signature))
+	td.CmpFalse(t, webhooks.VerifySignature("fedcba9876543210", 1700000000, body, signature))
+}
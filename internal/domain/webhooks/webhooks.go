@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"slices"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+)
+
+// Webhook
+// Subscription of an external endpoint to some breed events. A webhook failing
+// too many deliveries in a row is disabled until it is enabled again
+type Webhook struct {
+	id                  string
+	url                 values.WebhookURL
+	secret              values.WebhookSecret
+	eventTypes          []string
+	enabled             bool
+	consecutiveFailures int
+	disabledAt          *time.Time
+	createdAt           time.Time
+}
+
+func (w Webhook) ID() string {
+	return w.id
+}
+
+func (w Webhook) URL() values.WebhookURL {
+	return w.url
+}
+
+// Secret
+// Key of the HMAC-SHA256 signature of the deliveries
+func (w Webhook) Secret() values.WebhookSecret {
+	return w.secret
+}
+
+func (w Webhook) EventTypes() []string {
+	return w.eventTypes
+}
+
+func (w Webhook) Enabled() bool {
+	return w.enabled
+}
+
+func (w Webhook) ConsecutiveFailures() int {
+	return w.consecutiveFailures
+}
+
+// DisabledAt
+// When the webhook was automatically disabled. Nil if it never was or has been enabled again
+func (w Webhook) DisabledAt() *time.Time {
+	return w.disabledAt
+}
+
+func (w Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// Subscribes
+// Tell whether the webhook is interested in an event
+func (w Webhook) Subscribes(eventName string) bool {
+	return slices.Contains(w.eventTypes, eventName)
+}
@@ -11,6 +11,11 @@ var (
 	ErrResourceAlreadyExists = errors.New("resource already exists error")
 	ErrDomainValidation      = errors.New("resource validation error")
 	ErrNothingTodo           = errors.New("nothing to do error")
+	ErrUnauthenticated       = errors.New("unauthenticated error")
+	ErrForbidden             = errors.New("forbidden error")
+	ErrTooManyRequests       = errors.New("too many requests error")
+	ErrNotAcceptable         = errors.New("not acceptable error")
+	ErrUnprocessableEntity   = errors.New("unprocessable entity error")
 )
 
 func WrapError(wrapper error, errArr ...error) error {
@@ -0,0 +1,177 @@
+package encoders
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// JSON
+// Encode the values as application/json
+type JSON struct{}
+
+func (JSON) ContentType() string {
+	return "application/json"
+}
+
+func (JSON) Encode(w io.Writer, val any) error {
+	return json.NewEncoder(w).Encode(val)
+}
+
+// YAML
+// Encode the values as application/yaml, with the field names of their JSON encoding
+type YAML struct{}
+
+func (YAML) ContentType() string {
+	return "application/yaml"
+}
+
+func (YAML) Encode(w io.Writer, val any) error {
+	generic, err := toGeneric(val)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// MessagePack
+// Encode the values as application/msgpack, with the field names of their JSON encoding
+type MessagePack struct{}
+
+func (MessagePack) ContentType() string {
+	return "application/msgpack"
+}
+
+func (MessagePack) Encode(w io.Writer, val any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	return enc.Encode(val)
+}
+
+// CSV
+// Encode the values as text/csv: a list is written as one row by element and any other
+// value as a single row. The columns are the fields of the JSON encoding sorted by name,
+// the nested objects being flattened as parent.child
+type CSV struct{}
+
+func (CSV) ContentType() string {
+	return "text/csv"
+}
+
+func (CSV) Encode(w io.Writer, val any) error {
+	generic, err := toGeneric(val)
+	if err != nil {
+		return err
+	}
+
+	var elems []any
+	switch v := generic.(type) {
+	case []any:
+		elems = v
+	case nil:
+	default:
+		elems = []any{v}
+	}
+
+	var (
+		rows    = make([]map[string]string, 0, len(elems))
+		columns []string
+	)
+	for _, elem := range elems {
+		row := map[string]string{}
+		if err := flatten(row, "", elem); err != nil {
+			return err
+		}
+		for column := range row {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+		rows = append(rows, row)
+	}
+	slices.Sort(columns)
+
+	cw := csv.NewWriter(w)
+	if len(columns) > 0 {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// flatten
+// Write the cells of the value in the row, under the given column prefix
+func flatten(row map[string]string, prefix string, val any) error {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := flatten(row, key, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		// Lists cannot be spread over the columns, they are kept as JSON
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		row[prefix] = string(raw)
+	case string:
+		row[prefix] = v
+	case float64:
+		row[prefix] = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		row[prefix] = strconv.FormatBool(v)
+	case nil:
+		row[prefix] = ""
+	default:
+		return fmt.Errorf("cannot encode %T as csv", v)
+	}
+	if prefix == "" {
+		return fmt.Errorf("cannot encode %T as csv", val)
+	}
+	return nil
+}
+
+// toGeneric
+// Convert the value to maps, lists and scalars through its JSON encoding, to keep
+// its field names and omitted fields in the other media types
+func toGeneric(val any) (any, error) {
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var res any
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,145 @@
+package encoders_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/encoders"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type nested struct {
+	Version int `json:"version"`
+}
+
+type value struct {
+	Name    string     `json:"name"`
+	Weight  *int       `json:"weight,omitempty"`
+	Date    *time.Time `json:"date,omitempty"`
+	Tags    []string   `json:"tags,omitempty"`
+	Version nested     `json:"nested"`
+}
+
+func TestRegistry_Negotiate(t *testing.T) {
+	registry := encoders.Default()
+
+	tests := []struct {
+		name    string
+		accept  string
+		want    string
+		wantErr error
+	}{
+		{name: "valid case -- no preference", accept: "", want: "application/json"},
+		{name: "valid case -- exact", accept: "text/csv", want: "text/csv"},
+		{name: "valid case -- case insensitive with parameters", accept: "Application/YAML; charset=utf-8", want: "application/yaml"},
+		{name: "valid case -- highest quality", accept: "application/json;q=0.5, application/msgpack", want: "application/msgpack"},
+		{name: "valid case -- ties by order of the registry", accept: "application/msgpack, text/csv", want: "text/csv"},
+		{name: "valid case -- wildcard", accept: "*/*", want: "application/json"},
+		{name: "valid case -- type wildcard", accept: "text/*", want: "text/csv"},
+		{name: "valid case -- most specific range wins", accept: "*/*, application/json;q=0", want: "text/csv"},
+		{name: "invalid case -- not acceptable", accept: "application/xml", wantErr: domainerror.ErrNotAcceptable},
+		{name: "invalid case -- refused", accept: "application/json;q=0", wantErr: domainerror.ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := td.Require(t)
+			got, err := registry.Negotiate(tt.accept)
+			require.CmpErrorIs(err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Cmp(got.ContentType(), tt.want)
+			}
+		})
+	}
+}
+
+type otherJSON struct {
+	encoders.JSON
+}
+
+func (otherJSON) ContentType() string {
+	return "application/json"
+}
+
+func TestRegistry_Register(t *testing.T) {
+	require := td.Require(t)
+
+	registry := encoders.NewRegistry(encoders.CSV{})
+	registry.Register(encoders.JSON{})
+	got, err := registry.Negotiate("")
+	require.CmpNoError(err)
+	require.Cmp(got, encoders.CSV{})
+
+	// The encoder of the same content type is replaced in place
+	registry.Register(otherJSON{})
+	got, err = registry.Negotiate("application/json")
+	require.CmpNoError(err)
+	require.Cmp(got, otherJSON{})
+
+	_, err = encoders.NewRegistry().Negotiate("")
+	require.CmpErrorIs(err, domainerror.ErrInternalError)
+}
+
+func TestEncoders(t *testing.T) {
+	var (
+		date = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		list = []value{
+			{Name: "first", Weight: func() *int { v := 3; return &v }(), Version: nested{Version: 1}},
+			{Name: "second, with comma", Date: &date, Tags: []string{"a", "b"}, Version: nested{Version: 2}},
+		}
+	)
+
+	t.Run("valid case -- json", func(t *testing.T) {
+		var buf bytes.Buffer
+		td.Require(t).CmpNoError(encoders.JSON{}.Encode(&buf, list[0]))
+		td.Cmp(t, buf.String(), `{"name":"first","weight":3,"nested":{"version":1}}`+"\n")
+	})
+
+	t.Run("valid case -- csv list", func(t *testing.T) {
+		var buf bytes.Buffer
+		td.Require(t).CmpNoError(encoders.CSV{}.Encode(&buf, list))
+		td.Cmp(t, buf.String(), "date,name,nested.version,tags,weight\n"+
+			",first,1,,3\n"+
+			`2026-03-01T00:00:00Z,"second, with comma",2,"[""a"",""b""]",`+"\n")
+	})
+
+	t.Run("valid case -- csv single value", func(t *testing.T) {
+		var buf bytes.Buffer
+		td.Require(t).CmpNoError(encoders.CSV{}.Encode(&buf, list[0]))
+		td.Cmp(t, buf.String(), "name,nested.version,weight\nfirst,1,3\n")
+	})
+
+	t.Run("valid case -- csv empty list", func(t *testing.T) {
+		var buf bytes.Buffer
+		td.Require(t).CmpNoError(encoders.CSV{}.Encode(&buf, []value{}))
+		td.Cmp(t, buf.String(), "")
+	})
+
+	t.Run("invalid case -- csv scalar", func(t *testing.T) {
+		td.CmpError(t, encoders.CSV{}.Encode(&bytes.Buffer{}, []string{"a"}))
+	})
+
+	t.Run("valid case -- yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		td.Require(t).CmpNoError(encoders.YAML{}.Encode(&buf, list[0]))
+		td.Cmp(t, buf.String(), "name: first\nnested:\n  version: 1\nweight: 3\n")
+	})
+
+	t.Run("valid case -- msgpack", func(t *testing.T) {
+		require := td.Require(t)
+		var buf bytes.Buffer
+		require.CmpNoError(encoders.MessagePack{}.Encode(&buf, list[1]))
+
+		var got map[string]any
+		require.CmpNoError(msgpack.Unmarshal(buf.Bytes(), &got))
+		require.Cmp(got, td.SuperMapOf(map[string]any{
+			"name":   "second, with comma",
+			"tags":   []any{"a", "b"},
+			"nested": map[string]any{"version": int8(2)},
+		}, td.MapEntries{"date": td.Isa(time.Time{})}))
+		require.Cmp(got, td.Not(td.ContainsKey("weight")))
+	})
+}
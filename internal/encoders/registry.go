@@ -0,0 +1,108 @@
+package encoders
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+)
+
+// Encoder
+// Write the values sent by the api in a media type
+type Encoder interface {
+	// ContentType is the media type negotiated with the Accept header and sent
+	// in the Content-Type one
+	ContentType() string
+	Encode(w io.Writer, val any) error
+}
+
+// Registry
+// Encoders the responses can be negotiated in, by order of preference of the server
+type Registry struct {
+	encoders []Encoder
+}
+
+func NewRegistry(encoders ...Encoder) *Registry {
+	r := &Registry{}
+	for _, enc := range encoders {
+		r.Register(enc)
+	}
+	return r
+}
+
+// Default
+// Registry of JSON, the default, CSV, YAML and MessagePack
+func Default() *Registry {
+	return NewRegistry(JSON{}, CSV{}, YAML{}, MessagePack{})
+}
+
+// Register
+// Add the encoder, replacing the one registered for the same content type. The first
+// encoder registered is sent when the client does not send any preference
+func (r *Registry) Register(enc Encoder) {
+	for i, val := range r.encoders {
+		if strings.EqualFold(val.ContentType(), enc.ContentType()) {
+			r.encoders[i] = enc
+			return
+		}
+	}
+	r.encoders = append(r.encoders, enc)
+}
+
+// Negotiate
+// Encoder of the highest quality for the Accept header, the most specific media range
+// giving the quality of a content type. Ties are broken by the order of the registry
+func (r *Registry) Negotiate(accept string) (Encoder, error) {
+	if len(r.encoders) == 0 {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, fmt.Errorf("no encoder registered"))
+	}
+
+	prefs := httputil.ParsePreferences(accept)
+	if len(prefs) == 0 {
+		return r.encoders[0], nil
+	}
+
+	var (
+		best        Encoder
+		bestQuality float64
+	)
+	for _, enc := range r.encoders {
+		if q := quality(prefs, enc.ContentType()); q > bestQuality {
+			best, bestQuality = enc, q
+		}
+	}
+	if best == nil {
+		return nil, domainerror.WrapError(domainerror.ErrNotAcceptable, fmt.Errorf("none of %q can be sent", accept))
+	}
+	return best, nil
+}
+
+// quality
+// Quality of the most specific media range matching the content type, 0 when none does
+func quality(prefs []httputil.Preference, contentType string) float64 {
+	mainType, _, _ := strings.Cut(strings.ToLower(contentType), "/")
+
+	var (
+		res         float64
+		specificity = -1
+	)
+	for _, pref := range prefs {
+		var s int
+		switch pref.Value {
+		case strings.ToLower(contentType):
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			res, specificity = pref.Quality, s
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/events"
+)
+
+type subscription struct {
+	name       string
+	subscriber events.Subscriber
+	eventNames map[string]struct{}
+}
+
+func (s subscription) accept(evt events.Event) bool {
+	if len(s.eventNames) == 0 {
+		return true
+	}
+	_, ok := s.eventNames[evt.Name()]
+	return ok
+}
+
+// Bus
+// In-process events.Publisher. Events are dispatched synchronously to the
+// subscribers in their subscription order. A failing subscriber is logged and
+// does not prevent the others from receiving the event
+type Bus struct {
+	mu            sync.RWMutex
+	logger        *charmLog.Logger
+	subscriptions []subscription
+}
+
+func New(logger *charmLog.Logger) *Bus {
+	return &Bus{
+		logger: logger,
+	}
+}
+
+// Subscribe
+// Register a subscriber for the given event names, or for every event if none is given
+func (b *Bus) Subscribe(name string, subscriber events.Subscriber, eventNames ...string) {
+	s := subscription{
+		name:       name,
+		subscriber: subscriber,
+		eventNames: make(map[string]struct{}),
+	}
+	for _, val := range eventNames {
+		s.eventNames[val] = struct{}{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, s)
+}
+
+func (b *Bus) Publish(ctx context.Context, evts ...events.Event) {
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, evt := range evts {
+		for _, s := range subscriptions {
+			if !s.accept(evt) {
+				continue
+			}
+			if err := b.dispatch(ctx, s, evt); err != nil {
+				b.logger.Errorf("Subscriber %s failed to handle event %s: %s", s.name, evt.Name(), err)
+			}
+		}
+	}
+}
+
+func (b *Bus) dispatch(ctx context.Context, s subscription, evt events.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.subscriber.Handle(ctx, evt)
+}
+
+// LogSubscriber
+// Subscriber logging every event it receives
+func LogSubscriber(logger *charmLog.Logger) events.Subscriber {
+	return events.SubscriberFunc(func(_ context.Context, evt events.Event) error {
+		logger.Infof("Event %s occurred at %s", evt.Name(), evt.OccurredAt())
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/events"
+	"github.com/japhy-tech/backend-test/internal/eventbus"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+type testEvent string
+
+func (e testEvent) Name() string {
+	return string(e)
+}
+
+func (e testEvent) OccurredAt() time.Time {
+	return time.Time{}
+}
+
+func TestBus_Publish(t *testing.T) {
+	var (
+		require  = td.Require(t)
+		ctx      = context.Background()
+		bus      = eventbus.New(charmLog.New(io.Discard))
+		all      []string
+		filtered []string
+	)
+
+	bus.Subscribe("failing", events.SubscriberFunc(func(context.Context, events.Event) error {
+		return errors.New("failure")
+	}))
+	bus.Subscribe("panicking", events.SubscriberFunc(func(context.Context, events.Event) error {
+		panic("failure")
+	}))
+	bus.Subscribe("all", events.SubscriberFunc(func(_ context.Context, evt events.Event) error {
+		all = append(all, evt.Name())
+		return nil
+	}))
+	bus.Subscribe("filtered", events.SubscriberFunc(func(_ context.Context, evt events.Event) error {
+		filtered = append(filtered, evt.Name())
+		return nil
+	}), "first", "third")
+
+	bus.Publish(ctx, testEvent("first"), testEvent("second"), testEvent("third"))
+
+	require.Cmp(all, []string{"first", "second", "third"})
+	require.Cmp(filtered, []string{"first", "third"})
+}
@@ -0,0 +1,266 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTTL is how long an entry is served before being read again
+	DefaultTTL = time.Minute
+	// DefaultLoadTimeout bounds the reads of the missing entries, which outlive the
+	// request starting them
+	DefaultLoadTimeout = 5 * time.Second
+
+	breedKeyPrefix = "breeds:name:"
+	listKeyPrefix  = "breeds:list:"
+)
+
+// Datastore
+// Cache the reads of the breeds of a datastore
+type Datastore struct {
+	gateways.IDatastore
+	breeds *BreedRepository
+}
+
+func NewDatastore(datastore gateways.IDatastore, store Store, opts ...Option) Datastore {
+	return Datastore{
+		IDatastore: datastore,
+		breeds:     NewBreedRepository(datastore.Breeds(), store, opts...),
+	}
+}
+
+func (d Datastore) Breeds() breeds.Repository {
+	return d.breeds
+}
+
+// Transaction
+// Purge the cache once the transaction is over as well: the reads of a concurrent
+// request may have cached what the transaction changed before it was committed, and
+// its own reads what it changed before it was rolled back
+func (d Datastore) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	defer d.breeds.purge(ctx)
+	return d.IDatastore.Transaction(ctx, fn)
+}
+
+// Option
+// Configure the cache
+type Option func(*BreedRepository)
+
+// WithTTL
+// Serve the entries for the given duration instead of DefaultTTL
+func WithTTL(ttl time.Duration) Option {
+	return func(b *BreedRepository) {
+		b.ttl = ttl
+	}
+}
+
+// WithLoadTimeout
+// Bound the reads of the missing entries with the given duration instead of DefaultLoadTimeout
+func WithLoadTimeout(timeout time.Duration) Option {
+	return func(b *BreedRepository) {
+		b.loadTimeout = timeout
+	}
+}
+
+// BreedRepository
+// Read-through cache of GetOneByName and List. Concurrent misses of an entry are
+// read once, and every write purges the cache. The cache failures are logged and
+// the repository is read instead. The other reads, e.g. AsOf, are not cached, nor
+// the reads made in a transaction, which may see its uncommitted writes
+type BreedRepository struct {
+	breeds.Repository
+	store       Store
+	ttl         time.Duration
+	loadTimeout time.Duration
+	group       *singleflight.Group
+	// generation is incremented by the writes, so that the reads started before
+	// one of them are not cached
+	generation *atomic.Int64
+}
+
+func NewBreedRepository(repository breeds.Repository, store Store, opts ...Option) *BreedRepository {
+	b := &BreedRepository{
+		Repository:  repository,
+		store:       store,
+		ttl:         DefaultTTL,
+		loadTimeout: DefaultLoadTimeout,
+		group:       &singleflight.Group{},
+		generation:  &atomic.Int64{},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// breedEntry
+// Cached breed
+type breedEntry struct {
+	Name                string     `json:"name"`
+	Species             string     `json:"species"`
+	PetSize             string     `json:"pet_size"`
+	AverageFemaleWeight int        `json:"average_female_weight"`
+	AverageMaleWeight   int        `json:"average_male_weight"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	// Fields read, all of them when empty
+	Fields []breeds.Field `json:"fields,omitempty"`
+}
+
+func newBreedEntry(b *breeds.Breed) breedEntry {
+	return breedEntry{
+		Name:                b.Name().String(),
+		Species:             b.Species().String(),
+		PetSize:             b.PetSize().String(),
+		AverageFemaleWeight: b.AverageFemaleWeight(),
+		AverageMaleWeight:   b.AverageMaleWeight(),
+		DeletedAt:           b.DeletedAt(),
+		UpdatedAt:           b.UpdatedAt(),
+		Fields:              b.Fields(),
+	}
+}
+
+func (e breedEntry) ToDomain() (*breeds.Breed, error) {
+	return breeds.NewFactory(breeds.FactoryOpts{
+		Name:                e.Name,
+		Species:             e.Species,
+		PetSize:             e.PetSize,
+		AverageFemaleWeight: &e.AverageFemaleWeight,
+		AverageMaleWeight:   &e.AverageMaleWeight,
+		DeletedAt:           e.DeletedAt,
+		UpdatedAt:           e.UpdatedAt,
+		Fields:              e.Fields,
+	}).Instantiate()
+}
+
+func (b *BreedRepository) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	if _, ok := gateways.Transaction(ctx); ok {
+		return b.Repository.GetOneByName(ctx, name)
+	}
+
+	var entry breedEntry
+	err := b.read(ctx, breedKeyPrefix+name.String(), &entry, func(ctx context.Context) (any, error) {
+		res, err := b.Repository.GetOneByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return newBreedEntry(res), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.ToDomain()
+}
+
+func (b *BreedRepository) List(ctx context.Context, opts breeds.ListOpts) ([]*breeds.Breed, error) {
+	key, err := json.Marshal(opts)
+	if _, ok := gateways.Transaction(ctx); ok || err != nil {
+		return b.Repository.List(ctx, opts)
+	}
+
+	var entries []breedEntry
+	err = b.read(ctx, listKeyPrefix+string(key), &entries, func(ctx context.Context) (any, error) {
+		res, err := b.Repository.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return common.Map(res, newBreedEntry), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return common.EMap(entries, breedEntry.ToDomain)
+}
+
+// read
+// Decode the entry of the key in dst, loading and caching it when it is missing.
+// The load is shared by the concurrent misses of the key, so it leaves the context
+// of the caller starting it: it stops after the load timeout rather than with the
+// caller, and is made out of its transaction. Each caller stops waiting for it with
+// its own context
+func (b *BreedRepository) read(ctx context.Context, key string, dst any, load func(context.Context) (any, error)) error {
+	if val, ok, err := b.store.Get(ctx, key); err != nil {
+		logger.FromContext(ctx).Warnf("cannot read cache entry %s: %s", key, err)
+	} else if ok && json.Unmarshal(val, dst) == nil {
+		return nil
+	}
+
+	ch := b.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(gateways.WithoutTransaction(context.WithoutCancel(ctx)), b.loadTimeout)
+		defer cancel()
+
+		generation := b.generation.Load()
+		res, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+		if generation == b.generation.Load() {
+			if err := b.store.Set(ctx, key, val, b.ttl); err != nil {
+				logger.FromContext(ctx).Warnf("cannot write cache entry %s: %s", key, err)
+			}
+		}
+		return val, nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return res.Err
+		}
+		return json.Unmarshal(res.Val.([]byte), dst)
+	}
+}
+
+// purge
+// Invalidate the whole cache, the lists depending on every breed
+func (b *BreedRepository) purge(ctx context.Context) {
+	b.generation.Add(1)
+	if err := b.store.Purge(ctx); err != nil {
+		logger.FromContext(ctx).Errorf("cannot purge breeds cache: %s", err)
+	}
+}
+
+func (b *BreedRepository) CreateOne(ctx context.Context, breed *breeds.Breed) (*breeds.Breed, error) {
+	defer b.purge(ctx)
+	return b.Repository.CreateOne(ctx, breed)
+}
+
+func (b *BreedRepository) UpdateOne(ctx context.Context, breed *breeds.Breed) (*breeds.Breed, error) {
+	defer b.purge(ctx)
+	return b.Repository.UpdateOne(ctx, breed)
+}
+
+func (b *BreedRepository) DeleteOneByName(ctx context.Context, name values.BreedName) error {
+	defer b.purge(ctx)
+	return b.Repository.DeleteOneByName(ctx, name)
+}
+
+func (b *BreedRepository) RestoreOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	defer b.purge(ctx)
+	return b.Repository.RestoreOneByName(ctx, name)
+}
+
+func (b *BreedRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	defer b.purge(ctx)
+	return b.Repository.PurgeDeleted(ctx, before)
+}
+
+func (b *BreedRepository) CreateSeveral(ctx context.Context, arr []*breeds.Breed) ([]*breeds.Breed, error) {
+	defer b.purge(ctx)
+	return b.Repository.CreateSeveral(ctx, arr)
+}
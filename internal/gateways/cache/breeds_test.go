@@ -0,0 +1,183 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/gateways/cache"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// countRepository
+// Repository of one breed counting its reads, which last delay
+type countRepository struct {
+	breeds.Repository
+	breed *breeds.Breed
+	delay time.Duration
+	reads atomic.Int32
+}
+
+func (c *countRepository) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	c.reads.Add(1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+	}
+	if name != c.breed.Name() {
+		return nil, domainerror.ErrResourceNotFound
+	}
+	return c.breed, nil
+}
+
+func (c *countRepository) List(_ context.Context, _ breeds.ListOpts) ([]*breeds.Breed, error) {
+	c.reads.Add(1)
+	return []*breeds.Breed{c.breed}, nil
+}
+
+func (c *countRepository) CreateOne(_ context.Context, breed *breeds.Breed) (*breeds.Breed, error) {
+	return breed, nil
+}
+
+func TestBreedRepository(t *testing.T) {
+	require := td.Require(t)
+	ctx := context.Background()
+
+	breed, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:                "bengal",
+		Species:             values.Cat.String(),
+		PetSize:             values.Small.String(),
+		AverageFemaleWeight: common.ToPointer(4),
+		AverageMaleWeight:   common.ToPointer(5),
+	}).Instantiate()
+	require.CmpNoError(err)
+	var (
+		next = &countRepository{breed: breed, delay: 20 * time.Millisecond}
+		repo = cache.NewBreedRepository(next, cache.NewMemoryStore(10), cache.WithTTL(time.Minute))
+	)
+
+	t.Run("valid case -- concurrent misses are read once", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := repo.GetOneByName(ctx, breed.Name())
+				td.CmpNoError(t, err)
+				td.Cmp(t, res, breed)
+			}()
+		}
+		wg.Wait()
+		td.Cmp(t, next.reads.Load(), int32(1))
+	})
+
+	t.Run("valid case -- hits are not read", func(t *testing.T) {
+		res, err := repo.GetOneByName(ctx, breed.Name())
+		td.CmpNoError(t, err)
+		td.Cmp(t, res, breed)
+		td.Cmp(t, next.reads.Load(), int32(1))
+
+		for i := 0; i < 2; i++ {
+			arr, err := repo.List(ctx, breeds.ListOpts{Species: common.ToPointer(values.Cat)})
+			td.CmpNoError(t, err)
+			td.Cmp(t, arr, []*breeds.Breed{breed})
+		}
+		td.Cmp(t, next.reads.Load(), int32(2))
+	})
+
+	t.Run("invalid case -- errors are not cached", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := repo.GetOneByName(ctx, "unknown")
+			td.CmpErrorIs(t, err, domainerror.ErrResourceNotFound)
+		}
+		td.Cmp(t, next.reads.Load(), int32(4))
+	})
+
+	t.Run("valid case -- writes invalidate the cache", func(t *testing.T) {
+		_, err := repo.CreateOne(ctx, breed)
+		td.CmpNoError(t, err)
+		_, err = repo.GetOneByName(ctx, breed.Name())
+		td.CmpNoError(t, err)
+		_, err = repo.List(ctx, breeds.ListOpts{Species: common.ToPointer(values.Cat)})
+		td.CmpNoError(t, err)
+		td.Cmp(t, next.reads.Load(), int32(6))
+	})
+}
+
+func TestBreedRepository_Load(t *testing.T) {
+	require := td.Require(t)
+
+	breed, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "bengal",
+		Species: values.Cat.String(),
+		PetSize: values.Small.String(),
+	}).Instantiate()
+	require.CmpNoError(err)
+
+	t.Run("valid case -- the load outlives the caller starting it", func(t *testing.T) {
+		var (
+			next = &countRepository{breed: breed, delay: 50 * time.Millisecond}
+			repo = cache.NewBreedRepository(next, cache.NewMemoryStore(10))
+			wg   sync.WaitGroup
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.GetOneByName(ctx, breed.Name())
+			td.CmpErrorIs(t, err, context.DeadlineExceeded)
+		}()
+		time.Sleep(5 * time.Millisecond)
+		res, err := repo.GetOneByName(context.Background(), breed.Name())
+		td.CmpNoError(t, err)
+		td.Cmp(t, res, breed)
+		wg.Wait()
+		td.Cmp(t, next.reads.Load(), int32(1))
+	})
+
+	t.Run("invalid case -- the load is bounded by its timeout", func(t *testing.T) {
+		var (
+			next = &countRepository{breed: breed, delay: 50 * time.Millisecond}
+			repo = cache.NewBreedRepository(next, cache.NewMemoryStore(10), cache.WithLoadTimeout(10*time.Millisecond))
+		)
+		_, err := repo.GetOneByName(context.Background(), breed.Name())
+		td.CmpErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestBreedRepository_Transaction(t *testing.T) {
+	require := td.Require(t)
+
+	breed, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "bengal",
+		Species: values.Cat.String(),
+		PetSize: values.Small.String(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	var (
+		next  = &countRepository{breed: breed}
+		store = cache.NewMemoryStore(10)
+		repo  = cache.NewBreedRepository(next, store)
+		ctx   = gateways.WithTransaction(context.Background(), "tx")
+	)
+
+	for i := 0; i < 2; i++ {
+		res, err := repo.GetOneByName(ctx, breed.Name())
+		require.CmpNoError(err)
+		require.Cmp(res, breed)
+		_, err = repo.List(ctx, breeds.ListOpts{})
+		require.CmpNoError(err)
+	}
+	require.Cmp(next.reads.Load(), int32(4), "valid case -- read from the repository")
+	require.Cmp(store.Len(), 0, "valid case -- not cached")
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Store
+// Where the entries are cached. An out-of-process store, e.g. on Redis, is shared
+// by the replicas of the service, their writes invalidating it for all of them
+type Store interface {
+	// Get
+	// False when the entry is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	// Purge
+	// Remove every entry
+	Purge(ctx context.Context) error
+}
+
+type memoryEntry struct {
+	key       string
+	val       []byte
+	expiresAt time.Time
+}
+
+// MemoryStore
+// Store in the memory of the process, bounded to a number of entries. The least
+// recently used entries are evicted first
+type MemoryStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+func NewMemoryStore(size int) *MemoryStore {
+	return &MemoryStore{
+		size:    size,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+func (m *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+	m.lru.MoveToFront(elem)
+	return entry.val, true, nil
+}
+
+func (m *MemoryStore) Set(_ context.Context, key string, val []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key: key, val: val, expiresAt: m.now().Add(ttl)})
+	for m.lru.Len() > m.size {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+func (m *MemoryStore) Purge(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = map[string]*list.Element{}
+	m.lru.Init()
+	return nil
+}
+
+// Len
+// Number of entries, expired ones included until they are read or evicted
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+func (m *MemoryStore) remove(elem *list.Element) {
+	m.lru.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
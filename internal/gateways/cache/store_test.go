@@ -0,0 +1,44 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/gateways/cache"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestMemoryStore(t *testing.T) {
+	require := td.Require(t)
+	var (
+		ctx   = context.Background()
+		store = cache.NewMemoryStore(2)
+	)
+
+	require.CmpNoError(store.Set(ctx, "first", []byte("1"), time.Minute))
+	require.CmpNoError(store.Set(ctx, "second", []byte("2"), time.Minute))
+	val, ok, err := store.Get(ctx, "first")
+	require.CmpNoError(err)
+	require.True(ok)
+	require.Cmp(val, []byte("1"))
+
+	// second is the least recently used
+	require.CmpNoError(store.Set(ctx, "third", []byte("3"), time.Minute))
+	require.Cmp(store.Len(), 2)
+	_, ok, err = store.Get(ctx, "second")
+	require.CmpNoError(err)
+	require.False(ok)
+
+	require.CmpNoError(store.Set(ctx, "expired", []byte("4"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, ok, err = store.Get(ctx, "expired")
+	require.CmpNoError(err)
+	require.False(ok)
+
+	require.CmpNoError(store.Purge(ctx))
+	require.Cmp(store.Len(), 0)
+	_, ok, err = store.Get(ctx, "first")
+	require.CmpNoError(err)
+	require.False(ok)
+}
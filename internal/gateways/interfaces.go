@@ -3,13 +3,28 @@ package gateways
 import (
 	"context"
 
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
 )
 
 // IDatastore
 // More repositories could be added
 type IDatastore interface {
 	Breeds() breeds.Repository
+	Audit() audit.Repository
+	Outbox() outbox.Repository
+	Webhooks() webhooks.Repository
+	APIKeys() apikeys.Repository
+	IdempotencyKeys() idempotency.Repository
+	// Transaction
+	// Run the repositories calls of fn, made with the context it is given, in one
+	// transaction. It is committed if fn succeeds, rolled back otherwise
+	Transaction(ctx context.Context, fn func(context.Context) error) error
+	Ping(context.Context) error
 	Close() error
 	Reset(context.Context) error
 }
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// APIKeyStorage
+// API keys in the api_keys table
+type APIKeyStorage struct {
+	db *goqu.Database
+}
+
+func NewAPIKeyStorage(db *goqu.Database) *APIKeyStorage {
+	return &APIKeyStorage{
+		db: db,
+	}
+}
+
+type APIKeyModel struct {
+	ID        string     `db:"id"`
+	Name      string     `db:"name"`
+	KeyHash   string     `db:"key_hash"`
+	Scopes    string     `db:"scopes"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+var apiKeyColumns = []interface{}{
+	goqu.C("id"),
+	goqu.C("name"),
+	goqu.C("key_hash"),
+	goqu.C("scopes"),
+	goqu.C("created_at"),
+	goqu.C("revoked_at"),
+}
+
+func (k APIKeyModel) ToDomain() (*apikeys.APIKey, error) {
+	return apikeys.NewFactory(apikeys.FactoryOpts{
+		ID:        k.ID,
+		Name:      k.Name,
+		Hash:      k.KeyHash,
+		Scopes:    strings.Split(k.Scopes, ","),
+		CreatedAt: k.CreatedAt,
+		RevokedAt: k.RevokedAt,
+	}).Instantiate()
+}
+
+func (s APIKeyStorage) CreateOne(ctx context.Context, input *apikeys.APIKey) (*apikeys.APIKey, error) {
+	_, err := conn(ctx, s.db).Insert(goqu.T("api_keys")).Rows(goqu.Record{
+		"id":         input.ID(),
+		"name":       input.Name(),
+		"key_hash":   input.Hash(),
+		"scopes":     strings.Join(common.Map(input.Scopes(), func(val auth.Scope) string { return val.String() }), ","),
+		"created_at": input.CreatedAt().UTC(),
+	}).Executor().ExecContext(ctx)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return s.GetOneByName(ctx, input.Name())
+}
+
+func (s APIKeyStorage) GetOneByName(ctx context.Context, name string) (*apikeys.APIKey, error) {
+	return s.getOne(ctx, goqu.C("name").Eq(name), fmt.Errorf("api key %s not found", name))
+}
+
+func (s APIKeyStorage) GetOneByHash(ctx context.Context, hash string) (*apikeys.APIKey, error) {
+	return s.getOne(ctx, goqu.C("key_hash").Eq(hash), fmt.Errorf("api key not found"))
+}
+
+func (s APIKeyStorage) getOne(ctx context.Context, where exp.Expression, notFound error) (*apikeys.APIKey, error) {
+	var res APIKeyModel
+
+	found, err := conn(ctx, s.db).From("api_keys").
+		Select(apiKeyColumns...).
+		Where(where).
+		ScanStructContext(ctx, &res)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if !found {
+		return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, notFound)
+	}
+	return res.ToDomain()
+}
+
+func (s APIKeyStorage) List(ctx context.Context) ([]*apikeys.APIKey, error) {
+	var res []APIKeyModel
+
+	query := conn(ctx, s.db).From("api_keys").
+		Select(apiKeyColumns...).
+		Order(goqu.C("name").Asc())
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val APIKeyModel) (*apikeys.APIKey, error) {
+		return val.ToDomain()
+	})
+}
+
+func (s APIKeyStorage) RevokeOne(ctx context.Context, name string, at time.Time) error {
+	if _, err := s.GetOneByName(ctx, name); err != nil {
+		return err
+	}
+
+	_, err := conn(ctx, s.db).Update(goqu.T("api_keys")).
+		Set(goqu.Record{"revoked_at": at.UTC()}).
+		Where(goqu.C("name").Eq(name), goqu.C("revoked_at").IsNull()).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+)
+
+type AuditStorage struct {
+	db *goqu.Database
+}
+
+func NewAuditStorage(db *goqu.Database) *AuditStorage {
+	return &AuditStorage{
+		db: db,
+	}
+}
+
+type AuditModel struct {
+	BreedName      string         `db:"breed_name"`
+	Operation      string         `db:"operation"`
+	Usecase        string         `db:"usecase"`
+	Actor          string         `db:"actor"`
+	RequestID      string         `db:"request_id"`
+	BeforeSnapshot sql.NullString `db:"before_snapshot"`
+	AfterSnapshot  sql.NullString `db:"after_snapshot"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func (a AuditModel) ToDomain() (*audit.Entry, error) {
+	before, err := breedFromSnapshot(a.BeforeSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	after, err := breedFromSnapshot(a.AfterSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return audit.NewFactory(audit.FactoryOpts{
+		BreedName: a.BreedName,
+		Operation: a.Operation,
+		Usecase:   a.Usecase,
+		Actor:     a.Actor,
+		RequestID: a.RequestID,
+		Before:    before,
+		After:     after,
+		CreatedAt: a.CreatedAt,
+	}).Instantiate()
+}
+
+func (a AuditStorage) ListByBreedName(ctx context.Context, name values.BreedName) ([]*audit.Entry, error) {
+	var res []AuditModel
+
+	query := conn(ctx, a.db).From("breed_audit").
+		Select(
+			goqu.C("breed_name"),
+			goqu.C("operation"),
+			goqu.C("usecase"),
+			goqu.C("actor"),
+			goqu.C("request_id"),
+			goqu.C("before_snapshot"),
+			goqu.C("after_snapshot"),
+			goqu.C("created_at"),
+		).
+		Where(goqu.C("breed_name").Eq(name.String())).
+		Order(goqu.C("id").Asc())
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val AuditModel) (*audit.Entry, error) {
+		return val.ToDomain()
+	})
+}
+
+// auditRecord
+// Build the breed_audit row of one mutation. Actor, request id and usecase are
+// taken from the context
+func auditRecord(ctx context.Context, op audit.Operation, name values.BreedName, before, after *breeds.Breed) (goqu.Record, error) {
+	beforeSnapshot, err := breedToSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	afterSnapshot, err := breedToSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return goqu.Record{
+		"breed_name":      name.String(),
+		"operation":       op.String(),
+		"usecase":         reqcontext.Usecase(ctx),
+		"actor":           reqcontext.Actor(ctx),
+		"request_id":      reqcontext.RequestID(ctx),
+		"before_snapshot": beforeSnapshot,
+		"after_snapshot":  afterSnapshot,
+		"created_at":      time.Now().UTC(),
+	}, nil
+}
+
+func insertAudit(ctx context.Context, q queryer, records ...goqu.Record) error {
+	rows := common.Map(records, func(val goqu.Record) interface{} { return val })
+	if _, err := q.Insert(goqu.T("breed_audit")).Rows(rows...).Executor().ExecContext(ctx); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
+
+func recordAudit(ctx context.Context, q queryer, op audit.Operation, name values.BreedName, before, after *breeds.Breed) error {
+	record, err := auditRecord(ctx, op, name, before, after)
+	if err != nil {
+		return err
+	}
+	return insertAudit(ctx, q, record)
+}
+
+func breedToSnapshot(b *breeds.Breed) (sql.NullString, error) {
+	if b == nil {
+		return sql.NullString{}, nil
+	}
+	raw, err := json.Marshal(BreedModelFromDomain(b))
+	if err != nil {
+		return sql.NullString{}, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return sql.NullString{String: string(raw), Valid: true}, nil
+}
+
+func breedFromSnapshot(snapshot sql.NullString) (*breeds.Breed, error) {
+	var model BreedModel
+
+	if !snapshot.Valid {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(snapshot.String), &model); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return model.ToDomain()
+}
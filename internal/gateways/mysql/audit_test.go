@@ -0,0 +1,97 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestAuditStorage_ListByBreedName(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo = datastore.Breeds()
+		)
+
+		ctx = reqcontext.WithActor(ctx, "back_office")
+		ctx = reqcontext.WithRequestID(ctx, "request_id")
+		ctx = reqcontext.WithUsecase(ctx, "<UPDATE BREED>")
+
+		created, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		updated, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Dog.String(),
+			PetSize:             values.Tall.String(),
+			AverageFemaleWeight: common.ToPointer(2),
+			AverageMaleWeight:   common.ToPointer(2),
+		}).Instantiate()
+		require.CmpNoError(err)
+		other, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "other",
+			Species: values.Dog.String(),
+			PetSize: values.Tall.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		_, err = repo.CreateOne(ctx, created)
+		require.CmpNoError(err)
+		_, err = repo.UpdateOne(ctx, updated)
+		require.CmpNoError(err)
+		_, err = repo.UpdateOne(ctx, updated)
+		require.CmpErrorIs(err, domainerror.ErrNothingTodo)
+		require.CmpNoError(repo.DeleteOneByName(ctx, created.Name()))
+		_, err = repo.CreateSeveral(ctx, []*breeds.Breed{other})
+		require.CmpNoError(err)
+
+		res, err := datastore.Audit().ListByBreedName(ctx, created.Name())
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(3))
+
+		for _, val := range res {
+			require.Cmp(val.BreedName(), created.Name())
+			require.Cmp(val.Actor(), "back_office")
+			require.Cmp(val.RequestID(), "request_id")
+			require.Cmp(val.Usecase(), "<UPDATE BREED>")
+			require.Cmp(val.CreatedAt().IsZero(), false)
+		}
+
+		require.Cmp(res[0].Operation(), audit.OperationCreate)
+		require.Nil(res[0].Before())
+		require.Cmp(res[0].After(), created)
+
+		require.Cmp(res[1].Operation(), audit.OperationUpdate)
+		require.Cmp(res[1].Before(), created)
+		require.Cmp(res[1].After(), updated)
+
+		require.Cmp(res[2].Operation(), audit.OperationDelete)
+		require.Cmp(res[2].Before(), updated)
+		require.Cmp(res[2].After().Name(), updated.Name())
+		require.Cmp(res[2].After().IsDeleted(), true)
+
+		res, err = datastore.Audit().ListByBreedName(ctx, other.Name())
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(1))
+		require.Cmp(res[0].Operation(), audit.OperationCreate)
+		require.Cmp(res[0].After(), other)
+
+		res, err = datastore.Audit().ListByBreedName(ctx, "not_found")
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(0))
+	})
+}
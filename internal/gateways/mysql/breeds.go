@@ -2,10 +2,15 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 	"github.com/japhy-tech/backend-test/internal/domainerror"
@@ -22,35 +27,101 @@ func NewBreedStorage(db *goqu.Database) *BreedStorage {
 }
 
 type BreedModel struct {
-	Name                     string `db:"name"`
-	Species                  string `db:"species"`
-	PetSize                  string `db:"pet_size"`
-	AverageMaleAdultWeight   int    `db:"average_male_adult_weight"`
-	AverageFemaleAdultWeight int    `db:"average_female_adult_weight"`
+	Name                     string     `db:"name" json:"name"`
+	Species                  string     `db:"species" json:"species"`
+	PetSize                  string     `db:"pet_size" json:"pet_size"`
+	AverageMaleAdultWeight   int        `db:"average_male_adult_weight" json:"average_male_adult_weight"`
+	AverageFemaleAdultWeight int        `db:"average_female_adult_weight" json:"average_female_adult_weight"`
+	DeletedAt                *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	UpdatedAt                time.Time  `db:"updated_at" json:"-"`
+}
+
+var breedColumns = []interface{}{
+	goqu.C("name"),
+	goqu.C("pet_size"),
+	goqu.C("average_male_adult_weight"),
+	goqu.C("average_female_adult_weight"),
+	goqu.C("species"),
+	goqu.C("deleted_at"),
+	goqu.C("updated_at"),
+}
+
+func BreedModelFromDomain(b *breeds.Breed) BreedModel {
+	return BreedModel{
+		Name:                     b.Name().String(),
+		Species:                  b.Species().String(),
+		PetSize:                  b.PetSize().String(),
+		AverageMaleAdultWeight:   b.AverageMaleWeight(),
+		AverageFemaleAdultWeight: b.AverageFemaleWeight(),
+		DeletedAt:                b.DeletedAt(),
+		UpdatedAt:                b.UpdatedAt(),
+	}
 }
 
 func (b BreedModel) ToDomain() (*breeds.Breed, error) {
+	return b.toDomain(nil)
+}
+
+// toDomain
+// Build the breed of the model read with the given fields only
+func (b BreedModel) toDomain(fields []breeds.Field) (*breeds.Breed, error) {
 	return breeds.NewFactory(breeds.FactoryOpts{
 		Name:                b.Name,
 		Species:             b.Species,
 		PetSize:             b.PetSize,
 		AverageFemaleWeight: &b.AverageFemaleAdultWeight,
 		AverageMaleWeight:   &b.AverageMaleAdultWeight,
+		DeletedAt:           b.DeletedAt,
+		UpdatedAt:           b.UpdatedAt,
+		Fields:              fields,
 	}).Instantiate()
 }
 
+// selectedBreedColumns
+// Columns of the given fields, besides those of breeds.AlwaysRead and the update
+// date. All of them when no field is given
+func selectedBreedColumns(fields []breeds.Field) []interface{} {
+	if len(fields) == 0 {
+		return breedColumns
+	}
+	res := []interface{}{goqu.C("name"), goqu.C("deleted_at"), goqu.C("updated_at")}
+	for _, field := range fields {
+		if !slices.Contains(breeds.AlwaysRead, field) {
+			// The fields are named after their column
+			res = append(res, goqu.C(string(field)))
+		}
+	}
+	return res
+}
+
 func (b BreedStorage) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	return getOneBreedByName(ctx, conn(ctx, b.db), name)
+}
+
+// getOneBreedByName
+// Soft deleted breeds are ignored
+func getOneBreedByName(ctx context.Context, q queryer, name values.BreedName) (*breeds.Breed, error) {
+	return findOneBreed(ctx, q, name, goqu.C("deleted_at").IsNull())
+}
+
+func findOneBreed(ctx context.Context, q queryer, name values.BreedName, where ...exp.Expression) (*breeds.Breed, error) {
+	return scanOneBreed(ctx, q.From("breeds"), name, where...)
+}
+
+// lockOneBreed
+// Find a breed as findOneBreed, locking its row until the end of the transaction so
+// that the state it is changed from cannot change meanwhile
+func lockOneBreed(ctx context.Context, tx *goqu.TxDatabase, name values.BreedName, where ...exp.Expression) (*breeds.Breed, error) {
+	return scanOneBreed(ctx, tx.From("breeds").ForUpdate(exp.Wait), name, where...)
+}
+
+func scanOneBreed(ctx context.Context, from *goqu.SelectDataset, name values.BreedName, where ...exp.Expression) (*breeds.Breed, error) {
 	var res BreedModel
 
-	query := b.db.From("breeds").
-		Select(
-			"name",
-			"pet_size",
-			"average_male_adult_weight",
-			"average_female_adult_weight",
-			"species",
-		).
-		Where(goqu.I("name").Eq(name.String()))
+	query := from.
+		Select(breedColumns...).
+		Where(goqu.I("name").Eq(name.String())).
+		Where(where...)
 	found, err := query.ScanStructContext(ctx, &res)
 	if err != nil {
 		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
@@ -63,72 +134,265 @@ func (b BreedStorage) GetOneByName(ctx context.Context, name values.BreedName) (
 }
 
 func (b BreedStorage) CreateOne(ctx context.Context, input *breeds.Breed) (*breeds.Breed, error) {
-	insert := b.db.Insert(goqu.T("breeds")).Rows(
-		goqu.Record{
-			"name":                        input.Name().String(),
-			"species":                     input.Species().String(),
-			"pet_size":                    input.PetSize().String(),
-			"average_male_adult_weight":   input.AverageMaleWeight(),
-			"average_female_adult_weight": input.AverageFemaleWeight(),
-		},
-	).Executor()
+	var res *breeds.Breed
 
-	if _, err := insert.ExecContext(ctx); err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	err := withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		now := time.Now().UTC()
+
+		// A soft deleted breed keeps its name until it is purged
+		deleted, err := tx.From(goqu.T("breeds")).
+			Where(goqu.C("name").Eq(input.Name()), goqu.C("deleted_at").IsNotNull()).
+			CountContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if deleted > 0 {
+			return domainerror.WrapError(domainerror.ErrResourceAlreadyExists, fmt.Errorf("breed %s is deleted, restore it instead", input.Name()))
+		}
+
+		insert := tx.Insert(goqu.T("breeds")).Rows(
+			goqu.Record{
+				"name":                        input.Name().String(),
+				"species":                     input.Species().String(),
+				"pet_size":                    input.PetSize().String(),
+				"average_male_adult_weight":   input.AverageMaleWeight(),
+				"average_female_adult_weight": input.AverageFemaleWeight(),
+				"updated_at":                  now,
+			},
+		).Executor()
+
+		if _, err := insert.ExecContext(ctx); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		created, err := getOneBreedByName(ctx, tx, input.Name())
+		if err != nil {
+			return err
+		}
+		res = created
+		if err := openVersion(ctx, tx, created, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, audit.OperationCreate, input.Name(), nil, created); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, breeds.NewBreedCreated(created))
+	})
+	if err != nil {
+		return nil, err
 	}
-	return b.GetOneByName(ctx, input.Name())
+	return res, nil
 }
 
 func (b BreedStorage) UpdateOne(ctx context.Context, input *breeds.Breed) (*breeds.Breed, error) {
-	update := b.db.Update(goqu.T("breeds")).
-		Set(goqu.Record{
-			"name":                        input.Name().String(),
-			"species":                     input.Species().String(),
-			"pet_size":                    input.PetSize().String(),
-			"average_male_adult_weight":   input.AverageMaleWeight(),
-			"average_female_adult_weight": input.AverageFemaleWeight(),
-		}).
-		Where(
-			goqu.C("name").Eq(input.Name()),
-		).Executor()
+	var res *breeds.Breed
+
+	err := withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		before, err := lockOneBreed(ctx, tx, input.Name(), goqu.C("deleted_at").IsNull())
+		if errors.Is(err, domainerror.ErrResourceNotFound) {
+			// As for an update matching no row
+			return domainerror.ErrNothingTodo
+		} else if err != nil {
+			return err
+		}
+		// updated_at changing on every update, the rows affected cannot tell an unchanged breed
+		if len(breeds.Diff(before, input)) == 0 {
+			return domainerror.ErrNothingTodo
+		}
 
-	res, err := update.ExecContext(ctx)
+		now := time.Now().UTC()
+		update := tx.Update(goqu.T("breeds")).
+			Set(goqu.Record{
+				"name":                        input.Name().String(),
+				"species":                     input.Species().String(),
+				"pet_size":                    input.PetSize().String(),
+				"average_male_adult_weight":   input.AverageMaleWeight(),
+				"average_female_adult_weight": input.AverageFemaleWeight(),
+				"updated_at":                  now,
+			}).
+			Where(
+				goqu.C("name").Eq(input.Name()),
+				goqu.C("deleted_at").IsNull(),
+			).Executor()
+
+		updated, err := update.ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if i, err := updated.RowsAffected(); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		} else if i == 0 {
+			return domainerror.ErrNothingTodo
+		}
+
+		after, err := getOneBreedByName(ctx, tx, input.Name())
+		if err != nil {
+			return err
+		}
+		res = after
+
+		if err := closeVersion(ctx, tx, input.Name(), now); err != nil {
+			return err
+		}
+		if err := openVersion(ctx, tx, after, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, audit.OperationUpdate, input.Name(), before, after); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, breeds.NewBreedUpdated(before, after))
+	})
 	if err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+		return nil, err
 	}
-	if i, err := res.RowsAffected(); err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
-	} else if i == 0 {
-		return nil, domainerror.ErrNothingTodo
-	}
-
-	return b.GetOneByName(ctx, input.Name())
+	return res, nil
 }
 
 func (b BreedStorage) DeleteOneByName(ctx context.Context, name values.BreedName) error {
-	res, err := b.db.Delete(goqu.T("breeds")).Where(goqu.C("name").Eq(name)).Executor().ExecContext(ctx)
+	return withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		before, err := lockOneBreed(ctx, tx, name, goqu.C("deleted_at").IsNull())
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		res, err := tx.Update(goqu.T("breeds")).
+			Set(goqu.Record{"deleted_at": now, "updated_at": now}).
+			Where(goqu.C("name").Eq(name), goqu.C("deleted_at").IsNull()).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		} else if n == 0 {
+			return domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("breed %s not found", name))
+		}
+		if err := closeVersion(ctx, tx, name, now); err != nil {
+			return err
+		}
+
+		after, err := findOneBreed(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, audit.OperationDelete, name, before, after); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, breeds.NewBreedDeleted(before))
+	})
+}
+
+func (b BreedStorage) RestoreOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	var res *breeds.Breed
+
+	err := withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		before, err := lockOneBreed(ctx, tx, name, goqu.C("deleted_at").IsNotNull())
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		_, err = tx.Update(goqu.T("breeds")).
+			Set(goqu.Record{"deleted_at": nil, "updated_at": now}).
+			Where(goqu.C("name").Eq(name), goqu.C("deleted_at").IsNotNull()).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		after, err := getOneBreedByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		res = after
+		if err := openVersion(ctx, tx, after, now); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx, audit.OperationRestore, name, before, after); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, breeds.NewBreedRestored(after))
+	})
 	if err != nil {
-		return domainerror.WrapError(domainerror.ErrInternalError, err)
+		return nil, err
 	}
-	if n, err := res.RowsAffected(); err != nil {
-		return domainerror.WrapError(domainerror.ErrInternalError, err)
-	} else if n == 0 {
-		return domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("breed %s not found", name))
+	return res, nil
+}
+
+func (b BreedStorage) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+
+	err := withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		var found []BreedModel
+
+		// Locked, for a breed restored meanwhile not to be reported as purged
+		query := tx.From("breeds").
+			Select(breedColumns...).
+			Where(goqu.C("deleted_at").Lte(deletedBefore)).
+			ForUpdate(exp.Wait)
+		if err := query.ScanStructsContext(ctx, &found); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if len(found) == 0 {
+			return nil
+		}
+
+		var (
+			audits []goqu.Record
+			events []breeds.Event
+		)
+		for _, val := range found {
+			before, err := val.ToDomain()
+			if err != nil {
+				return err
+			}
+			record, err := auditRecord(ctx, audit.OperationPurge, before.Name(), before, nil)
+			if err != nil {
+				return err
+			}
+			audits = append(audits, record)
+			events = append(events, breeds.NewBreedPurged(before))
+		}
+
+		names := common.Map(found, func(val BreedModel) string { return val.Name })
+		_, err := tx.Delete(goqu.T("breeds")).
+			Where(goqu.C("name").In(names), goqu.C("deleted_at").IsNotNull()).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		purged = len(found)
+		if err := insertAudit(ctx, tx, audits...); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (b BreedStorage) AsOf(at time.Time) breeds.Reader {
+	return BreedVersionStorage{
+		db:   b.db,
+		asOf: at.UTC(),
 	}
-	return nil
+}
+
+func (b BreedStorage) ListVersions(ctx context.Context, name values.BreedName) ([]*breeds.Version, error) {
+	return listBreedVersions(ctx, conn(ctx, b.db), name)
 }
 
 func (b BreedStorage) List(ctx context.Context, params breeds.ListOpts) ([]*breeds.Breed, error) {
 	var res []BreedModel
 
-	query := b.db.From("breeds").
-		Select(
-			goqu.C("name"),
-			goqu.C("pet_size"),
-			goqu.C("average_male_adult_weight"),
-			goqu.C("average_female_adult_weight"),
-			goqu.C("species"),
-		)
+	query := conn(ctx, b.db).From("breeds").
+		Select(selectedBreedColumns(params.Fields)...)
+	if !params.IncludeDeleted {
+		query = query.Where(goqu.C("deleted_at").IsNull())
+	}
 	if params.Species != nil {
 		query = query.Where(goqu.C("species").Eq(params.Species.String()))
 	}
@@ -144,15 +408,51 @@ func (b BreedStorage) List(ctx context.Context, params breeds.ListOpts) ([]*bree
 	if len(params.NameIn) > 0 {
 		query = query.Where(goqu.C("name").In(params.NameIn))
 	}
+	if params.NameAfter != nil {
+		query = query.Where(goqu.C("name").Gt(params.NameAfter.String()))
+	}
+	if params.NameAfter != nil || params.Limit > 0 {
+		query = query.Order(goqu.C("name").Asc())
+	}
+	if params.Limit > 0 {
+		query = query.Limit(uint(params.Limit))
+	}
 	if err := query.ScanStructsContext(ctx, &res); err != nil {
 		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
 	}
 	return common.EMap(res, func(val BreedModel) (*breeds.Breed, error) {
-		return val.ToDomain()
+		return val.toDomain(params.Fields)
 	})
 }
 
+func (b BreedStorage) CountBySpecies(ctx context.Context) (map[values.Species]int, error) {
+	var rows []struct {
+		Species string `db:"species"`
+		Count   int    `db:"count"`
+	}
+
+	err := conn(ctx, b.db).From("breeds").
+		Select(goqu.C("species"), goqu.COUNT("*").As("count")).
+		Where(goqu.C("deleted_at").IsNull()).
+		GroupBy(goqu.C("species")).
+		ScanStructsContext(ctx, &rows)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+
+	res := map[values.Species]int{}
+	for _, val := range rows {
+		species, err := values.SpeciesFromString(val.Species)
+		if err != nil {
+			return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		res[species] = val.Count
+	}
+	return res, nil
+}
+
 func (b BreedStorage) CreateSeveral(ctx context.Context, arr []*breeds.Breed) ([]*breeds.Breed, error) {
+	now := time.Now().UTC()
 	toInsert := common.Map(arr, func(input *breeds.Breed) interface{} {
 		return goqu.Record{
 			"name":                        input.Name().String(),
@@ -160,12 +460,36 @@ func (b BreedStorage) CreateSeveral(ctx context.Context, arr []*breeds.Breed) ([
 			"pet_size":                    input.PetSize().String(),
 			"average_male_adult_weight":   input.AverageMaleWeight(),
 			"average_female_adult_weight": input.AverageFemaleWeight(),
+			"updated_at":                  now,
 		}
 	})
+	audits, err := common.EMap(arr, func(input *breeds.Breed) (goqu.Record, error) {
+		return auditRecord(ctx, audit.OperationCreate, input.Name(), nil, input)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	insert := b.db.Insert(goqu.T("breeds")).Rows(toInsert...).Executor()
-	if _, err := insert.ExecContext(ctx); err != nil {
-		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	err = withTx(ctx, b.db, func(tx *goqu.TxDatabase) error {
+		insert := tx.Insert(goqu.T("breeds")).Rows(toInsert...).Executor()
+		if _, err := insert.ExecContext(ctx); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		for _, val := range arr {
+			if err := openVersion(ctx, tx, val, now); err != nil {
+				return err
+			}
+		}
+		if err := insertAudit(ctx, tx, audits...); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, common.Map(arr, func(val *breeds.Breed) breeds.Event {
+			return breeds.NewBreedCreated(val)
+		})...)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return arr, nil
 }
@@ -3,9 +3,11 @@ package mysql_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	charmLog "github.com/charmbracelet/log"
 	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 	"github.com/japhy-tech/backend-test/internal/domainerror"
@@ -220,6 +222,18 @@ func TestBreedStorage_UpdateOne(t *testing.T) {
 		require.Cmp(r.AverageFemaleWeight(), bUpdated.AverageFemaleWeight())
 		require.Cmp(r.AverageMaleWeight(), bUpdated.AverageMaleWeight())
 		require.Cmp(r.PetSize(), bUpdated.PetSize())
+
+		_, err = datastore.Breeds().UpdateOne(ctx, bUpdated)
+		require.CmpErrorIs(err, domainerror.ErrNothingTodo, "invalid case -- no change")
+
+		unknown, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "unknown",
+			Species: values.Cat.String(),
+			PetSize: values.Small.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().UpdateOne(ctx, unknown)
+		require.CmpErrorIs(err, domainerror.ErrNothingTodo, "invalid case -- unknown breed")
 	})
 }
 
@@ -382,6 +396,27 @@ func TestBreedStorage_List(t *testing.T) {
 					Species: common.ToPointer(values.Dog),
 				},
 			},
+			{
+				name: "get first page by name",
+				expectedResult: []*breeds.Breed{
+					breedsCreated[1],
+					breedsCreated[2],
+				},
+				filter: breeds.ListOpts{
+					Limit: 2,
+				},
+			},
+			{
+				name: "get page after name",
+				expectedResult: []*breeds.Breed{
+					breedsCreated[0],
+					breedsCreated[4],
+				},
+				filter: breeds.ListOpts{
+					NameAfter: common.ToPointer(values.BreedName("test_cat_small")),
+					Limit:     2,
+				},
+			},
 		}
 
 		for _, tt := range tests {
@@ -393,3 +428,176 @@ func TestBreedStorage_List(t *testing.T) {
 		}
 	})
 }
+
+func TestBreedStorage_RestoreOneByName(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo  = datastore.Breeds()
+			tests = []struct {
+				name      string
+				breedName values.BreedName
+				wantErr   error
+			}{
+				{
+					name:      "valid case",
+					breedName: "test",
+				},
+				{
+					name:      "invalid case -- already restored",
+					breedName: "test",
+					wantErr:   domainerror.ErrResourceNotFound,
+				},
+				{
+					name:      "invalid case -- name not found",
+					breedName: "not_found",
+					wantErr:   domainerror.ErrResourceNotFound,
+				},
+			}
+		)
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Cat.String(),
+			PetSize: values.Small.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		_, err = repo.CreateOne(ctx, b)
+		require.CmpNoError(err)
+		require.CmpNoError(repo.DeleteOneByName(ctx, b.Name()))
+
+		res, err := repo.List(ctx, breeds.ListOpts{})
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(0))
+
+		res, err = repo.List(ctx, breeds.ListOpts{IncludeDeleted: true})
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(1))
+		require.Cmp(res[0].IsDeleted(), true)
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := repo.RestoreOneByName(ctx, tt.breedName)
+				require.CmpErrorIs(err, tt.wantErr)
+
+				if tt.wantErr == nil {
+					require.Cmp(res, storedBreed(b))
+
+					found, err := repo.GetOneByName(ctx, tt.breedName)
+					require.CmpNoError(err)
+					require.Cmp(found.IsDeleted(), false)
+				}
+			})
+		}
+	})
+}
+
+func TestBreedStorage_CreateOne_Deleted(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		repo := datastore.Breeds()
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Cat.String(),
+			PetSize: values.Small.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+		replacement, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Dog.String(),
+			PetSize: values.Tall.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		_, err = repo.CreateOne(ctx, b)
+		require.CmpNoError(err)
+		require.CmpNoError(repo.DeleteOneByName(ctx, b.Name()))
+
+		_, err = repo.CreateOne(ctx, replacement)
+		require.CmpErrorIs(err, domainerror.ErrResourceAlreadyExists, "invalid case -- name of a deleted breed")
+		require.Cmp(err, td.Contains("restore it instead"))
+
+		found, err := repo.List(ctx, breeds.ListOpts{IncludeDeleted: true})
+		require.CmpNoError(err)
+		require.Cmp(found, td.Len(1))
+		require.Cmp(found[0].Species(), values.Cat, "the deleted breed is kept")
+		_, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+		require.CmpNoError(err)
+
+		res, err := repo.CreateOne(ctx, replacement)
+		require.CmpNoError(err, "valid case -- name of a purged breed")
+		require.Cmp(res, storedBreed(replacement))
+	})
+}
+
+func TestBreedStorage_PurgeDeleted(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		repo := datastore.Breeds()
+
+		for _, name := range []string{"deleted", "other_deleted", "kept"} {
+			b, err := breeds.NewFactory(breeds.FactoryOpts{
+				Name:    name,
+				Species: values.Cat.String(),
+				PetSize: values.Small.String(),
+			}).Instantiate()
+			require.CmpNoError(err)
+			_, err = repo.CreateOne(ctx, b)
+			require.CmpNoError(err)
+		}
+		require.CmpNoError(repo.DeleteOneByName(ctx, "deleted"))
+		require.CmpNoError(repo.DeleteOneByName(ctx, "other_deleted"))
+
+		n, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+		require.CmpNoError(err)
+		require.Cmp(n, 0)
+
+		n, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Second))
+		require.CmpNoError(err)
+		require.Cmp(n, 2)
+
+		res, err := repo.List(ctx, breeds.ListOpts{IncludeDeleted: true})
+		require.CmpNoError(err)
+		require.Cmp(common.Map(res, func(val *breeds.Breed) values.BreedName { return val.Name() }), []values.BreedName{"kept"})
+
+		history, err := datastore.Audit().ListByBreedName(ctx, "deleted")
+		require.CmpNoError(err)
+		require.Cmp(history, td.Len(3))
+		require.Cmp(history[2].Operation(), audit.OperationPurge)
+		require.Nil(history[2].After())
+	})
+}
+
+// storedBreed
+// Expect the breed as stored, its date of update being set by the storage
+func storedBreed(b *breeds.Breed) td.TestDeep {
+	return td.Struct(b, td.StructFields{"updatedAt": td.NotZero()})
+}
+
+func TestBreedStorage_List_Fields(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test_fields",
+			Species:             values.Dog.String(),
+			PetSize:             values.Tall.String(),
+			AverageFemaleWeight: common.ToPointer(10),
+			AverageMaleWeight:   common.ToPointer(12),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().CreateOne(ctx, b)
+		require.CmpNoError(err)
+
+		res, err := datastore.Breeds().List(ctx, breeds.ListOpts{
+			PetSize: common.ToPointer(values.Tall),
+			Fields:  []breeds.Field{breeds.FieldSpecies, breeds.FieldAverageMaleWeight},
+		})
+		require.CmpNoError(err)
+		require.Cmp(res, td.Len(1))
+		require.Cmp(res[0].Name(), b.Name())
+		require.Cmp(res[0].Species(), values.Dog)
+		require.Cmp(res[0].AverageMaleWeight(), 12)
+		require.Cmp(res[0].UpdatedAt(), td.NotZero())
+		// The columns which are not selected are left to their zero value
+		require.False(res[0].Has(breeds.FieldPetSize))
+		require.Cmp(res[0].AverageFemaleWeight(), 0)
+	})
+}
@@ -6,37 +6,116 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/XSAM/otelsql"
 	charmLog "github.com/charmbracelet/log"
 	"github.com/doug-martin/goqu/v9"
 	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
 	"github.com/japhy-tech/backend-test/database_actions"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Datastore struct {
-	breeds *BreedStorage
-	logger *charmLog.Logger
-	goquDb *goqu.Database
-	db     *sql.DB
+	breeds          *BreedStorage
+	audit           *AuditStorage
+	outbox          *OutboxStorage
+	webhooks        *WebhookStorage
+	apiKeys         *APIKeyStorage
+	idempotencyKeys *IdempotencyKeyStorage
+	logger          *charmLog.Logger
+	goquDb          *goqu.Database
+	db              *sql.DB
 }
 
 func (d Datastore) Close() error {
 	return d.db.Close()
 }
 
+// Ping
+// Check that the database can be reached
+func (d Datastore) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+// DB
+// The connection pool, to monitor it
+func (d Datastore) DB() *sql.DB {
+	return d.db
+}
+
 func (d Datastore) Reset(ctx context.Context) error {
-	_, err := d.goquDb.Truncate(goqu.T("breeds")).Executor().ExecContext(ctx)
-	if err != nil {
-		return fmt.Errorf("fail to truncate table %w", err)
+	for _, table := range []string{"breeds", "breed_audit", "breed_versions", "breed_outbox", "webhooks", "webhook_deliveries", "api_keys", "idempotency_keys"} {
+		_, err := d.goquDb.Truncate(goqu.T(table)).Executor().ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("fail to truncate table %w", err)
+		}
 	}
 	return nil
 }
 
+// Transaction
+// Run the repositories calls of fn in one transaction, committed if fn succeeds and
+// rolled back otherwise
+func (d Datastore) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return withTx(ctx, d.goquDb, func(tx *goqu.TxDatabase) error {
+		return fn(gateways.WithTransaction(ctx, tx))
+	})
+}
+
 func (d Datastore) Breeds() breeds.Repository {
 	return d.breeds
 }
 
-func New(dsn string, logger *charmLog.Logger) *Datastore {
+func (d Datastore) Audit() audit.Repository {
+	return d.audit
+}
+
+func (d Datastore) Outbox() outbox.Repository {
+	return d.outbox
+}
+
+func (d Datastore) Webhooks() webhooks.Repository {
+	return d.webhooks
+}
+
+func (d Datastore) APIKeys() apikeys.Repository {
+	return d.apiKeys
+}
+
+func (d Datastore) IdempotencyKeys() idempotency.Repository {
+	return d.idempotencyKeys
+}
+
+// Option
+// Configure the datastore
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+}
+
+// WithTracerProvider
+// Trace the queries with the given provider instead of the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+func New(dsn string, logger *charmLog.Logger, opts ...Option) *Datastore {
+	cfg := config{tracerProvider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	err := database_actions.InitMigrator(dsn)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -49,7 +128,12 @@ func New(dsn string, logger *charmLog.Logger) *Datastore {
 		logger.Info(msg)
 	}
 
-	db, err := sql.Open("mysql", dsn)
+	// Every query is traced with its statement
+	db, err := otelsql.Open("mysql", dsn,
+		otelsql.WithTracerProvider(cfg.tracerProvider),
+		otelsql.WithAttributes(semconv.DBSystemMySQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		logger.Fatal(err.Error())
 		os.Exit(1)
@@ -66,9 +150,14 @@ func New(dsn string, logger *charmLog.Logger) *Datastore {
 	goquDB := goqu.New("mysql", db)
 
 	return &Datastore{
-		goquDb: goquDB,
-		breeds: NewBreedStorage(goquDB),
-		db:     db,
-		logger: logger,
+		goquDb:          goquDB,
+		breeds:          NewBreedStorage(goquDB),
+		audit:           NewAuditStorage(goquDB),
+		outbox:          NewOutboxStorage(goquDB),
+		webhooks:        NewWebhookStorage(goquDB),
+		apiKeys:         NewAPIKeyStorage(goquDB),
+		idempotencyKeys: NewIdempotencyKeyStorage(goquDB),
+		db:              db,
+		logger:          logger,
 	}
 }
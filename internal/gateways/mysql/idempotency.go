@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// IdempotencyKeyStorage
+// Idempotency keys in the idempotency_keys table
+type IdempotencyKeyStorage struct {
+	db *goqu.Database
+}
+
+func NewIdempotencyKeyStorage(db *goqu.Database) *IdempotencyKeyStorage {
+	return &IdempotencyKeyStorage{
+		db: db,
+	}
+}
+
+type IdempotencyKeyModel struct {
+	Client         string    `db:"client"`
+	IdempotencyKey string    `db:"idempotency_key"`
+	RequestHash    string    `db:"request_hash"`
+	Status         int       `db:"status"`
+	ContentType    string    `db:"content_type"`
+	Body           []byte    `db:"body"`
+	CreatedAt      time.Time `db:"created_at"`
+	ExpiresAt      time.Time `db:"expires_at"`
+}
+
+var idempotencyKeyColumns = []interface{}{
+	goqu.C("client"),
+	goqu.C("idempotency_key"),
+	goqu.C("request_hash"),
+	goqu.C("status"),
+	goqu.C("content_type"),
+	goqu.C("body"),
+	goqu.C("created_at"),
+	goqu.C("expires_at"),
+}
+
+func (k IdempotencyKeyModel) ToDomain() (*idempotency.Record, error) {
+	return idempotency.NewFactory(idempotency.FactoryOpts{
+		Client:      k.Client,
+		Key:         k.IdempotencyKey,
+		RequestHash: k.RequestHash,
+		Status:      k.Status,
+		ContentType: k.ContentType,
+		Body:        k.Body,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}).Instantiate()
+}
+
+func (s IdempotencyKeyStorage) CreateOne(ctx context.Context, input *idempotency.Record) error {
+	return withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		// An expired record does not hold the key anymore
+		_, err := tx.Delete(goqu.T("idempotency_keys")).
+			Where(
+				goqu.C("client").Eq(input.Client()),
+				goqu.C("idempotency_key").Eq(input.Key()),
+				goqu.C("expires_at").Lte(input.CreatedAt().UTC()),
+			).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		res, err := tx.Insert(goqu.T("idempotency_keys")).Rows(goqu.Record{
+			"client":          input.Client(),
+			"idempotency_key": input.Key(),
+			"request_hash":    input.RequestHash(),
+			"status":          input.Status(),
+			"content_type":    input.ContentType(),
+			"body":            input.Body(),
+			"created_at":      input.CreatedAt().UTC(),
+			"expires_at":      input.ExpiresAt().UTC(),
+		}).OnConflict(goqu.DoNothing()).Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		inserted, err := res.RowsAffected()
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if inserted == 0 {
+			return domainerror.WrapError(domainerror.ErrResourceAlreadyExists, fmt.Errorf("idempotency key %s already used", input.Key()))
+		}
+		return nil
+	})
+}
+
+func (s IdempotencyKeyStorage) GetOne(ctx context.Context, client, key string) (*idempotency.Record, error) {
+	var res IdempotencyKeyModel
+
+	found, err := conn(ctx, s.db).From("idempotency_keys").
+		Select(idempotencyKeyColumns...).
+		Where(
+			goqu.C("client").Eq(client),
+			goqu.C("idempotency_key").Eq(key),
+			goqu.C("expires_at").Gt(time.Now().UTC()),
+		).
+		ScanStructContext(ctx, &res)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if !found {
+		return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("idempotency key %s not found", key))
+	}
+	return res.ToDomain()
+}
+
+func (s IdempotencyKeyStorage) UpdateOne(ctx context.Context, input *idempotency.Record) error {
+	_, err := conn(ctx, s.db).Update(goqu.T("idempotency_keys")).
+		Set(goqu.Record{
+			"status":       input.Status(),
+			"content_type": input.ContentType(),
+			"body":         input.Body(),
+		}).
+		Where(goqu.C("client").Eq(input.Client()), goqu.C("idempotency_key").Eq(input.Key())).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
+
+func (s IdempotencyKeyStorage) DeleteOne(ctx context.Context, client, key string) error {
+	_, err := conn(ctx, s.db).Delete(goqu.T("idempotency_keys")).
+		Where(goqu.C("client").Eq(client), goqu.C("idempotency_key").Eq(key)).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
+
+func (s IdempotencyKeyStorage) PurgeExpired(ctx context.Context, at time.Time) (int, error) {
+	res, err := conn(ctx, s.db).Delete(goqu.T("idempotency_keys")).
+		Where(goqu.C("expires_at").Lte(at.UTC())).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return 0, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return int(purged), nil
+}
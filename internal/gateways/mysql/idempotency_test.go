@@ -0,0 +1,85 @@
+package mysql_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/idempotency"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newIdempotencyRecord(require *td.T, client, key string, createdAt time.Time, ttl time.Duration) *idempotency.Record {
+	res, err := idempotency.NewFactory(idempotency.FactoryOpts{
+		Client:      client,
+		Key:         key,
+		RequestHash: idempotency.HashRequest(http.MethodPost, "/v1/breeds", []byte(key)),
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(ttl),
+	}).Instantiate()
+	require.CmpNoError(err)
+	return res
+}
+
+func TestIdempotencyKeyStorage_CRUD(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo   = datastore.IdempotencyKeys()
+			now    = time.Now()
+			record = newIdempotencyRecord(require, "principal:1", "key", now, time.Hour)
+		)
+
+		require.CmpNoError(repo.CreateOne(ctx, record))
+
+		err := repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:1", "key", now, time.Hour))
+		require.CmpErrorIs(err, domainerror.ErrResourceAlreadyExists)
+		require.CmpNoError(repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:2", "key", now, time.Hour)), "keys are distinct by client")
+
+		got, err := repo.GetOne(ctx, "principal:1", "key")
+		require.CmpNoError(err)
+		require.Cmp(got.RequestHash(), record.RequestHash())
+		require.False(got.Completed())
+
+		require.CmpNoError(repo.UpdateOne(ctx, record.Complete(http.StatusCreated, "application/json", []byte(`{"name":"test"}`))))
+		got, err = repo.GetOne(ctx, "principal:1", "key")
+		require.CmpNoError(err)
+		require.Cmp(got.Status(), http.StatusCreated)
+		require.Cmp(got.ContentType(), "application/json")
+		require.Cmp(got.Body(), []byte(`{"name":"test"}`))
+
+		require.CmpNoError(repo.DeleteOne(ctx, "principal:1", "key"))
+		_, err = repo.GetOne(ctx, "principal:1", "key")
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+	})
+}
+
+func TestIdempotencyKeyStorage_Expiration(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo = datastore.IdempotencyKeys()
+			past = time.Now().Add(-2 * time.Hour)
+		)
+
+		require.CmpNoError(repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:1", "expired", past, time.Hour)))
+		require.CmpNoError(repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:1", "other", past, time.Hour)))
+		require.CmpNoError(repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:1", "alive", time.Now(), time.Hour)))
+
+		_, err := repo.GetOne(ctx, "principal:1", "expired")
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+
+		require.CmpNoError(repo.CreateOne(ctx, newIdempotencyRecord(require, "principal:1", "expired", time.Now(), time.Hour)), "an expired key can be reused")
+		_, err = repo.GetOne(ctx, "principal:1", "expired")
+		require.CmpNoError(err)
+
+		purged, err := repo.PurgeExpired(ctx, time.Now())
+		require.CmpNoError(err)
+		require.Cmp(purged, 1)
+		_, err = repo.GetOne(ctx, "principal:1", "alive")
+		require.CmpNoError(err)
+	})
+}
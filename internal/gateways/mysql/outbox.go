@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// OutboxStorage
+// Breed events written to the breed_outbox table in the same transaction as
+// the mutation raising them
+type OutboxStorage struct {
+	db *goqu.Database
+}
+
+func NewOutboxStorage(db *goqu.Database) *OutboxStorage {
+	return &OutboxStorage{
+		db: db,
+	}
+}
+
+type OutboxModel struct {
+	ID         int64     `db:"id"`
+	EventName  string    `db:"event_name"`
+	BreedName  string    `db:"breed_name"`
+	Payload    string    `db:"payload"`
+	OccurredAt time.Time `db:"occurred_at"`
+	Attempts   int       `db:"attempts"`
+}
+
+func (o OutboxModel) ToDomain() *outbox.Message {
+	return outbox.NewMessage(o.ID, o.EventName, values.BreedName(o.BreedName), []byte(o.Payload), o.OccurredAt, o.Attempts)
+}
+
+var outboxColumns = []interface{}{
+	goqu.C("id"),
+	goqu.C("event_name"),
+	goqu.C("breed_name"),
+	goqu.C("payload"),
+	goqu.C("occurred_at"),
+	goqu.C("attempts"),
+}
+
+func (o OutboxStorage) ListPending(ctx context.Context, now time.Time, limit int) ([]*outbox.Message, error) {
+	older := conn(ctx, o.db).From(goqu.T("breed_outbox").As("older")).
+		Select(goqu.L("1")).
+		Where(
+			goqu.I("older.breed_name").Eq(goqu.I("breed_outbox.breed_name")),
+			goqu.I("older.delivered_at").IsNull(),
+			goqu.I("older.id").Lt(goqu.I("breed_outbox.id")),
+		)
+	return o.list(ctx, limit,
+		goqu.C("delivered_at").IsNull(),
+		goqu.C("next_attempt_at").Lte(now.UTC()),
+		goqu.L("NOT EXISTS ?", older),
+	)
+}
+
+func (o OutboxStorage) ListSince(ctx context.Context, afterID int64, limit int) ([]*outbox.Message, error) {
+	return o.list(ctx, limit, goqu.C("id").Gt(afterID))
+}
+
+func (o OutboxStorage) list(ctx context.Context, limit int, where ...exp.Expression) ([]*outbox.Message, error) {
+	var res []OutboxModel
+
+	query := conn(ctx, o.db).From("breed_outbox").
+		Select(outboxColumns...).
+		Where(where...).
+		Order(goqu.C("id").Asc()).
+		Limit(uint(limit))
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.Map(res, func(val OutboxModel) *outbox.Message {
+		return val.ToDomain()
+	}), nil
+}
+
+func (o OutboxStorage) LastID(ctx context.Context) (int64, error) {
+	var id int64
+
+	_, err := conn(ctx, o.db).From("breed_outbox").
+		Select(goqu.COALESCE(goqu.MAX("id"), 0)).
+		ScanValContext(ctx, &id)
+	if err != nil {
+		return 0, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return id, nil
+}
+
+func (o OutboxStorage) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	return o.update(ctx, id, goqu.Record{
+		"delivered_at": at.UTC(),
+		"last_error":   nil,
+	})
+}
+
+func (o OutboxStorage) MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, cause error) error {
+	return o.update(ctx, id, goqu.Record{
+		"attempts":        goqu.L("attempts + 1"),
+		"next_attempt_at": nextAttemptAt.UTC(),
+		"last_error":      cause.Error(),
+	})
+}
+
+func (o OutboxStorage) update(ctx context.Context, id int64, set goqu.Record) error {
+	res, err := conn(ctx, o.db).Update(goqu.T("breed_outbox")).
+		Set(set).
+		Where(goqu.C("id").Eq(id), goqu.C("delivered_at").IsNull()).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	} else if n == 0 {
+		return domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("pending outbox message %d not found", id))
+	}
+	return nil
+}
+
+// outboxRecord
+// Build the breed_outbox row of one event. Its first attempt is due immediately
+func outboxRecord(evt breeds.Event) (goqu.Record, error) {
+	payload, err := outbox.Encode(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return goqu.Record{
+		"event_name":      evt.Name(),
+		"breed_name":      evt.BreedName().String(),
+		"payload":         string(payload),
+		"occurred_at":     evt.OccurredAt(),
+		"next_attempt_at": evt.OccurredAt(),
+	}, nil
+}
+
+func insertOutbox(ctx context.Context, q queryer, evts ...breeds.Event) error {
+	rows, err := common.EMap(evts, func(val breeds.Event) (interface{}, error) {
+		return outboxRecord(val)
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := q.Insert(goqu.T("breed_outbox")).Rows(rows...).Executor().ExecContext(ctx); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
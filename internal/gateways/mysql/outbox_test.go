@@ -0,0 +1,153 @@
+package mysql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestOutboxStorage(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo   = datastore.Breeds()
+			outbx  = datastore.Outbox()
+			future = time.Now().Add(time.Hour)
+		)
+
+		created, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		updated, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Tall.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		other, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "other",
+			Species: values.Dog.String(),
+			PetSize: values.Tall.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		_, err = repo.CreateOne(ctx, created)
+		require.CmpNoError(err)
+		_, err = repo.UpdateOne(ctx, updated)
+		require.CmpNoError(err)
+		_, err = repo.CreateOne(ctx, other)
+		require.CmpNoError(err)
+
+		// The update of test waits for its creation to be delivered
+		pending, err := outbx.ListPending(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Cmp(common.Map(pending, func(val *outbox.Message) string {
+			return val.BreedName().String() + " " + val.EventName()
+		}), []string{"test breed.created", "other breed.created"})
+
+		require.CmpNoError(outbx.MarkFailed(ctx, pending[0].ID(), future, errors.New("sink down")))
+		require.CmpNoError(outbx.MarkDelivered(ctx, pending[1].ID(), time.Now()))
+		require.CmpErrorIs(outbx.MarkDelivered(ctx, pending[1].ID(), time.Now()), domainerror.ErrResourceNotFound)
+
+		pending, err = outbx.ListPending(ctx, time.Now(), 10)
+		require.CmpNoError(err)
+		require.Empty(pending)
+
+		pending, err = outbx.ListPending(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Len(pending, 1)
+		require.Cmp(pending[0].EventName(), breeds.EventBreedCreated)
+		require.Cmp(pending[0].Attempts(), 1)
+		require.CmpNoError(outbx.MarkDelivered(ctx, pending[0].ID(), time.Now()))
+
+		pending, err = outbx.ListPending(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Len(pending, 1)
+		require.Cmp(pending[0].EventName(), breeds.EventBreedUpdated)
+
+		var envelope outbox.Envelope
+		require.CmpNoError(json.Unmarshal(pending[0].Payload(), &envelope))
+		require.Cmp(envelope, td.SStruct(outbox.Envelope{
+			Event:     breeds.EventBreedUpdated,
+			BreedName: "test",
+			Breed: outbox.BreedPayload{
+				Name:                     "test",
+				Species:                  values.Cat.String(),
+				PetSize:                  values.Tall.String(),
+				AverageMaleAdultWeight:   1,
+				AverageFemaleAdultWeight: 1,
+			},
+			Changes: []outbox.ChangePayload{
+				{Field: "pet_size", From: values.Medium.String(), To: values.Tall.String()},
+			},
+		}, td.StructFields{"OccurredAt": td.NotZero()}))
+	})
+}
+
+func TestOutboxStorage_RestoreAndPurge(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		repo := datastore.Breeds()
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Cat.String(),
+			PetSize: values.Small.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		_, err = repo.CreateOne(ctx, b)
+		require.CmpNoError(err)
+		require.CmpNoError(repo.DeleteOneByName(ctx, b.Name()))
+		_, err = repo.RestoreOneByName(ctx, b.Name())
+		require.CmpNoError(err)
+		require.CmpNoError(repo.DeleteOneByName(ctx, b.Name()))
+		n, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+		require.CmpNoError(err)
+		require.Cmp(n, 1)
+
+		// The messages of a breed are listed one at a time, in order
+		var found []*outbox.Message
+		for {
+			pending, err := datastore.Outbox().ListPending(ctx, time.Now().Add(time.Hour), 10)
+			require.CmpNoError(err)
+			if len(pending) == 0 {
+				break
+			}
+			require.Len(pending, 1)
+			require.CmpNoError(datastore.Outbox().MarkDelivered(ctx, pending[0].ID(), time.Now()))
+			found = append(found, pending[0])
+		}
+		require.Cmp(common.Map(found, func(val *outbox.Message) string {
+			return val.EventName()
+		}), []string{
+			breeds.EventBreedCreated,
+			breeds.EventBreedDeleted,
+			breeds.EventBreedRestored,
+			breeds.EventBreedDeleted,
+			breeds.EventBreedPurged,
+		})
+
+		var envelope outbox.Envelope
+		require.CmpNoError(json.Unmarshal(found[4].Payload(), &envelope))
+		require.Cmp(envelope.BreedName, "test")
+		require.Cmp(envelope.Breed.Species, values.Cat.String())
+	})
+}
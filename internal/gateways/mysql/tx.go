@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+)
+
+// queryer
+// Implemented by both *goqu.Database and *goqu.TxDatabase, so that helpers
+// can be shared inside and outside of a transaction
+type queryer interface {
+	From(...interface{}) *goqu.SelectDataset
+	Insert(interface{}) *goqu.InsertDataset
+	Update(interface{}) *goqu.UpdateDataset
+	Delete(interface{}) *goqu.DeleteDataset
+}
+
+// conn
+// Transaction of the context when the call is part of one (see Datastore.Transaction),
+// db otherwise
+func conn(ctx context.Context, db *goqu.Database) queryer {
+	if tx, ok := contextTx(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+func contextTx(ctx context.Context) (*goqu.TxDatabase, bool) {
+	tx, ok := gateways.Transaction(ctx)
+	if !ok {
+		return nil, false
+	}
+	res, ok := tx.(*goqu.TxDatabase)
+	return res, ok
+}
+
+// withTx
+// Run fn inside a transaction. It is committed if fn succeeds, rolled back otherwise.
+// When the context already carries a transaction, fn joins it and its outcome is
+// left to whoever began it
+func withTx(ctx context.Context, db *goqu.Database, fn func(*goqu.TxDatabase) error) error {
+	if tx, ok := contextTx(ctx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
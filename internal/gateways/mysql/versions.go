@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// BreedVersionStorage
+// Read the breeds catalog as it was at a given date from the breed_versions table.
+// A version is valid during [valid_from, valid_to)
+type BreedVersionStorage struct {
+	db   *goqu.Database
+	asOf time.Time
+}
+
+type BreedVersionModel struct {
+	Name                     string     `db:"name"`
+	Version                  int        `db:"version"`
+	Species                  string     `db:"species"`
+	PetSize                  string     `db:"pet_size"`
+	AverageMaleAdultWeight   int        `db:"average_male_adult_weight"`
+	AverageFemaleAdultWeight int        `db:"average_female_adult_weight"`
+	ValidFrom                time.Time  `db:"valid_from"`
+	ValidTo                  *time.Time `db:"valid_to"`
+}
+
+var breedVersionColumns = []interface{}{
+	goqu.C("name"),
+	goqu.C("version"),
+	goqu.C("species"),
+	goqu.C("pet_size"),
+	goqu.C("average_male_adult_weight"),
+	goqu.C("average_female_adult_weight"),
+	goqu.C("valid_from"),
+	goqu.C("valid_to"),
+}
+
+func (v BreedVersionModel) ToDomain() (*breeds.Version, error) {
+	b, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:                v.Name,
+		Species:             v.Species,
+		PetSize:             v.PetSize,
+		AverageFemaleWeight: &v.AverageFemaleAdultWeight,
+		AverageMaleWeight:   &v.AverageMaleAdultWeight,
+		UpdatedAt:           v.ValidFrom,
+	}).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+	return breeds.NewVersion(b, v.Version, v.ValidFrom, v.ValidTo), nil
+}
+
+func (b BreedVersionStorage) query(ctx context.Context) *goqu.SelectDataset {
+	return conn(ctx, b.db).From("breed_versions").
+		Select(breedVersionColumns...).
+		Where(
+			goqu.C("valid_from").Lte(b.asOf),
+			goqu.Or(goqu.C("valid_to").IsNull(), goqu.C("valid_to").Gt(b.asOf)),
+		)
+}
+
+func (b BreedVersionStorage) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	var res BreedVersionModel
+
+	found, err := b.query(ctx).Where(goqu.C("name").Eq(name.String())).ScanStructContext(ctx, &res)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if !found {
+		return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("breed %s not found at %s", name, b.asOf.Format(time.RFC3339)))
+	}
+
+	version, err := res.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	return version.Breed(), nil
+}
+
+func (b BreedVersionStorage) List(ctx context.Context, params breeds.ListOpts) ([]*breeds.Breed, error) {
+	var res []BreedVersionModel
+
+	query := b.query(ctx)
+	if params.Species != nil {
+		query = query.Where(goqu.C("species").Eq(params.Species.String()))
+	}
+	if params.AverageFemaleWeight != nil {
+		query = query.Where(goqu.C("average_female_adult_weight").Eq(*params.AverageFemaleWeight))
+	}
+	if params.AverageMaleWeight != nil {
+		query = query.Where(goqu.C("average_male_adult_weight").Eq(*params.AverageMaleWeight))
+	}
+	if params.PetSize != nil {
+		query = query.Where(goqu.C("pet_size").Eq(params.PetSize.String()))
+	}
+	if len(params.NameIn) > 0 {
+		query = query.Where(goqu.C("name").In(params.NameIn))
+	}
+	if params.NameAfter != nil {
+		query = query.Where(goqu.C("name").Gt(params.NameAfter.String()))
+	}
+	query = query.Order(goqu.C("name").Asc())
+	if params.Limit > 0 {
+		query = query.Limit(uint(params.Limit))
+	}
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val BreedVersionModel) (*breeds.Breed, error) {
+		version, err := val.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		return version.Breed(), nil
+	})
+}
+
+func listBreedVersions(ctx context.Context, q queryer, name values.BreedName) ([]*breeds.Version, error) {
+	var res []BreedVersionModel
+
+	query := q.From("breed_versions").
+		Select(breedVersionColumns...).
+		Where(goqu.C("name").Eq(name.String())).
+		Order(goqu.C("version").Asc())
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val BreedVersionModel) (*breeds.Version, error) {
+		return val.ToDomain()
+	})
+}
+
+// openVersion
+// Insert the version of a breed starting at the given date. The previous
+// version must have been closed beforehand
+func openVersion(ctx context.Context, q queryer, b *breeds.Breed, at time.Time) error {
+	var last int
+
+	_, err := q.From("breed_versions").
+		Select(goqu.COALESCE(goqu.MAX("version"), 0)).
+		Where(goqu.C("name").Eq(b.Name().String())).
+		ScanValContext(ctx, &last)
+	if err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+
+	insert := q.Insert(goqu.T("breed_versions")).Rows(
+		goqu.Record{
+			"name":                        b.Name().String(),
+			"version":                     last + 1,
+			"species":                     b.Species().String(),
+			"pet_size":                    b.PetSize().String(),
+			"average_male_adult_weight":   b.AverageMaleWeight(),
+			"average_female_adult_weight": b.AverageFemaleWeight(),
+			"valid_from":                  at,
+		},
+	).Executor()
+	if _, err := insert.ExecContext(ctx); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
+
+// closeVersion
+// End the current version of a breed at the given date
+func closeVersion(ctx context.Context, q queryer, name values.BreedName, at time.Time) error {
+	update := q.Update(goqu.T("breed_versions")).
+		Set(goqu.Record{"valid_to": at}).
+		Where(goqu.C("name").Eq(name.String()), goqu.C("valid_to").IsNull()).
+		Executor()
+	if _, err := update.ExecContext(ctx); err != nil {
+		return domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestBreedVersionStorage(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		repo := datastore.Breeds()
+
+		created, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Small.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+		updated, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Dog.String(),
+			PetSize:             values.Small.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		}).Instantiate()
+		require.CmpNoError(err)
+
+		// Validity dates are stored with a precision of one second
+		_, err = repo.CreateOne(ctx, created)
+		require.CmpNoError(err)
+		time.Sleep(1100 * time.Millisecond)
+		_, err = repo.UpdateOne(ctx, updated)
+		require.CmpNoError(err)
+		time.Sleep(1100 * time.Millisecond)
+		require.CmpNoError(repo.DeleteOneByName(ctx, created.Name()))
+
+		versions, err := repo.ListVersions(ctx, created.Name())
+		require.CmpNoError(err)
+		require.Cmp(versions, td.Len(2))
+		require.Cmp(versions[0].Number(), 1)
+		require.Cmp(versions[0].Breed(), storedBreed(created))
+		require.Cmp(versions[0].ValidTo(), td.Ptr(versions[1].ValidFrom()))
+		require.Cmp(versions[1].Number(), 2)
+		require.Cmp(versions[1].Breed(), storedBreed(updated))
+		require.Cmp(versions[1].IsCurrent(), false)
+
+		tests := []struct {
+			name    string
+			asOf    time.Time
+			want    *breeds.Breed
+			wantErr error
+		}{
+			{
+				name: "valid case -- first version",
+				asOf: versions[0].ValidFrom(),
+				want: created,
+			},
+			{
+				name: "valid case -- second version",
+				asOf: versions[1].ValidFrom(),
+				want: updated,
+			},
+			{
+				name:    "invalid case -- before creation",
+				asOf:    versions[0].ValidFrom().Add(-time.Second),
+				wantErr: domainerror.ErrResourceNotFound,
+			},
+			{
+				name:    "invalid case -- after deletion",
+				asOf:    *versions[1].ValidTo(),
+				wantErr: domainerror.ErrResourceNotFound,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.AsOf(tt.asOf).GetOneByName(ctx, created.Name())
+				require.CmpErrorIs(err, tt.wantErr)
+
+				list, listErr := repo.AsOf(tt.asOf).List(ctx, breeds.ListOpts{})
+				require.CmpNoError(listErr)
+
+				if tt.wantErr == nil {
+					require.Cmp(got, storedBreed(tt.want))
+					require.Cmp(list, td.Bag(storedBreed(tt.want)))
+				} else {
+					require.Cmp(list, td.Len(0))
+				}
+			})
+		}
+
+		list, err := repo.AsOf(versions[1].ValidFrom()).List(ctx, breeds.ListOpts{Species: common.ToPointer(values.Cat)})
+		require.CmpNoError(err)
+		require.Cmp(list, td.Len(0))
+
+		_, err = repo.RestoreOneByName(ctx, created.Name())
+		require.CmpNoError(err)
+		versions, err = repo.ListVersions(ctx, created.Name())
+		require.CmpNoError(err)
+		require.Cmp(versions, td.Len(3))
+		require.Cmp(versions[2].IsCurrent(), true)
+		require.Cmp(versions[2].Breed(), storedBreed(updated))
+	})
+}
@@ -0,0 +1,448 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+// WebhookStorage
+// Webhooks subscriptions in the webhooks table and their delivery log in the
+// webhook_deliveries table
+type WebhookStorage struct {
+	db *goqu.Database
+}
+
+func NewWebhookStorage(db *goqu.Database) *WebhookStorage {
+	return &WebhookStorage{
+		db: db,
+	}
+}
+
+type WebhookModel struct {
+	ID                  string     `db:"id"`
+	URL                 string     `db:"url"`
+	Secret              string     `db:"secret"`
+	EventTypes          string     `db:"event_types"`
+	Enabled             bool       `db:"enabled"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	DisabledAt          *time.Time `db:"disabled_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+}
+
+var webhookColumns = []interface{}{
+	goqu.C("id"),
+	goqu.C("url"),
+	goqu.C("secret"),
+	goqu.C("event_types"),
+	goqu.C("enabled"),
+	goqu.C("consecutive_failures"),
+	goqu.C("disabled_at"),
+	goqu.C("created_at"),
+}
+
+func (w WebhookModel) ToDomain() (*webhooks.Webhook, error) {
+	return webhooks.NewFactory(webhooks.FactoryOpts{
+		ID:                  w.ID,
+		URL:                 w.URL,
+		Secret:              w.Secret,
+		EventTypes:          strings.Split(w.EventTypes, ","),
+		Enabled:             w.Enabled,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledAt:          w.DisabledAt,
+		CreatedAt:           w.CreatedAt,
+	}).Instantiate()
+}
+
+type DeliveryModel struct {
+	ID             int64          `db:"id"`
+	WebhookID      string         `db:"webhook_id"`
+	EventID        int64          `db:"event_id"`
+	EventName      string         `db:"event_name"`
+	Payload        string         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	LastStatusCode int            `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	DeliveredAt    *time.Time     `db:"delivered_at"`
+}
+
+var deliveryColumns = []interface{}{
+	goqu.C("id"),
+	goqu.C("webhook_id"),
+	goqu.C("event_id"),
+	goqu.C("event_name"),
+	goqu.C("payload"),
+	goqu.C("status"),
+	goqu.C("attempts"),
+	goqu.C("last_status_code"),
+	goqu.C("last_error"),
+	goqu.C("next_attempt_at"),
+	goqu.C("created_at"),
+	goqu.C("delivered_at"),
+}
+
+func (d DeliveryModel) ToDomain() (*webhooks.Delivery, error) {
+	return webhooks.NewDeliveryFactory(webhooks.DeliveryFactoryOpts{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventName:      d.EventName,
+		Payload:        []byte(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError.String,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}).Instantiate()
+}
+
+func webhookRecord(w *webhooks.Webhook) goqu.Record {
+	return goqu.Record{
+		"url":                  w.URL().String(),
+		"secret":               w.Secret().String(),
+		"event_types":          strings.Join(w.EventTypes(), ","),
+		"enabled":              w.Enabled(),
+		"consecutive_failures": w.ConsecutiveFailures(),
+		"disabled_at":          w.DisabledAt(),
+	}
+}
+
+func (s WebhookStorage) CreateOne(ctx context.Context, input *webhooks.Webhook) (*webhooks.Webhook, error) {
+	record := webhookRecord(input)
+	record["id"] = input.ID()
+	record["created_at"] = input.CreatedAt().UTC()
+
+	if _, err := conn(ctx, s.db).Insert(goqu.T("webhooks")).Rows(record).Executor().ExecContext(ctx); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return s.GetOneByID(ctx, input.ID())
+}
+
+func (s WebhookStorage) GetOneByID(ctx context.Context, id string) (*webhooks.Webhook, error) {
+	return getOneWebhookByID(ctx, conn(ctx, s.db), id)
+}
+
+func getOneWebhookByID(ctx context.Context, q queryer, id string) (*webhooks.Webhook, error) {
+	var res WebhookModel
+
+	found, err := q.From("webhooks").
+		Select(webhookColumns...).
+		Where(goqu.C("id").Eq(id)).
+		ScanStructContext(ctx, &res)
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if !found {
+		return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("webhook %s not found", id))
+	}
+	return res.ToDomain()
+}
+
+func (s WebhookStorage) List(ctx context.Context) ([]*webhooks.Webhook, error) {
+	return listWebhooks(ctx, conn(ctx, s.db))
+}
+
+func listWebhooks(ctx context.Context, q queryer, where ...exp.Expression) ([]*webhooks.Webhook, error) {
+	var res []WebhookModel
+
+	query := q.From("webhooks").
+		Select(webhookColumns...).
+		Where(where...).
+		Order(goqu.C("created_at").Asc(), goqu.C("id").Asc())
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val WebhookModel) (*webhooks.Webhook, error) {
+		return val.ToDomain()
+	})
+}
+
+func (s WebhookStorage) UpdateOne(ctx context.Context, input *webhooks.Webhook) (*webhooks.Webhook, error) {
+	var res *webhooks.Webhook
+
+	err := withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		if _, err := getOneWebhookByID(ctx, tx, input.ID()); err != nil {
+			return err
+		}
+
+		_, err := tx.Update(goqu.T("webhooks")).
+			Set(webhookRecord(input)).
+			Where(goqu.C("id").Eq(input.ID())).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		res, err = getOneWebhookByID(ctx, tx, input.ID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s WebhookStorage) DeleteOneByID(ctx context.Context, id string) error {
+	return withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		res, err := tx.Delete(goqu.T("webhooks")).
+			Where(goqu.C("id").Eq(id)).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		} else if n == 0 {
+			return domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("webhook %s not found", id))
+		}
+
+		_, err = tx.Delete(goqu.T("webhook_deliveries")).
+			Where(goqu.C("webhook_id").Eq(id)).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		return nil
+	})
+}
+
+func (s WebhookStorage) Enqueue(ctx context.Context, eventID int64, eventName string, payload []byte) (int, error) {
+	subscribed, err := listWebhooks(ctx, conn(ctx, s.db), goqu.C("enabled").IsTrue())
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	rows := []interface{}{}
+	for _, val := range subscribed {
+		if !val.Subscribes(eventName) {
+			continue
+		}
+		rows = append(rows, goqu.Record{
+			"webhook_id":      val.ID(),
+			"event_id":        eventID,
+			"event_name":      eventName,
+			"payload":         string(payload),
+			"status":          webhooks.DeliveryPending.String(),
+			"next_attempt_at": now,
+			"created_at":      now,
+		})
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	res, err := conn(ctx, s.db).Insert(goqu.T("webhook_deliveries")).
+		Rows(rows...).
+		OnConflict(goqu.DoNothing()).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return 0, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return int(n), nil
+}
+
+func (s WebhookStorage) ListDeliveries(ctx context.Context, webhookID string) ([]*webhooks.Delivery, error) {
+	if _, err := s.GetOneByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return listDeliveries(ctx, conn(ctx, s.db), []exp.OrderedExpression{goqu.C("id").Desc()}, 0, goqu.C("webhook_id").Eq(webhookID))
+}
+
+func (s WebhookStorage) ListPendingDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhooks.Delivery, error) {
+	enabled := conn(ctx, s.db).From("webhooks").
+		Select(goqu.L("1")).
+		Where(
+			goqu.I("webhooks.id").Eq(goqu.I("webhook_deliveries.webhook_id")),
+			goqu.I("webhooks.enabled").IsTrue(),
+		)
+
+	return listDeliveries(ctx, conn(ctx, s.db), []exp.OrderedExpression{goqu.C("id").Asc()}, uint(limit),
+		goqu.C("status").Eq(webhooks.DeliveryPending.String()),
+		goqu.C("next_attempt_at").Lte(now.UTC()),
+		goqu.L("EXISTS ?", enabled),
+	)
+}
+
+func listDeliveries(ctx context.Context, q queryer, order []exp.OrderedExpression, limit uint, where ...exp.Expression) ([]*webhooks.Delivery, error) {
+	var res []DeliveryModel
+
+	query := q.From("webhook_deliveries").
+		Select(deliveryColumns...).
+		Where(where...).
+		Order(order...)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.ScanStructsContext(ctx, &res); err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return common.EMap(res, func(val DeliveryModel) (*webhooks.Delivery, error) {
+		return val.ToDomain()
+	})
+}
+
+func getOneDelivery(ctx context.Context, q queryer, webhookID string, deliveryID int64) (*webhooks.Delivery, error) {
+	found, err := listDeliveries(ctx, q, []exp.OrderedExpression{goqu.C("id").Asc()}, 1,
+		goqu.C("id").Eq(deliveryID),
+		goqu.C("webhook_id").Eq(webhookID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("delivery %d of webhook %s not found", deliveryID, webhookID))
+	}
+	return found[0], nil
+}
+
+func (s WebhookStorage) RecordSuccess(ctx context.Context, deliveryID int64, statusCode int, at time.Time) error {
+	return withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		webhookID, err := updateDelivery(ctx, tx, deliveryID, goqu.Record{
+			"status":           webhooks.DeliverySucceeded.String(),
+			"attempts":         goqu.L("attempts + 1"),
+			"last_status_code": statusCode,
+			"last_error":       nil,
+			"delivered_at":     at.UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Update(goqu.T("webhooks")).
+			Set(goqu.Record{"consecutive_failures": 0}).
+			Where(goqu.C("id").Eq(webhookID)).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		return nil
+	})
+}
+
+func (s WebhookStorage) RecordFailure(ctx context.Context, deliveryID int64, failure webhooks.DeliveryFailure, disableAfter int) (bool, error) {
+	var disabled bool
+
+	err := withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		set := goqu.Record{
+			"status":           webhooks.DeliveryFailed.String(),
+			"attempts":         goqu.L("attempts + 1"),
+			"last_status_code": failure.StatusCode,
+			"last_error":       failure.Error,
+		}
+		if failure.NextAttemptAt != nil {
+			set["status"] = webhooks.DeliveryPending.String()
+			set["next_attempt_at"] = failure.NextAttemptAt.UTC()
+		}
+		webhookID, err := updateDelivery(ctx, tx, deliveryID, set)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Update(goqu.T("webhooks")).
+			Set(goqu.Record{"consecutive_failures": goqu.L("consecutive_failures + 1")}).
+			Where(goqu.C("id").Eq(webhookID)).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		res, err := tx.Update(goqu.T("webhooks")).
+			Set(goqu.Record{"enabled": false, "disabled_at": time.Now().UTC()}).
+			Where(
+				goqu.C("id").Eq(webhookID),
+				goqu.C("enabled").IsTrue(),
+				goqu.C("consecutive_failures").Gte(disableAfter),
+			).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+		disabled = n > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return disabled, nil
+}
+
+// updateDelivery
+// Update a pending delivery and return the id of its webhook
+func updateDelivery(ctx context.Context, q queryer, deliveryID int64, set goqu.Record) (string, error) {
+	var webhookID string
+
+	found, err := q.From("webhook_deliveries").
+		Select(goqu.C("webhook_id")).
+		Where(goqu.C("id").Eq(deliveryID), goqu.C("status").Eq(webhooks.DeliveryPending.String())).
+		ScanValContext(ctx, &webhookID)
+	if err != nil {
+		return "", domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	if !found {
+		return "", domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("pending delivery %d not found", deliveryID))
+	}
+
+	_, err = q.Update(goqu.T("webhook_deliveries")).
+		Set(set).
+		Where(goqu.C("id").Eq(deliveryID)).
+		Executor().ExecContext(ctx)
+	if err != nil {
+		return "", domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	return webhookID, nil
+}
+
+func (s WebhookStorage) Replay(ctx context.Context, webhookID string, deliveryID int64) (*webhooks.Delivery, error) {
+	var res *webhooks.Delivery
+
+	err := withTx(ctx, s.db, func(tx *goqu.TxDatabase) error {
+		found, err := getOneDelivery(ctx, tx, webhookID, deliveryID)
+		if err != nil {
+			return err
+		}
+		if found.Status() == webhooks.DeliveryPending {
+			return domainerror.ErrNothingTodo
+		}
+
+		_, err = tx.Update(goqu.T("webhook_deliveries")).
+			Set(goqu.Record{
+				"status":          webhooks.DeliveryPending.String(),
+				"next_attempt_at": time.Now().UTC(),
+				"delivered_at":    nil,
+			}).
+			Where(goqu.C("id").Eq(deliveryID)).
+			Executor().ExecContext(ctx)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrInternalError, err)
+		}
+
+		res, err = getOneDelivery(ctx, tx, webhookID, deliveryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func newWebhook(require *td.T, eventTypes ...string) *webhooks.Webhook {
+	res, err := webhooks.NewFactory(webhooks.FactoryOpts{
+		ID:         uuid.NewString(),
+		URL:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: eventTypes,
+		Enabled:    true,
+		CreatedAt:  time.Now(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	return res
+}
+
+func TestWebhookStorage_CRUD(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		repo := datastore.Webhooks()
+
+		created, err := repo.CreateOne(ctx, newWebhook(require, breeds.EventBreedCreated, breeds.EventBreedUpdated))
+		require.CmpNoError(err)
+		require.Cmp(created.EventTypes(), []string{breeds.EventBreedCreated, breeds.EventBreedUpdated})
+		require.True(created.Enabled())
+
+		updated, err := webhooks.NewFactory(webhooks.FactoryOpts{
+			ID:         created.ID(),
+			URL:        "https://example.com/other",
+			Secret:     created.Secret().String(),
+			EventTypes: []string{breeds.EventBreedDeleted},
+			CreatedAt:  created.CreatedAt(),
+		}).Instantiate()
+		require.CmpNoError(err)
+		res, err := repo.UpdateOne(ctx, updated)
+		require.CmpNoError(err)
+		require.Cmp(res.URL().String(), "https://example.com/other")
+		require.False(res.Enabled())
+
+		list, err := repo.List(ctx)
+		require.CmpNoError(err)
+		require.Len(list, 1)
+
+		require.CmpNoError(repo.DeleteOneByID(ctx, created.ID()))
+		_, err = repo.GetOneByID(ctx, created.ID())
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+		require.CmpErrorIs(repo.DeleteOneByID(ctx, created.ID()), domainerror.ErrResourceNotFound)
+	})
+}
+
+func TestWebhookStorage_Deliveries(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			repo   = datastore.Webhooks()
+			future = time.Now().Add(time.Hour)
+		)
+
+		created, err := repo.CreateOne(ctx, newWebhook(require, breeds.EventBreedCreated))
+		require.CmpNoError(err)
+		_, err = repo.CreateOne(ctx, newWebhook(require, breeds.EventBreedDeleted))
+		require.CmpNoError(err)
+
+		// Enqueuing is idempotent and only targets the subscribed webhooks
+		n, err := repo.Enqueue(ctx, 1, breeds.EventBreedCreated, []byte(`{"event":"breed.created"}`))
+		require.CmpNoError(err)
+		require.Cmp(n, 1)
+		n, err = repo.Enqueue(ctx, 1, breeds.EventBreedCreated, []byte(`{"event":"breed.created"}`))
+		require.CmpNoError(err)
+		require.Cmp(n, 0)
+
+		pending, err := repo.ListPendingDeliveries(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Len(pending, 1)
+		require.Cmp(pending[0].WebhookID(), created.ID())
+		require.Cmp(pending[0].Status(), webhooks.DeliveryPending)
+
+		// A failure with a next attempt keeps the delivery pending
+		disabled, err := repo.RecordFailure(ctx, pending[0].ID(), webhooks.DeliveryFailure{
+			StatusCode:    500,
+			Error:         "webhook answered 500",
+			NextAttemptAt: common.ToPointer(future),
+		}, 2)
+		require.CmpNoError(err)
+		require.False(disabled)
+		pending, err = repo.ListPendingDeliveries(ctx, time.Now(), 10)
+		require.CmpNoError(err)
+		require.Empty(pending)
+
+		// The second consecutive failure gives up the delivery and disables the webhook
+		pending, err = repo.ListPendingDeliveries(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Len(pending, 1)
+		disabled, err = repo.RecordFailure(ctx, pending[0].ID(), webhooks.DeliveryFailure{Error: "connection refused"}, 2)
+		require.CmpNoError(err)
+		require.True(disabled)
+
+		found, err := repo.GetOneByID(ctx, created.ID())
+		require.CmpNoError(err)
+		require.False(found.Enabled())
+		require.Cmp(found.ConsecutiveFailures(), 2)
+		require.NotNil(found.DisabledAt())
+
+		log, err := repo.ListDeliveries(ctx, created.ID())
+		require.CmpNoError(err)
+		require.Len(log, 1)
+		require.Cmp(log[0].Status(), webhooks.DeliveryFailed)
+		require.Cmp(log[0].Attempts(), 2)
+		require.Cmp(log[0].LastError(), "connection refused")
+
+		// Replayed deliveries of a disabled webhook wait for it to be enabled again
+		replayed, err := repo.Replay(ctx, created.ID(), log[0].ID())
+		require.CmpNoError(err)
+		require.Cmp(replayed.Status(), webhooks.DeliveryPending)
+		_, err = repo.Replay(ctx, created.ID(), log[0].ID())
+		require.CmpErrorIs(err, domainerror.ErrNothingTodo)
+		pending, err = repo.ListPendingDeliveries(ctx, future, 10)
+		require.CmpNoError(err)
+		require.Empty(pending)
+
+		_, err = repo.Replay(ctx, created.ID(), 42)
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+		_, err = repo.ListDeliveries(ctx, "unknown")
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+	})
+}
@@ -0,0 +1,25 @@
+package gateways
+
+import (
+	"context"
+)
+
+type transactionKey struct{}
+
+// WithTransaction
+// Store the transaction which the repositories calls made with the context are
+// part of (see IDatastore.Transaction). Its type is up to the datastore
+func WithTransaction(ctx context.Context, tx any) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+func Transaction(ctx context.Context) (any, bool) {
+	tx := ctx.Value(transactionKey{})
+	return tx, tx != nil
+}
+
+// WithoutTransaction
+// Leave the transaction of the context, e.g. for a call shared with other requests
+func WithoutTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionKey{}, nil)
+}
@@ -0,0 +1,126 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+)
+
+var (
+	ErrInvalidFirst  = errors.New("first must be between 1 and 100")
+	ErrInvalidCursor = errors.New("after must be the cursor of a breed")
+)
+
+// nodeFieldsByName
+// Fields of the breeds read for each field of the Breed type, the name being
+// always read
+var nodeFieldsByName = map[string]breeds.Field{
+	"species":                  breeds.FieldSpecies,
+	"petSize":                  breeds.FieldPetSize,
+	"averageFemaleAdultWeight": breeds.FieldAverageFemaleWeight,
+	"averageMaleAdultWeight":   breeds.FieldAverageMaleWeight,
+	"deletedAt":                breeds.FieldDeletedAt,
+}
+
+// nodeFields
+// Fields of the breeds selected under edges.node by the breeds field being
+// resolved, for the storage to read only them. Nil when all of them are selected
+func nodeFields(ctx context.Context) []string {
+	var (
+		opCtx = graphql.GetOperationContext(ctx)
+		res   = []string{string(breeds.FieldName)}
+	)
+	for _, edges := range graphql.CollectFieldsCtx(ctx, []string{"BreedConnection"}) {
+		if edges.Name != "edges" {
+			continue
+		}
+		for _, node := range graphql.CollectFields(opCtx, edges.Selections, []string{"BreedEdge"}) {
+			if node.Name != "node" {
+				continue
+			}
+			for _, field := range graphql.CollectFields(opCtx, node.Selections, []string{"Breed"}) {
+				if val, ok := nodeFieldsByName[field.Name]; ok && !slices.Contains(res, string(val)) {
+					res = append(res, string(val))
+				}
+			}
+		}
+	}
+	if len(res) == len(breeds.Fields) {
+		return nil
+	}
+	return res
+}
+
+// encodeCursor
+// Opaque cursor of a breed, for the clients not to rely on it being its name
+func encodeCursor(name string) string {
+	return base64.URLEncoding.EncodeToString([]byte(name))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	name, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil || len(name) == 0 {
+		return "", domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidCursor)
+	}
+	return string(name), nil
+}
+
+func listOpts(filter *BreedFilter) breedsUsecase.ListOpts {
+	var res breedsUsecase.ListOpts
+	if filter == nil {
+		return res
+	}
+	if filter.Species != nil {
+		val := strings.ToLower(filter.Species.String())
+		res.Species = &val
+	}
+	if filter.PetSize != nil {
+		val := strings.ToLower(filter.PetSize.String())
+		res.PetSize = &val
+	}
+	res.AverageFemaleWeight = filter.AverageFemaleAdultWeight
+	res.AverageMaleWeight = filter.AverageMaleAdultWeight
+	if filter.IncludeDeleted != nil {
+		res.IncludeDeleted = *filter.IncludeDeleted
+	}
+	res.AsOf = filter.AsOf
+	return res
+}
+
+func breedFromInput(input BreedInput) breeds.FactoryOpts {
+	return breeds.FactoryOpts{
+		Name:                input.Name,
+		Species:             strings.ToLower(input.Species.String()),
+		PetSize:             strings.ToLower(input.PetSize.String()),
+		AverageFemaleWeight: input.AverageFemaleAdultWeight,
+		AverageMaleWeight:   input.AverageMaleAdultWeight,
+	}
+}
+
+func breedToGraphQL(domain *breeds.Breed) *Breed {
+	res := &Breed{
+		Name:      domain.Name().String(),
+		Species:   Species(strings.ToUpper(domain.Species().String())),
+		PetSize:   PetSize(strings.ToUpper(domain.PetSize().String())),
+		DeletedAt: domain.DeletedAt(),
+	}
+	if domain.Has(breeds.FieldAverageFemaleWeight) {
+		val := domain.AverageFemaleWeight()
+		res.AverageFemaleAdultWeight = &val
+	}
+	if domain.Has(breeds.FieldAverageMaleWeight) {
+		val := domain.AverageMaleWeight()
+		res.AverageMaleAdultWeight = &val
+	}
+	if val := domain.UpdatedAt(); !val.IsZero() {
+		res.UpdatedAt = &val
+	}
+	return res
+}
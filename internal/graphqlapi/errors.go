@@ -0,0 +1,56 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// errCodes
+// extensions.code of the domain errors, the way HandleErrorResponse maps them to
+// HTTP statuses
+var errCodes = []struct {
+	err  error
+	code string
+}{
+	{domainerror.ErrDomainValidation, "BAD_USER_INPUT"},
+	{domainerror.ErrResourceNotFound, "NOT_FOUND"},
+	{domainerror.ErrResourceAlreadyExists, "ALREADY_EXISTS"},
+	{domainerror.ErrNothingTodo, "NOTHING_TODO"},
+	{domainerror.ErrUnprocessableEntity, "UNPROCESSABLE_ENTITY"},
+	{domainerror.ErrUnauthenticated, "UNAUTHENTICATED"},
+	{domainerror.ErrForbidden, "FORBIDDEN"},
+	{domainerror.ErrTooManyRequests, "TOO_MANY_REQUESTS"},
+}
+
+// presentError
+// Add the code of the domain error to the GraphQL error, INTERNAL_SERVER_ERROR when
+// it is unknown. Internal errors are logged
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	res := graphql.DefaultErrorPresenter(ctx, err)
+
+	// Errors of the operation itself, e.g. a syntax error, already have a code
+	if _, ok := res.Extensions["code"]; ok {
+		return res
+	}
+
+	code := "INTERNAL_SERVER_ERROR"
+	for _, val := range errCodes {
+		if errors.Is(err, val.err) {
+			code = val.code
+			break
+		}
+	}
+	if code == "INTERNAL_SERVER_ERROR" {
+		logger.FromContext(ctx).Error("graphql", "path", res.Path.String(), "error", err)
+	}
+	if res.Extensions == nil {
+		res.Extensions = map[string]any{}
+	}
+	res.Extensions["code"] = code
+	return res
+}
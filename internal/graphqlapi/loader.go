@@ -0,0 +1,42 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/loader"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+)
+
+// breedLoader
+// Batch the breeds looked up by name while resolving a request, e.g. through
+// aliases of the breed field, into one listing
+type breedLoader = loader.Loader[string, *breeds.Breed]
+
+type breedLoaderKey struct{}
+
+func withBreedLoader(ctx context.Context, l *breedLoader) context.Context {
+	return context.WithValue(ctx, breedLoaderKey{}, l)
+}
+
+func breedLoaderFromContext(ctx context.Context) *breedLoader {
+	l, _ := ctx.Value(breedLoaderKey{}).(*breedLoader)
+	return l
+}
+
+func (r *Resolver) newBreedLoader() *breedLoader {
+	return loader.New(func(ctx context.Context, names []string) (map[string]*breeds.Breed, error) {
+		res, err := usecases.New(&breedsUsecase.List{}, r.datastore, r.usecaseOpts...).Handle(ctx, breedsUsecase.ListOpts{
+			Names: names,
+		})
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string]*breeds.Breed, len(res))
+		for _, val := range res {
+			byName[val.Name().String()] = val
+		}
+		return byName, nil
+	}, loader.WithMaxBatch(MaxPageSize))
+}
@@ -0,0 +1,146 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graphqlapi
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Breed struct {
+	Name                     string  `json:"name"`
+	Species                  Species `json:"species"`
+	PetSize                  PetSize `json:"petSize"`
+	AverageFemaleAdultWeight *int    `json:"averageFemaleAdultWeight,omitempty"`
+	AverageMaleAdultWeight   *int    `json:"averageMaleAdultWeight,omitempty"`
+	// Set when the breed is soft deleted
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// Page of breeds sorted by name
+type BreedConnection struct {
+	Edges    []*BreedEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+}
+
+type BreedEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Breed `json:"node"`
+}
+
+// Filters of the breeds, matching the query parameters of GET /v1/breeds
+type BreedFilter struct {
+	Species                  *Species `json:"species,omitempty"`
+	PetSize                  *PetSize `json:"petSize,omitempty"`
+	AverageFemaleAdultWeight *int     `json:"averageFemaleAdultWeight,omitempty"`
+	AverageMaleAdultWeight   *int     `json:"averageMaleAdultWeight,omitempty"`
+	IncludeDeleted           *bool    `json:"includeDeleted,omitempty"`
+	// List the breeds as they were at this date instead of the current ones
+	AsOf *time.Time `json:"asOf,omitempty"`
+}
+
+type BreedInput struct {
+	Name                     string  `json:"name"`
+	Species                  Species `json:"species"`
+	PetSize                  PetSize `json:"petSize"`
+	AverageFemaleAdultWeight *int    `json:"averageFemaleAdultWeight,omitempty"`
+	AverageMaleAdultWeight   *int    `json:"averageMaleAdultWeight,omitempty"`
+}
+
+type Mutation struct {
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
+
+type Query struct {
+}
+
+type PetSize string
+
+const (
+	PetSizeSmall  PetSize = "SMALL"
+	PetSizeMedium PetSize = "MEDIUM"
+	PetSizeTall   PetSize = "TALL"
+)
+
+var AllPetSize = []PetSize{
+	PetSizeSmall,
+	PetSizeMedium,
+	PetSizeTall,
+}
+
+func (e PetSize) IsValid() bool {
+	switch e {
+	case PetSizeSmall, PetSizeMedium, PetSizeTall:
+		return true
+	}
+	return false
+}
+
+func (e PetSize) String() string {
+	return string(e)
+}
+
+func (e *PetSize) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PetSize(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PetSize", str)
+	}
+	return nil
+}
+
+func (e PetSize) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Species string
+
+const (
+	SpeciesDog Species = "DOG"
+	SpeciesCat Species = "CAT"
+)
+
+var AllSpecies = []Species{
+	SpeciesDog,
+	SpeciesCat,
+}
+
+func (e Species) IsValid() bool {
+	switch e {
+	case SpeciesDog, SpeciesCat:
+		return true
+	}
+	return false
+}
+
+func (e Species) String() string {
+	return string(e)
+}
+
+func (e *Species) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Species(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Species", str)
+	}
+	return nil
+}
+
+func (e Species) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
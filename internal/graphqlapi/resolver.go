@@ -0,0 +1,83 @@
+package graphqlapi
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+const (
+	// DefaultPageSize and MaxPageSize bound the breeds listed by page
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	// DefaultComplexityLimit lets a page of MaxPageSize breeds be read whole, but
+	// not two of them
+	DefaultComplexityLimit = 2000
+)
+
+// Resolver
+// Root of the resolvers, executing the usecases of the breeds
+type Resolver struct {
+	logger    *charmLog.Logger
+	datastore gateways.IDatastore
+	// usecaseOpts configure every usecase executed by the resolvers
+	usecaseOpts     []usecases.Option
+	complexityLimit int
+}
+
+type Option func(*Resolver)
+
+// WithUsecaseOptions
+// Build the usecases with the given options, e.g. the interceptors executing them
+func WithUsecaseOptions(opts ...usecases.Option) Option {
+	return func(r *Resolver) {
+		r.usecaseOpts = append(r.usecaseOpts, opts...)
+	}
+}
+
+// WithComplexityLimit
+// Refuse the operations more complex than the limit instead of DefaultComplexityLimit.
+// Every field costs 1, the breeds connection costing its fields by breed of the page
+func WithComplexityLimit(limit int) Option {
+	return func(r *Resolver) {
+		r.complexityLimit = limit
+	}
+}
+
+// New
+// Handler executing the GraphQL operations sent with POST, the by name lookups of
+// each request being batched (see breedLoader)
+func New(logger *charmLog.Logger, datastore gateways.IDatastore, opts ...Option) http.Handler {
+	r := &Resolver{
+		logger:          logger,
+		datastore:       datastore,
+		complexityLimit: DefaultComplexityLimit,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	cfg := Config{Resolvers: r}
+	cfg.Complexity.Query.Breeds = func(childComplexity int, _ *BreedFilter, first *int, _ *string) int {
+		size := DefaultPageSize
+		if first != nil {
+			size = *first
+		}
+		return 1 + size*childComplexity
+	}
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(r.complexityLimit))
+	srv.SetErrorPresenter(presentError)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		srv.ServeHTTP(w, req.WithContext(withBreedLoader(req.Context(), r.newBreedLoader())))
+	})
+}
@@ -0,0 +1,112 @@
+package graphqlapi
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+)
+
+// CreateBreed is the resolver for the createBreed field.
+func (r *mutationResolver) CreateBreed(ctx context.Context, input BreedInput) (*Breed, error) {
+	res, err := usecases.New(&breedsUsecase.CreateOne{}, r.datastore, r.usecaseOpts...).Handle(ctx, breedFromInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return breedToGraphQL(res), nil
+}
+
+// UpdateBreed is the resolver for the updateBreed field.
+func (r *mutationResolver) UpdateBreed(ctx context.Context, input BreedInput) (*Breed, error) {
+	res, err := usecases.New(&breedsUsecase.UpdateOne{}, r.datastore, r.usecaseOpts...).Handle(ctx, breedFromInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return breedToGraphQL(res), nil
+}
+
+// DeleteBreed is the resolver for the deleteBreed field.
+func (r *mutationResolver) DeleteBreed(ctx context.Context, name string) (bool, error) {
+	if err := usecases.NewSimple(&breedsUsecase.DeleteOneByName{}, r.datastore, r.usecaseOpts...).Handle(ctx, name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Breeds is the resolver for the breeds field.
+func (r *queryResolver) Breeds(ctx context.Context, filter *BreedFilter, first *int, after *string) (*BreedConnection, error) {
+	size := DefaultPageSize
+	if first != nil {
+		size = *first
+	}
+	if size < 1 || size > MaxPageSize {
+		return nil, domainerror.WrapError(domainerror.ErrDomainValidation, ErrInvalidFirst)
+	}
+
+	opts := listOpts(filter)
+	if after != nil {
+		name, err := decodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		opts.After = &name
+	}
+	opts.Fields = nodeFields(ctx)
+	// One more breed tells whether there is a next page
+	opts.Limit = size + 1
+
+	res, err := usecases.New(&breedsUsecase.List{}, r.datastore, r.usecaseOpts...).Handle(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &BreedConnection{
+		Edges:    make([]*BreedEdge, 0, size),
+		PageInfo: &PageInfo{HasNextPage: len(res) > size},
+	}
+	if len(res) > size {
+		res = res[:size]
+	}
+	for _, val := range res {
+		conn.Edges = append(conn.Edges, &BreedEdge{
+			Cursor: encodeCursor(val.Name().String()),
+			Node:   breedToGraphQL(val),
+		})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
+}
+
+// Breed is the resolver for the breed field.
+func (r *queryResolver) Breed(ctx context.Context, name string) (*Breed, error) {
+	if err := values.Verify(values.BreedName(name)); err != nil {
+		return nil, err
+	}
+
+	l := breedLoaderFromContext(ctx)
+	if l == nil {
+		l = r.newBreedLoader()
+	}
+	res, ok, err := l.Load(ctx, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return breedToGraphQL(res), nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,1344 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: breeds/v1/breeds.proto
+
+package breedsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Species int32
+
+const (
+	Species_SPECIES_UNSPECIFIED Species = 0
+	Species_SPECIES_DOG         Species = 1
+	Species_SPECIES_CAT         Species = 2
+)
+
+// Enum value maps for Species.
+var (
+	Species_name = map[int32]string{
+		0: "SPECIES_UNSPECIFIED",
+		1: "SPECIES_DOG",
+		2: "SPECIES_CAT",
+	}
+	Species_value = map[string]int32{
+		"SPECIES_UNSPECIFIED": 0,
+		"SPECIES_DOG":         1,
+		"SPECIES_CAT":         2,
+	}
+)
+
+func (x Species) Enum() *Species {
+	p := new(Species)
+	*p = x
+	return p
+}
+
+func (x Species) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Species) Descriptor() protoreflect.EnumDescriptor {
+	return file_breeds_v1_breeds_proto_enumTypes[0].Descriptor()
+}
+
+func (Species) Type() protoreflect.EnumType {
+	return &file_breeds_v1_breeds_proto_enumTypes[0]
+}
+
+func (x Species) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Species.Descriptor instead.
+func (Species) EnumDescriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{0}
+}
+
+type PetSize int32
+
+const (
+	PetSize_PET_SIZE_UNSPECIFIED PetSize = 0
+	PetSize_PET_SIZE_SMALL       PetSize = 1
+	PetSize_PET_SIZE_MEDIUM      PetSize = 2
+	PetSize_PET_SIZE_TALL        PetSize = 3
+)
+
+// Enum value maps for PetSize.
+var (
+	PetSize_name = map[int32]string{
+		0: "PET_SIZE_UNSPECIFIED",
+		1: "PET_SIZE_SMALL",
+		2: "PET_SIZE_MEDIUM",
+		3: "PET_SIZE_TALL",
+	}
+	PetSize_value = map[string]int32{
+		"PET_SIZE_UNSPECIFIED": 0,
+		"PET_SIZE_SMALL":       1,
+		"PET_SIZE_MEDIUM":      2,
+		"PET_SIZE_TALL":        3,
+	}
+)
+
+func (x PetSize) Enum() *PetSize {
+	p := new(PetSize)
+	*p = x
+	return p
+}
+
+func (x PetSize) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PetSize) Descriptor() protoreflect.EnumDescriptor {
+	return file_breeds_v1_breeds_proto_enumTypes[1].Descriptor()
+}
+
+func (PetSize) Type() protoreflect.EnumType {
+	return &file_breeds_v1_breeds_proto_enumTypes[1]
+}
+
+func (x PetSize) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PetSize.Descriptor instead.
+func (PetSize) EnumDescriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{1}
+}
+
+type Breed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name                     string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Species                  Species `protobuf:"varint,2,opt,name=species,proto3,enum=breeds.v1.Species" json:"species,omitempty"`
+	PetSize                  PetSize `protobuf:"varint,3,opt,name=pet_size,json=petSize,proto3,enum=breeds.v1.PetSize" json:"pet_size,omitempty"`
+	AverageFemaleAdultWeight *int32  `protobuf:"varint,4,opt,name=average_female_adult_weight,json=averageFemaleAdultWeight,proto3,oneof" json:"average_female_adult_weight,omitempty"`
+	AverageMaleAdultWeight   *int32  `protobuf:"varint,5,opt,name=average_male_adult_weight,json=averageMaleAdultWeight,proto3,oneof" json:"average_male_adult_weight,omitempty"`
+	// Set when the breed is soft deleted
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Breed) Reset() {
+	*x = Breed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Breed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breed) ProtoMessage() {}
+
+func (x *Breed) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breed.ProtoReflect.Descriptor instead.
+func (*Breed) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Breed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Breed) GetSpecies() Species {
+	if x != nil {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *Breed) GetPetSize() PetSize {
+	if x != nil {
+		return x.PetSize
+	}
+	return PetSize_PET_SIZE_UNSPECIFIED
+}
+
+func (x *Breed) GetAverageFemaleAdultWeight() int32 {
+	if x != nil && x.AverageFemaleAdultWeight != nil {
+		return *x.AverageFemaleAdultWeight
+	}
+	return 0
+}
+
+func (x *Breed) GetAverageMaleAdultWeight() int32 {
+	if x != nil && x.AverageMaleAdultWeight != nil {
+		return *x.AverageMaleAdultWeight
+	}
+	return 0
+}
+
+func (x *Breed) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Breed) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Read the breed as it was at this date instead of the current one
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetResponse) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Every species when unspecified
+	Species Species `protobuf:"varint,1,opt,name=species,proto3,enum=breeds.v1.Species" json:"species,omitempty"`
+	// Every pet size when unspecified
+	PetSize                  PetSize `protobuf:"varint,2,opt,name=pet_size,json=petSize,proto3,enum=breeds.v1.PetSize" json:"pet_size,omitempty"`
+	AverageFemaleAdultWeight *int32  `protobuf:"varint,3,opt,name=average_female_adult_weight,json=averageFemaleAdultWeight,proto3,oneof" json:"average_female_adult_weight,omitempty"`
+	AverageMaleAdultWeight   *int32  `protobuf:"varint,4,opt,name=average_male_adult_weight,json=averageMaleAdultWeight,proto3,oneof" json:"average_male_adult_weight,omitempty"`
+	IncludeDeleted           bool    `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// List the breeds as they were at this date instead of the current ones
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRequest) GetSpecies() Species {
+	if x != nil {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *ListRequest) GetPetSize() PetSize {
+	if x != nil {
+		return x.PetSize
+	}
+	return PetSize_PET_SIZE_UNSPECIFIED
+}
+
+func (x *ListRequest) GetAverageFemaleAdultWeight() int32 {
+	if x != nil && x.AverageFemaleAdultWeight != nil {
+		return *x.AverageFemaleAdultWeight
+	}
+	return 0
+}
+
+func (x *ListRequest) GetAverageMaleAdultWeight() int32 {
+	if x != nil && x.AverageMaleAdultWeight != nil {
+		return *x.AverageMaleAdultWeight
+	}
+	return 0
+}
+
+func (x *ListRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListResponse) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateRequest) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateResponse) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The breed to update is found by its name
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateRequest) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type UpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Breed *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateResponse) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{10}
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Every species when unspecified
+	Species Species `protobuf:"varint,1,opt,name=species,proto3,enum=breeds.v1.Species" json:"species,omitempty"`
+	// Id of the last event received, the following ones being sent first
+	LastEventId *int64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchRequest) GetSpecies() Species {
+	if x != nil {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *WatchRequest) GetLastEventId() int64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
+	}
+	return 0
+}
+
+type WatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *BreedEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchResponse) GetEvent() *BreedEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type BreedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Id of the event, to resume the stream from
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// breed.created, breed.updated, breed.deleted, breed.restored or breed.purged
+	Type       string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Breed once changed, without its deletion and update dates
+	Breed *Breed `protobuf:"bytes,4,opt,name=breed,proto3" json:"breed,omitempty"`
+	// Fields changed by an update
+	ChangedFields []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+}
+
+func (x *BreedEvent) Reset() {
+	*x = BreedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_breeds_v1_breeds_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreedEvent) ProtoMessage() {}
+
+func (x *BreedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_breeds_v1_breeds_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreedEvent.ProtoReflect.Descriptor instead.
+func (*BreedEvent) Descriptor() ([]byte, []int) {
+	return file_breeds_v1_breeds_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BreedEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BreedEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BreedEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *BreedEvent) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+func (x *BreedEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+var File_breeds_v1_breeds_proto protoreflect.FileDescriptor
+
+var file_breeds_v1_breeds_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x72, 0x65, 0x65,
+	0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73,
+	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x03, 0x0a, 0x05, 0x42, 0x72, 0x65, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x2d, 0x0a, 0x08, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x07, 0x70, 0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x42, 0x0a, 0x1b, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x65, 0x6d, 0x61, 0x6c,
+	0x65, 0x5f, 0x61, 0x64, 0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x18, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x46,
+	0x65, 0x6d, 0x61, 0x6c, 0x65, 0x41, 0x64, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x19, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6d,
+	0x61, 0x6c, 0x65, 0x5f, 0x61, 0x64, 0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x16, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67,
+	0x65, 0x4d, 0x61, 0x6c, 0x65, 0x41, 0x64, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x61, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x65, 0x6d, 0x61, 0x6c, 0x65, 0x5f, 0x61, 0x64, 0x75,
+	0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x61, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x6c, 0x65, 0x5f, 0x61, 0x64, 0x75, 0x6c, 0x74,
+	0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x51, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x61, 0x73, 0x5f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x22, 0x35, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x72, 0x65,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65,
+	0x64, 0x22, 0x86, 0x03, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12,
+	0x2d, 0x0a, 0x08, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
+	0x74, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x07, 0x70, 0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x42,
+	0x0a, 0x1b, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x65, 0x6d, 0x61, 0x6c, 0x65,
+	0x5f, 0x61, 0x64, 0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x18, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x46, 0x65,
+	0x6d, 0x61, 0x6c, 0x65, 0x41, 0x64, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x3e, 0x0a, 0x19, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61,
+	0x6c, 0x65, 0x5f, 0x61, 0x64, 0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x16, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x4d, 0x61, 0x6c, 0x65, 0x41, 0x64, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88,
+	0x01, 0x01, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x05, 0x61,
+	0x73, 0x5f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x42, 0x1e, 0x0a, 0x1c,
+	0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x65, 0x6d, 0x61, 0x6c, 0x65, 0x5f,
+	0x61, 0x64, 0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x1c, 0x0a, 0x1a,
+	0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x6c, 0x65, 0x5f, 0x61, 0x64,
+	0x75, 0x6c, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x36, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x72,
+	0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x65, 0x65,
+	0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x52, 0x05, 0x62, 0x72, 0x65,
+	0x65, 0x64, 0x22, 0x37, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x72, 0x65, 0x65, 0x64, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x22, 0x38, 0x0a, 0x0e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a,
+	0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62,
+	0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x52, 0x05,
+	0x62, 0x72, 0x65, 0x65, 0x64, 0x22, 0x37, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x22, 0x38,
+	0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x26, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x65,
+	0x64, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x22, 0x23, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x10, 0x0a,
+	0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x77, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2c, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0xbc, 0x01, 0x0a, 0x0a, 0x42, 0x72, 0x65, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x72, 0x65, 0x65, 0x64, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x2a, 0x44, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x17, 0x0a, 0x13, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x45, 0x53, 0x5f, 0x44, 0x4f, 0x47, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x43, 0x41, 0x54, 0x10, 0x02, 0x2a, 0x5f, 0x0a, 0x07, 0x50,
+	0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x45, 0x54, 0x5f, 0x53, 0x49,
+	0x5a, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x54, 0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x53, 0x4d, 0x41,
+	0x4c, 0x4c, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x45, 0x54, 0x5f, 0x53, 0x49, 0x5a, 0x45,
+	0x5f, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x45, 0x54,
+	0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x54, 0x41, 0x4c, 0x4c, 0x10, 0x03, 0x32, 0xfa, 0x02, 0x0a,
+	0x0c, 0x42, 0x72, 0x65, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a,
+	0x03, 0x47, 0x65, 0x74, 0x12, 0x15, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x72,
+	0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x62, 0x72,
+	0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3d,
+	0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x61, 0x70, 0x68, 0x79, 0x2d, 0x74, 0x65,
+	0x63, 0x68, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69,
+	0x2f, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73, 0x76, 0x31, 0x3b, 0x62, 0x72, 0x65, 0x65, 0x64, 0x73,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_breeds_v1_breeds_proto_rawDescOnce sync.Once
+	file_breeds_v1_breeds_proto_rawDescData = file_breeds_v1_breeds_proto_rawDesc
+)
+
+func file_breeds_v1_breeds_proto_rawDescGZIP() []byte {
+	file_breeds_v1_breeds_proto_rawDescOnce.Do(func() {
+		file_breeds_v1_breeds_proto_rawDescData = protoimpl.X.CompressGZIP(file_breeds_v1_breeds_proto_rawDescData)
+	})
+	return file_breeds_v1_breeds_proto_rawDescData
+}
+
+var file_breeds_v1_breeds_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_breeds_v1_breeds_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_breeds_v1_breeds_proto_goTypes = []any{
+	(Species)(0),                  // 0: breeds.v1.Species
+	(PetSize)(0),                  // 1: breeds.v1.PetSize
+	(*Breed)(nil),                 // 2: breeds.v1.Breed
+	(*GetRequest)(nil),            // 3: breeds.v1.GetRequest
+	(*GetResponse)(nil),           // 4: breeds.v1.GetResponse
+	(*ListRequest)(nil),           // 5: breeds.v1.ListRequest
+	(*ListResponse)(nil),          // 6: breeds.v1.ListResponse
+	(*CreateRequest)(nil),         // 7: breeds.v1.CreateRequest
+	(*CreateResponse)(nil),        // 8: breeds.v1.CreateResponse
+	(*UpdateRequest)(nil),         // 9: breeds.v1.UpdateRequest
+	(*UpdateResponse)(nil),        // 10: breeds.v1.UpdateResponse
+	(*DeleteRequest)(nil),         // 11: breeds.v1.DeleteRequest
+	(*DeleteResponse)(nil),        // 12: breeds.v1.DeleteResponse
+	(*WatchRequest)(nil),          // 13: breeds.v1.WatchRequest
+	(*WatchResponse)(nil),         // 14: breeds.v1.WatchResponse
+	(*BreedEvent)(nil),            // 15: breeds.v1.BreedEvent
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+}
+var file_breeds_v1_breeds_proto_depIdxs = []int32{
+	0,  // 0: breeds.v1.Breed.species:type_name -> breeds.v1.Species
+	1,  // 1: breeds.v1.Breed.pet_size:type_name -> breeds.v1.PetSize
+	16, // 2: breeds.v1.Breed.deleted_at:type_name -> google.protobuf.Timestamp
+	16, // 3: breeds.v1.Breed.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 4: breeds.v1.GetRequest.as_of:type_name -> google.protobuf.Timestamp
+	2,  // 5: breeds.v1.GetResponse.breed:type_name -> breeds.v1.Breed
+	0,  // 6: breeds.v1.ListRequest.species:type_name -> breeds.v1.Species
+	1,  // 7: breeds.v1.ListRequest.pet_size:type_name -> breeds.v1.PetSize
+	16, // 8: breeds.v1.ListRequest.as_of:type_name -> google.protobuf.Timestamp
+	2,  // 9: breeds.v1.ListResponse.breed:type_name -> breeds.v1.Breed
+	2,  // 10: breeds.v1.CreateRequest.breed:type_name -> breeds.v1.Breed
+	2,  // 11: breeds.v1.CreateResponse.breed:type_name -> breeds.v1.Breed
+	2,  // 12: breeds.v1.UpdateRequest.breed:type_name -> breeds.v1.Breed
+	2,  // 13: breeds.v1.UpdateResponse.breed:type_name -> breeds.v1.Breed
+	0,  // 14: breeds.v1.WatchRequest.species:type_name -> breeds.v1.Species
+	15, // 15: breeds.v1.WatchResponse.event:type_name -> breeds.v1.BreedEvent
+	16, // 16: breeds.v1.BreedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 17: breeds.v1.BreedEvent.breed:type_name -> breeds.v1.Breed
+	3,  // 18: breeds.v1.BreedService.Get:input_type -> breeds.v1.GetRequest
+	5,  // 19: breeds.v1.BreedService.List:input_type -> breeds.v1.ListRequest
+	7,  // 20: breeds.v1.BreedService.Create:input_type -> breeds.v1.CreateRequest
+	9,  // 21: breeds.v1.BreedService.Update:input_type -> breeds.v1.UpdateRequest
+	11, // 22: breeds.v1.BreedService.Delete:input_type -> breeds.v1.DeleteRequest
+	13, // 23: breeds.v1.BreedService.Watch:input_type -> breeds.v1.WatchRequest
+	4,  // 24: breeds.v1.BreedService.Get:output_type -> breeds.v1.GetResponse
+	6,  // 25: breeds.v1.BreedService.List:output_type -> breeds.v1.ListResponse
+	8,  // 26: breeds.v1.BreedService.Create:output_type -> breeds.v1.CreateResponse
+	10, // 27: breeds.v1.BreedService.Update:output_type -> breeds.v1.UpdateResponse
+	12, // 28: breeds.v1.BreedService.Delete:output_type -> breeds.v1.DeleteResponse
+	14, // 29: breeds.v1.BreedService.Watch:output_type -> breeds.v1.WatchResponse
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_breeds_v1_breeds_proto_init() }
+func file_breeds_v1_breeds_proto_init() {
+	if File_breeds_v1_breeds_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_breeds_v1_breeds_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Breed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_breeds_v1_breeds_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*BreedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_breeds_v1_breeds_proto_msgTypes[0].OneofWrappers = []any{}
+	file_breeds_v1_breeds_proto_msgTypes[3].OneofWrappers = []any{}
+	file_breeds_v1_breeds_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_breeds_v1_breeds_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_breeds_v1_breeds_proto_goTypes,
+		DependencyIndexes: file_breeds_v1_breeds_proto_depIdxs,
+		EnumInfos:         file_breeds_v1_breeds_proto_enumTypes,
+		MessageInfos:      file_breeds_v1_breeds_proto_msgTypes,
+	}.Build()
+	File_breeds_v1_breeds_proto = out.File
+	file_breeds_v1_breeds_proto_rawDesc = nil
+	file_breeds_v1_breeds_proto_goTypes = nil
+	file_breeds_v1_breeds_proto_depIdxs = nil
+}
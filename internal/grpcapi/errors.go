@@ -0,0 +1,49 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errCodes
+// gRPC counterpart of the domain errors, the way HandleErrorResponse maps them to
+// HTTP statuses
+var errCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{domainerror.ErrDomainValidation, codes.InvalidArgument},
+	{domainerror.ErrResourceNotFound, codes.NotFound},
+	{domainerror.ErrResourceAlreadyExists, codes.AlreadyExists},
+	{domainerror.ErrNothingTodo, codes.FailedPrecondition},
+	{domainerror.ErrUnprocessableEntity, codes.FailedPrecondition},
+	{domainerror.ErrUnauthenticated, codes.Unauthenticated},
+	{domainerror.ErrForbidden, codes.PermissionDenied},
+	{domainerror.ErrTooManyRequests, codes.ResourceExhausted},
+	{domainerror.ErrNotAcceptable, codes.InvalidArgument},
+	{domainerror.ErrInternalError, codes.Internal},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+	{context.Canceled, codes.Canceled},
+}
+
+// StatusFromError
+// Convert an error returned by the usecases to a gRPC status error. The errors
+// which are already a status are kept, the unknown ones are internal
+func StatusFromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, val := range errCodes {
+		if errors.Is(err, val.err) {
+			return status.Error(val.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
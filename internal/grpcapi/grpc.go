@@ -0,0 +1,27 @@
+package grpcapi
+
+import (
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedsv1"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewGRPCServer
+// gRPC server of the breeds service, along with the health service backed by the
+// checker and the reflection service. The calls of the breeds service are
+// authenticated with the given authenticators
+func NewGRPCServer(server breedsv1.BreedServiceServer, checker *health.Checker, authenticators []api.Authenticator, opts ...grpc.ServerOption) *grpc.Server {
+	interceptors := []Interceptor{RequestContext(), Auth(authenticators...)}
+	s := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryInterceptor(interceptors...)),
+		grpc.ChainStreamInterceptor(StreamInterceptor(interceptors...)),
+	}, opts...)...)
+
+	breedsv1.RegisterBreedServiceServer(s, server)
+	healthpb.RegisterHealthServer(s, NewHealthServer(checker))
+	reflection.Register(s)
+	return s
+}
@@ -0,0 +1,76 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedsv1"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthWatchInterval is how often the readiness is checked for the health watchers
+const HealthWatchInterval = 5 * time.Second
+
+// HealthServer
+// Implement the gRPC health service with the readiness of the service (see
+// health.Checker): the service is serving when it is ready
+type HealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	checker *health.Checker
+}
+
+func NewHealthServer(checker *health.Checker) *HealthServer {
+	return &HealthServer{
+		checker: checker,
+	}
+}
+
+func (h HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !knownService(req.GetService()) {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: h.status(ctx)}, nil
+}
+
+// Watch
+// Send the serving status, then each of its changes
+func (h HealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if !knownService(req.GetService()) {
+		return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	ticker := time.NewTicker(HealthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		if current := h.status(stream.Context()); current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h HealthServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if h.checker.Ready(ctx).Status != health.StatusReady {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// knownService
+// The whole server is checked with the empty service name
+func knownService(name string) bool {
+	return name == "" || name == breedsv1.BreedService_ServiceDesc.ServiceName
+}
@@ -0,0 +1,178 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedsv1"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptor
+// Decorate the calls, unary and streaming alike, with what the REST middlewares do
+// for the requests. The context returned is the one the call is handled with
+type Interceptor func(ctx context.Context, method string) (context.Context, error)
+
+// UnaryInterceptor
+// Run the interceptors before a unary call, and send back its errors as statuses
+func UnaryInterceptor(interceptors ...Interceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := intercept(ctx, info.FullMethod, interceptors)
+		if err != nil {
+			return nil, err
+		}
+		res, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, err)
+		return res, StatusFromError(err)
+	}
+}
+
+// StreamInterceptor
+// Run the interceptors before a streaming call, and send back its errors as statuses
+func StreamInterceptor(interceptors ...Interceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := intercept(ss.Context(), info.FullMethod, interceptors)
+		if err != nil {
+			return err
+		}
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, err)
+		return StatusFromError(err)
+	}
+}
+
+func intercept(ctx context.Context, method string, interceptors []Interceptor) (context.Context, error) {
+	ctx = context.WithValue(ctx, startKey{}, time.Now())
+	for _, interceptor := range interceptors {
+		var err error
+		ctx, err = interceptor(ctx, method)
+		if err != nil {
+			logCall(ctx, method, err)
+			return ctx, StatusFromError(err)
+		}
+	}
+	return ctx, nil
+}
+
+type startKey struct{}
+
+// logCall
+// Log every call once it is handled, with its status code and its latency, like
+// api.AccessLogMiddleware does for the requests
+func logCall(ctx context.Context, method string, err error) {
+	var (
+		code = status.Code(StatusFromError(err))
+		log  = logger.FromContext(ctx).Info
+	)
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		log = logger.FromContext(ctx).Error
+	}
+	start, _ := ctx.Value(startKey{}).(time.Time)
+	log("call",
+		"method", method,
+		"code", code.String(),
+		"latency", time.Since(start),
+	)
+}
+
+// serverStream
+// Stream handled with the context returned by the interceptors
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestContext
+// Store the request id and the actor of the call in its context, along with a logger
+// carrying them, from the x-request-id and x-actor metadata like
+// api.RequestContextMiddleware. The request id is generated when the client does not
+// provide one and is sent back in the header metadata. The actor given by the client is
+// replaced by the principal once Auth authenticates the call
+func RequestContext() Interceptor {
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID := first(md, api.RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		actor := first(md, api.ActorHeader)
+		if actor == "" {
+			actor = api.AnonymousActor
+		}
+
+		ctx = api.WithRequestID(ctx, requestID)
+		ctx = api.WithActor(ctx, actor)
+
+		// Only fails when the header is already sent, which cannot be before the call
+		_ = grpc.SetHeader(ctx, metadata.Pairs(api.RequestIDHeader, requestID))
+		return ctx, nil
+	}
+}
+
+// Auth
+// Authenticate the calls of the breeds service with the first authenticator finding
+// credentials in their metadata, and store their principal in the call context. The
+// same authenticators as the REST api are used: the metadata are given to them as the
+// headers of a request. What a principal may do is checked by the usecases
+func Auth(authenticators ...api.Authenticator) Interceptor {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		// The health and reflection services are public
+		if !strings.HasPrefix(method, "/"+breedsv1.BreedService_ServiceDesc.ServiceName+"/") {
+			return ctx, nil
+		}
+
+		r := metadataRequest(ctx)
+		for _, authenticate := range authenticators {
+			principal, ok, err := authenticate(r)
+			if !ok {
+				continue
+			}
+			if err != nil {
+				return ctx, err
+			}
+			// The actor claimed by the client is only trusted without credentials
+			return api.WithActor(reqcontext.WithPrincipal(ctx, principal), principal.ID()), nil
+		}
+		return ctx, domainerror.WrapError(domainerror.ErrUnauthenticated, errors.New("credentials are required"))
+	}
+}
+
+// metadataRequest
+// Request carrying the metadata of the call as headers, and its peer as remote address
+func metadataRequest(ctx context.Context) *http.Request {
+	r, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/", nil)
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, vals := range md {
+		for _, val := range vals {
+			r.Header.Add(key, val)
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.RemoteAddr = p.Addr.String()
+	}
+	return r
+}
+
+func first(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
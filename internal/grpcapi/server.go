@@ -0,0 +1,292 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedsv1"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server
+// Implement breedsv1.BreedServiceServer with the usecases of the breeds
+type Server struct {
+	breedsv1.UnimplementedBreedServiceServer
+
+	logger    *charmLog.Logger
+	datastore gateways.IDatastore
+	// usecaseOpts configure every usecase executed by the server
+	usecaseOpts []usecases.Option
+	broker      *sse.Broker
+}
+
+type ServerOption func(*Server)
+
+// WithUsecaseOptions
+// Build the usecases with the given options, e.g. the interceptors executing them
+func WithUsecaseOptions(opts ...usecases.Option) ServerOption {
+	return func(s *Server) {
+		s.usecaseOpts = append(s.usecaseOpts, opts...)
+	}
+}
+
+// WithBroker
+// Serve the breeds changes stream from the given broker
+func WithBroker(broker *sse.Broker) ServerOption {
+	return func(s *Server) {
+		s.broker = broker
+	}
+}
+
+func New(logger *charmLog.Logger, datastore gateways.IDatastore, opts ...ServerOption) *Server {
+	s := &Server{
+		logger:    logger,
+		datastore: datastore,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s Server) Get(ctx context.Context, req *breedsv1.GetRequest) (*breedsv1.GetResponse, error) {
+	var (
+		res *breeds.Breed
+		err error
+	)
+
+	if req.GetAsOf() != nil {
+		res, err = usecases.New(&breedsUsecase.GetOneByNameAsOf{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedsUsecase.GetOneByNameAsOfOpts{
+			Name: req.GetName(),
+			AsOf: req.GetAsOf().AsTime(),
+		})
+	} else {
+		res, err = usecases.New(&breedsUsecase.GetOneByName{}, s.datastore, s.usecaseOpts...).Handle(ctx, req.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &breedsv1.GetResponse{Breed: BreedToProto(res)}, nil
+}
+
+func (s Server) List(req *breedsv1.ListRequest, stream breedsv1.BreedService_ListServer) error {
+	if _, ok := breedsv1.Species_name[int32(req.GetSpecies())]; !ok {
+		return domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies)
+	}
+	if _, ok := breedsv1.PetSize_name[int32(req.GetPetSize())]; !ok {
+		return domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidPetSize)
+	}
+
+	opts := breedsUsecase.ListOpts{
+		Species:             enumToString(req.GetSpecies(), speciesPrefix),
+		PetSize:             enumToString(req.GetPetSize(), petSizePrefix),
+		AverageFemaleWeight: int32ToInt(req.AverageFemaleAdultWeight),
+		AverageMaleWeight:   int32ToInt(req.AverageMaleAdultWeight),
+		IncludeDeleted:      req.GetIncludeDeleted(),
+	}
+	if req.GetAsOf() != nil {
+		asOf := req.GetAsOf().AsTime()
+		opts.AsOf = &asOf
+	}
+
+	res, err := usecases.New(&breedsUsecase.List{}, s.datastore, s.usecaseOpts...).Handle(stream.Context(), opts)
+	if err != nil {
+		return err
+	}
+	for _, val := range res {
+		if err := stream.Send(&breedsv1.ListResponse{Breed: BreedToProto(val)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Server) Create(ctx context.Context, req *breedsv1.CreateRequest) (*breedsv1.CreateResponse, error) {
+	res, err := usecases.New(&breedsUsecase.CreateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedFromProto(req.GetBreed()))
+	if err != nil {
+		return nil, err
+	}
+	return &breedsv1.CreateResponse{Breed: BreedToProto(res)}, nil
+}
+
+func (s Server) Update(ctx context.Context, req *breedsv1.UpdateRequest) (*breedsv1.UpdateResponse, error) {
+	res, err := usecases.New(&breedsUsecase.UpdateOne{}, s.datastore, s.usecaseOpts...).Handle(ctx, breedFromProto(req.GetBreed()))
+	if err != nil {
+		return nil, err
+	}
+	return &breedsv1.UpdateResponse{Breed: BreedToProto(res)}, nil
+}
+
+func (s Server) Delete(ctx context.Context, req *breedsv1.DeleteRequest) (*breedsv1.DeleteResponse, error) {
+	err := usecases.NewSimple(&breedsUsecase.DeleteOneByName{}, s.datastore, s.usecaseOpts...).Handle(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &breedsv1.DeleteResponse{}, nil
+}
+
+func (s Server) Watch(req *breedsv1.WatchRequest, stream breedsv1.BreedService_WatchServer) error {
+	ctx := stream.Context()
+
+	// Watching the changes is listing the breeds as they go
+	if err := usecases.Authorize(ctx, usecases.UseCaseInfo{Action: usecases.ActionList, Name: usecases.BreedUsecase}); err != nil {
+		return err
+	}
+	if s.broker == nil {
+		return status.Error(codes.Unavailable, "events stream is not available")
+	}
+
+	var species string
+	if val := enumToString(req.GetSpecies(), speciesPrefix); val != nil {
+		parsed, err := values.SpeciesFromString(*val)
+		if err != nil {
+			return domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies)
+		}
+		species = parsed.String()
+	}
+
+	replay, events, cancel, err := s.broker.Subscribe(ctx, req.LastEventId, species)
+	if errors.Is(err, sse.ErrClosed) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for _, evt := range replay {
+		if err := stream.Send(&breedsv1.WatchResponse{Event: EventToProto(evt)}); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-events:
+			if !ok {
+				// Lagging behind or shutting down: the client resumes from its last event
+				return status.Error(codes.Unavailable, "events stream is closed, resume from the last event received")
+			}
+			if err := stream.Send(&breedsv1.WatchResponse{Event: EventToProto(evt)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+const (
+	speciesPrefix = "SPECIES_"
+	petSizePrefix = "PET_SIZE_"
+)
+
+// enum
+// Constraint of the generated enums
+type enum interface {
+	~int32
+	String() string
+}
+
+// enumToString
+// Value of an enum as known by the domain, e.g. SPECIES_CAT is cat. The unspecified
+// value is nil
+func enumToString[T enum](val T, prefix string) *string {
+	if val == 0 {
+		return nil
+	}
+	res := strings.ToLower(strings.TrimPrefix(val.String(), prefix))
+	return &res
+}
+
+// enumFromString
+// Enum of a value known by the domain, the unspecified value when it is unknown
+func enumFromString[T enum](val string, prefix string, names map[string]int32) T {
+	return T(names[prefix+strings.ToUpper(val)])
+}
+
+func int32ToInt(val *int32) *int {
+	if val == nil {
+		return nil
+	}
+	res := int(*val)
+	return &res
+}
+
+func intToInt32(val int) *int32 {
+	res := int32(val)
+	return &res
+}
+
+func BreedToProto(domain *breeds.Breed) *breedsv1.Breed {
+	res := &breedsv1.Breed{
+		Name:                     domain.Name().String(),
+		Species:                  enumFromString[breedsv1.Species](domain.Species().String(), speciesPrefix, breedsv1.Species_value),
+		PetSize:                  enumFromString[breedsv1.PetSize](domain.PetSize().String(), petSizePrefix, breedsv1.PetSize_value),
+		AverageFemaleAdultWeight: intToInt32(domain.AverageFemaleWeight()),
+		AverageMaleAdultWeight:   intToInt32(domain.AverageMaleWeight()),
+	}
+	if domain.DeletedAt() != nil {
+		res.DeletedAt = timestamppb.New(*domain.DeletedAt())
+	}
+	if !domain.UpdatedAt().IsZero() {
+		res.UpdatedAt = timestamppb.New(domain.UpdatedAt())
+	}
+	return res
+}
+
+func breedFromProto(msg *breedsv1.Breed) breeds.FactoryOpts {
+	var res breeds.FactoryOpts
+	if msg == nil {
+		return res
+	}
+	res.Name = msg.GetName()
+	if val := enumToString(msg.GetSpecies(), speciesPrefix); val != nil {
+		res.Species = *val
+	}
+	if val := enumToString(msg.GetPetSize(), petSizePrefix); val != nil {
+		res.PetSize = *val
+	}
+	res.AverageFemaleWeight = int32ToInt(msg.AverageFemaleAdultWeight)
+	res.AverageMaleWeight = int32ToInt(msg.AverageMaleAdultWeight)
+	return res
+}
+
+// EventToProto
+// Convert an event of the stream from its outbox.Envelope
+func EventToProto(evt sse.Event) *breedsv1.BreedEvent {
+	var envelope outbox.Envelope
+
+	// Payloads are written by the outbox, an invalid one only loses the breed
+	_ = json.Unmarshal(evt.Data, &envelope)
+	res := &breedsv1.BreedEvent{
+		Id:   evt.ID,
+		Type: evt.Name,
+		Breed: &breedsv1.Breed{
+			Name:                     envelope.Breed.Name,
+			Species:                  enumFromString[breedsv1.Species](envelope.Breed.Species, speciesPrefix, breedsv1.Species_value),
+			PetSize:                  enumFromString[breedsv1.PetSize](envelope.Breed.PetSize, petSizePrefix, breedsv1.PetSize_value),
+			AverageFemaleAdultWeight: intToInt32(envelope.Breed.AverageFemaleAdultWeight),
+			AverageMaleAdultWeight:   intToInt32(envelope.Breed.AverageMaleAdultWeight),
+		},
+	}
+	if !envelope.OccurredAt.IsZero() {
+		res.OccurredAt = timestamppb.New(envelope.OccurredAt)
+	}
+	for _, val := range envelope.Changes {
+		res.ChangedFields = append(res.ChangedFields, val.Field)
+	}
+	return res
+}
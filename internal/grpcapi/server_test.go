@@ -0,0 +1,244 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/api"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/grpcapi"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedsv1"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	apikeysUsecase "github.com/japhy-tech/backend-test/internal/usecases/apikeys"
+	"github.com/maxatome/go-testdeep/td"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newConn
+// Serve the breeds service in memory and connect to it
+func newConn(t *testing.T, logger *charmLog.Logger, datastore gateways.IDatastore, opts ...grpcapi.ServerOption) *grpc.ClientConn {
+	var (
+		listener = bufconn.Listen(1 << 20)
+		server   = grpcapi.NewGRPCServer(grpcapi.New(logger, datastore, opts...), health.New(), []api.Authenticator{api.APIKeyAuthenticator(datastore.APIKeys())})
+	)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	td.Require(t).CmpNoError(err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// withKeys
+// Context of the calls made with an api key of each scope
+func withKeys(ctx context.Context, require *td.T, datastore gateways.IDatastore) map[string]context.Context {
+	res := map[string]context.Context{}
+	for _, scope := range []string{"read", "write", "admin"} {
+		key, err := usecases.New(&apikeysUsecase.CreateOne{}, datastore).Handle(ctx, apikeysUsecase.CreateOneOpts{Name: scope, Scopes: []string{scope}})
+		require.CmpNoError(err)
+		res[scope] = metadata.AppendToOutgoingContext(ctx, "x-api-key", key.Key)
+	}
+	return res
+}
+
+func TestBreedService(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			client = breedsv1.NewBreedServiceClient(newConn(t, logger, datastore))
+			calls  = withKeys(ctx, require, datastore)
+			breed  = &breedsv1.Breed{
+				Name:                     "bengal",
+				Species:                  breedsv1.Species_SPECIES_CAT,
+				PetSize:                  breedsv1.PetSize_PET_SIZE_MEDIUM,
+				AverageFemaleAdultWeight: proto.Int32(4000),
+				AverageMaleAdultWeight:   proto.Int32(5000),
+			}
+		)
+
+		_, err := client.Get(ctx, &breedsv1.GetRequest{Name: "bengal"})
+		require.Cmp(status.Code(err), codes.Unauthenticated, "invalid case -- missing credentials")
+
+		created, err := client.Create(metadata.AppendToOutgoingContext(calls["write"], "x-actor", "back_office"), &breedsv1.CreateRequest{Breed: breed})
+		require.CmpNoError(err, "valid case -- create")
+		require.Cmp(created.GetBreed(), td.Struct(&breedsv1.Breed{}, td.StructFields{
+			"Name":                     "bengal",
+			"Species":                  breedsv1.Species_SPECIES_CAT,
+			"PetSize":                  breedsv1.PetSize_PET_SIZE_MEDIUM,
+			"AverageFemaleAdultWeight": td.Ptr(int32(4000)),
+			"AverageMaleAdultWeight":   td.Ptr(int32(5000)),
+			"DeletedAt":                nil,
+			"UpdatedAt":                td.NotNil(),
+		}))
+		entries, err := datastore.Audit().ListByBreedName(ctx, values.BreedName("bengal"))
+		require.CmpNoError(err)
+		require.Cmp(entries, td.Len(1))
+		require.Cmp(entries[0].Actor(), apikeys.PrincipalPrefix+"write", "valid case -- the principal is the actor")
+		_, err = client.Create(calls["write"], &breedsv1.CreateRequest{Breed: breed})
+		require.Cmp(status.Code(err), codes.AlreadyExists, "invalid case -- create existing")
+		_, err = client.Create(calls["write"], &breedsv1.CreateRequest{Breed: &breedsv1.Breed{Name: "sphynx", PetSize: breedsv1.PetSize_PET_SIZE_SMALL}})
+		require.Cmp(status.Code(err), codes.InvalidArgument, "invalid case -- create without species")
+		require.Contains(status.Convert(err).Message(), values.ErrInvalidSpecies.Error())
+		_, err = client.Create(calls["read"], &breedsv1.CreateRequest{Breed: breed})
+		require.Cmp(status.Code(err), codes.PermissionDenied, "invalid case -- create with read scope")
+
+		got, err := client.Get(calls["read"], &breedsv1.GetRequest{Name: "bengal"})
+		require.CmpNoError(err, "valid case -- get")
+		require.Cmp(got.GetBreed().GetName(), "bengal")
+		_, err = client.Get(calls["read"], &breedsv1.GetRequest{Name: "unknown"})
+		require.Cmp(status.Code(err), codes.NotFound, "invalid case -- get unknown")
+
+		breed.AverageFemaleAdultWeight = proto.Int32(4500)
+		updated, err := client.Update(calls["write"], &breedsv1.UpdateRequest{Breed: breed})
+		require.CmpNoError(err, "valid case -- update")
+		require.Cmp(updated.GetBreed().GetAverageFemaleAdultWeight(), int32(4500))
+		_, err = client.Update(calls["write"], &breedsv1.UpdateRequest{Breed: &breedsv1.Breed{Name: "unknown", Species: breedsv1.Species_SPECIES_DOG, PetSize: breedsv1.PetSize_PET_SIZE_TALL}})
+		require.Cmp(status.Code(err), codes.NotFound, "invalid case -- update unknown")
+
+		_, err = client.Create(calls["write"], &breedsv1.CreateRequest{Breed: &breedsv1.Breed{Name: "beagle", Species: breedsv1.Species_SPECIES_DOG, PetSize: breedsv1.PetSize_PET_SIZE_MEDIUM}})
+		require.CmpNoError(err)
+		list := func(req *breedsv1.ListRequest) ([]string, error) {
+			stream, err := client.List(calls["read"], req)
+			require.CmpNoError(err)
+			var res []string
+			for {
+				msg, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					return res, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, msg.GetBreed().GetName())
+			}
+		}
+		names, err := list(&breedsv1.ListRequest{})
+		require.CmpNoError(err, "valid case -- list")
+		require.Cmp(names, td.Bag("bengal", "beagle"))
+		names, err = list(&breedsv1.ListRequest{Species: breedsv1.Species_SPECIES_DOG})
+		require.CmpNoError(err, "valid case -- list by species")
+		require.Cmp(names, []string{"beagle"})
+		_, err = list(&breedsv1.ListRequest{IncludeDeleted: true})
+		require.Cmp(status.Code(err), codes.PermissionDenied, "invalid case -- list deleted with read scope")
+		_, err = list(&breedsv1.ListRequest{Species: breedsv1.Species(42)})
+		require.Cmp(status.Code(err), codes.InvalidArgument, "invalid case -- list unknown species")
+
+		_, err = client.Delete(calls["write"], &breedsv1.DeleteRequest{Name: "bengal"})
+		require.Cmp(status.Code(err), codes.PermissionDenied, "invalid case -- delete with write scope")
+		_, err = client.Delete(calls["admin"], &breedsv1.DeleteRequest{Name: "bengal"})
+		require.CmpNoError(err, "valid case -- delete")
+		names, err = list(&breedsv1.ListRequest{})
+		require.CmpNoError(err)
+		require.Cmp(names, []string{"beagle"})
+	})
+}
+
+func TestBreedService_Watch(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			broker = sse.NewBroker(datastore.Outbox(), logger)
+			client = breedsv1.NewBreedServiceClient(newConn(t, logger, datastore, grpcapi.WithBroker(broker)))
+			calls  = withKeys(ctx, require, datastore)
+		)
+		require.CmpNoError(broker.Poll(ctx))
+
+		for _, val := range []struct {
+			name    string
+			species values.Species
+		}{{"cat", values.Cat}, {"dog", values.Dog}} {
+			b, err := breeds.NewFactory(breeds.FactoryOpts{Name: val.name, Species: val.species.String(), PetSize: values.Tall.String()}).Instantiate()
+			require.CmpNoError(err)
+			_, err = datastore.Breeds().CreateOne(ctx, b)
+			require.CmpNoError(err)
+		}
+		require.CmpNoError(broker.Poll(ctx))
+
+		// Resume from the beginning, only the dogs
+		stream, err := client.Watch(calls["read"], &breedsv1.WatchRequest{Species: breedsv1.Species_SPECIES_DOG, LastEventId: proto.Int64(0)})
+		require.CmpNoError(err)
+		msg, err := stream.Recv()
+		require.CmpNoError(err)
+		require.Cmp(msg.GetEvent().GetType(), breeds.EventBreedCreated)
+		require.Cmp(msg.GetEvent().GetBreed().GetName(), "dog")
+		require.Cmp(msg.GetEvent().GetBreed().GetSpecies(), breedsv1.Species_SPECIES_DOG)
+		dogID := msg.GetEvent().GetId()
+
+		// Live events
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "cat"))
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "dog"))
+		require.CmpNoError(broker.Poll(ctx))
+		msg, err = stream.Recv()
+		require.CmpNoError(err)
+		require.Cmp(msg.GetEvent().GetType(), breeds.EventBreedDeleted)
+		require.Gt(msg.GetEvent().GetId(), dogID)
+
+		// Shutting down ends the stream
+		broker.Close()
+		_, err = stream.Recv()
+		require.Cmp(status.Code(err), codes.Unavailable)
+	})
+}
+
+func TestHealthServer(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		client := healthpb.NewHealthClient(newConn(t, logger, datastore))
+
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		require.CmpNoError(err, "valid case -- without credentials")
+		require.Cmp(res.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+		res, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: breedsv1.BreedService_ServiceDesc.ServiceName})
+		require.CmpNoError(err, "valid case -- breeds service")
+		require.Cmp(res.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+		_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+		require.Cmp(status.Code(err), codes.NotFound, "invalid case -- unknown service")
+	})
+}
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "valid case -- validation", err: domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies), want: codes.InvalidArgument},
+		{name: "valid case -- not found", err: domainerror.WrapError(domainerror.ErrResourceNotFound, errors.New("breed")), want: codes.NotFound},
+		{name: "valid case -- already exists", err: domainerror.ErrResourceAlreadyExists, want: codes.AlreadyExists},
+		{name: "valid case -- unauthenticated", err: domainerror.ErrUnauthenticated, want: codes.Unauthenticated},
+		{name: "valid case -- forbidden", err: domainerror.ErrForbidden, want: codes.PermissionDenied},
+		{name: "valid case -- too many requests", err: domainerror.ErrTooManyRequests, want: codes.ResourceExhausted},
+		{name: "valid case -- timeout", err: context.DeadlineExceeded, want: codes.DeadlineExceeded},
+		{name: "valid case -- status kept", err: status.Error(codes.Unavailable, "closed"), want: codes.Unavailable},
+		{name: "valid case -- unknown error", err: errors.New("boom"), want: codes.Internal},
+		{name: "valid case -- no error", err: nil, want: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td.Cmp(t, status.Code(grpcapi.StatusFromError(tt.err)), tt.want)
+		})
+	}
+}
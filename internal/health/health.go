@@ -0,0 +1,184 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds each check of the readiness
+	DefaultTimeout = 2 * time.Second
+
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+var (
+	ErrShuttingDown = errors.New("the service is shutting down")
+	ErrNotCompleted = errors.New("not completed yet")
+)
+
+// Check
+// Verify that a dependency of the service is usable
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// CheckReport
+// Outcome of one check of the readiness
+type CheckReport struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report
+// Outcome of the readiness, every check being reported
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckReport `json:"checks"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// Checker
+// Serve the liveness and the readiness of the service. The service is ready when
+// every check succeeds and it is not shutting down
+type Checker struct {
+	checks       []namedCheck
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// Option
+// Configure the checker
+type Option func(*Checker)
+
+// WithCheck
+// Add a check to the readiness, reported under the given name
+func WithCheck(name string, check Check) Option {
+	return func(c *Checker) {
+		c.checks = append(c.checks, namedCheck{name: name, check: check})
+	}
+}
+
+// WithTimeout
+// Bound each check by the given duration instead of DefaultTimeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) {
+		c.timeout = timeout
+	}
+}
+
+func New(opts ...Option) *Checker {
+	c := &Checker{
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Shutdown
+// Report the service as not ready from now on, so that no more traffic is sent to it
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready
+// Run every check concurrently and report their outcome
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{
+		Status: StatusReady,
+		Checks: make([]CheckReport, len(c.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, val := range c.checks {
+		wg.Add(1)
+		go func(i int, val namedCheck) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := val.check(ctx)
+			report.Checks[i] = CheckReport{
+				Name:      val.name,
+				Status:    StatusOK,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				report.Checks[i].Status = StatusFailed
+				report.Checks[i].Error = err.Error()
+			}
+		}(i, val)
+	}
+	wg.Wait()
+
+	for _, val := range report.Checks {
+		if val.Status != StatusOK {
+			report.Status = StatusNotReady
+		}
+	}
+	if c.shuttingDown.Load() {
+		report.Status = StatusNotReady
+		report.Error = ErrShuttingDown.Error()
+	}
+	return report
+}
+
+// Livez
+// The process is up and serving requests, whatever the state of its dependencies
+// (GET /livez)
+func (c *Checker) Livez(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, map[string]string{"status": StatusOK}, http.StatusOK)
+}
+
+// Readyz
+// The service can handle the traffic (GET /readyz)
+func (c *Checker) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusReady {
+		status = http.StatusServiceUnavailable
+	}
+	sendJSON(w, report, status)
+}
+
+func sendJSON(w http.ResponseWriter, val any, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(val)
+}
+
+// Flag
+// Check of a task done once, e.g. the initial sync of the data
+type Flag struct {
+	done atomic.Bool
+}
+
+// Done
+// Mark the task as completed
+func (f *Flag) Done() {
+	f.done.Store(true)
+}
+
+func (f *Flag) Check(_ context.Context) error {
+	if !f.done.Load() {
+		return ErrNotCompleted
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/helpers/tdhttp"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestChecker(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			synced  = &health.Flag{}
+			failure = errors.New("cache unreachable")
+			checker = health.New(
+				health.WithCheck("datastore", datastore.Ping),
+				health.WithCheck("csv_sync", synced.Check),
+			)
+			r  = mux.NewRouter()
+			ta = tdhttp.NewTestAPI(t, r)
+		)
+		r.HandleFunc("/livez", checker.Livez)
+		r.HandleFunc("/readyz", checker.Readyz)
+
+		ta.Name("live").Get("/livez").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.JSON(`{"status": "ok"}`))
+
+		ta.Name("invalid case -- sync not completed").Get("/readyz").
+			CmpStatus(http.StatusServiceUnavailable).
+			CmpJSONBody(td.JSON(`{
+				"status": "not_ready",
+				"checks": [
+					{"name": "datastore", "status": "ok", "latency_ms": $1},
+					{"name": "csv_sync", "status": "failed", "latency_ms": $1, "error": $2}
+				]
+			}`, td.Gte(0.0), health.ErrNotCompleted.Error()))
+
+		synced.Done()
+		ta.Name("valid case").Get("/readyz").
+			CmpStatus(http.StatusOK).
+			CmpJSONBody(td.SuperJSONOf(`{"status": "ready", "checks": [SuperMapOf({"status": "ok"}), SuperMapOf({"status": "ok"})]}`))
+
+		require.Cmp(health.New(health.WithCheck("cache", func(context.Context) error { return failure })).Ready(ctx), td.Struct(health.Report{
+			Status: health.StatusNotReady,
+		}, td.StructFields{
+			"Checks": []health.CheckReport{{Name: "cache", Status: health.StatusFailed, Error: failure.Error()}},
+		}))
+
+		checker.Shutdown()
+		ta.Name("invalid case -- shutting down").Get("/readyz").
+			CmpStatus(http.StatusServiceUnavailable).
+			CmpJSONBody(td.SuperJSONOf(`{"status": "not_ready", "error": $1}`, health.ErrShuttingDown.Error()))
+		ta.Name("still live while shutting down").Get("/livez").
+			CmpStatus(http.StatusOK)
+	})
+}
@@ -0,0 +1,42 @@
+package httputil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Preference
+// Value listed in a negotiation header such as Accept or Accept-Encoding, with its
+// quality between 0 and 1
+type Preference struct {
+	Value   string
+	Quality float64
+}
+
+// ParsePreferences
+// Parse the comma separated values of a negotiation header. The parameters other
+// than the quality are dropped, the values are lowercased and the invalid qualities
+// are read as 1
+func ParsePreferences(header string) []Preference {
+	var res []Preference
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		pref := Preference{Value: value, Quality: 1}
+		for _, param := range strings.Split(params, ";") {
+			key, raw, ok := strings.Cut(param, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil && q >= 0 && q <= 1 {
+				pref.Quality = q
+			}
+		}
+		res = append(res, pref)
+	}
+	return res
+}
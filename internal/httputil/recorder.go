@@ -0,0 +1,54 @@
+package httputil
+
+import "net/http"
+
+// ResponseRecorder
+// Remember the status and the size of the response sent to the client. Flush is
+// kept for the events stream
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *ResponseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *ResponseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *ResponseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Status
+// Status sent to the client, 200 when the handler only wrote the body
+func (r *ResponseRecorder) Status() int {
+	return r.status
+}
+
+// Bytes
+// Size of the body sent to the client
+func (r *ResponseRecorder) Bytes() int {
+	return r.bytes
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+)
+
+// Every
+// Run fn every interval until ctx is done. A failing run is logged and
+// does not stop the job
+func Every(ctx context.Context, logger *charmLog.Logger, name string, interval time.Duration, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof("Job %s stopped", name)
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				logger.Errorf("Job %s [FAILED]: %s", name, err)
+			}
+		}
+	}
+}
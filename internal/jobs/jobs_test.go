@@ -0,0 +1,42 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/jobs"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestEvery(t *testing.T) {
+	var (
+		require     = td.Require(t)
+		logger      = charmLog.New(io.Discard)
+		ctx, cancel = context.WithCancel(context.Background())
+		runs        atomic.Int32
+		done        = make(chan struct{})
+	)
+
+	go func() {
+		jobs.Every(ctx, logger, "test", time.Millisecond, func(context.Context) error {
+			if runs.Add(1) >= 3 {
+				cancel()
+			}
+			// A failure must not stop the job
+			return errors.New("failure")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job did not stop after its context was cancelled")
+	}
+	require.Gte(runs.Load(), int32(3))
+}
@@ -0,0 +1,233 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRefreshInterval is the minimum time between two fetches of a remote key set
+	DefaultRefreshInterval = time.Minute
+)
+
+var (
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// KeySet
+// Public keys verifying the tokens signatures, by key id
+type KeySet interface {
+	Key(ctx context.Context, kid string) (any, error)
+}
+
+// LoadKeySet
+// Key set of a JWKS document, either read from a file or fetched from an http(s) url
+func LoadKeySet(ctx context.Context, source string) (KeySet, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		res := NewRemoteKeySet(source, http.DefaultClient)
+		if err := res.refresh(ctx); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read jwks file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// StaticKeySet
+// Keys of a JWKS document
+type StaticKeySet struct {
+	keys map[string]any
+}
+
+func (s StaticKeySet) Key(_ context.Context, kid string) (any, error) {
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS
+// Read the RSA, EC and Ed25519 signing keys of a JWKS document. Other keys are ignored
+func ParseJWKS(data []byte) (*StaticKeySet, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot decode jwks: %w", err)
+	}
+
+	res := &StaticKeySet{keys: map[string]any{}}
+	for _, val := range doc.Keys {
+		if val.Use != "" && val.Use != "sig" {
+			continue
+		}
+		key, err := val.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode jwk %s: %w", val.Kid, err)
+		}
+		if key != nil {
+			res.keys[val.Kid] = key
+		}
+	}
+	return res, nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+// RemoteKeySet
+// Keys of a JWKS document served by the identity provider. The document is fetched
+// again when a token is signed by an unknown key, which happens after a rotation,
+// at most once per refresh interval
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	keys      *StaticKeySet
+	fetchedAt time.Time
+}
+
+func NewRemoteKeySet(url string, client *http.Client) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:             url,
+		client:          client,
+		refreshInterval: DefaultRefreshInterval,
+		keys:            &StaticKeySet{},
+	}
+}
+
+func (r *RemoteKeySet) Key(ctx context.Context, kid string) (any, error) {
+	r.mu.Lock()
+	keys, fetchedAt := r.keys, r.fetchedAt
+	r.mu.Unlock()
+
+	key, err := keys.Key(ctx, kid)
+	if err == nil || time.Since(fetchedAt) < r.refreshInterval {
+		return key, err
+	}
+	if err := r.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.keys.Key(ctx, kid)
+}
+
+func (r *RemoteKeySet) refresh(ctx context.Context) error {
+	// Failed fetches count as well, not to flood an unavailable provider
+	r.mu.Lock()
+	r.fetchedAt = time.Now()
+	r.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch jwks: %w", err)
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot fetch jwks: status %d", res.StatusCode)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("cannot fetch jwks: %w", err)
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+	return nil
+}
@@ -0,0 +1,146 @@
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+)
+
+const (
+	DefaultRolesClaim = "roles"
+	DefaultLeeway     = 30 * time.Second
+)
+
+var (
+	ErrMissingKeyID   = errors.New("token has no kid header")
+	ErrMissingSubject = errors.New("token has no sub claim")
+)
+
+// Verifier
+// Validate the bearer tokens issued by the identity provider and turn them into principals
+type Verifier struct {
+	keys       KeySet
+	issuer     string
+	audience   string
+	rolesClaim string
+	leeway     time.Duration
+}
+
+type Option func(*Verifier)
+
+// WithIssuer
+// Only accept the tokens whose iss claim is the given issuer
+func WithIssuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience
+// Only accept the tokens whose aud claim contains the given audience
+func WithAudience(audience string) Option {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// WithRolesClaim
+// Claim holding the roles, either a list or a space separated string. Nested
+// claims are reached with a dotted path, e.g. realm_access.roles
+func WithRolesClaim(claim string) Option {
+	return func(v *Verifier) {
+		v.rolesClaim = claim
+	}
+}
+
+// WithLeeway
+// Clock skew tolerated when checking the token dates
+func WithLeeway(leeway time.Duration) Option {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+func NewVerifier(keys KeySet, opts ...Option) *Verifier {
+	v := &Verifier{
+		keys:       keys,
+		rolesClaim: DefaultRolesClaim,
+		leeway:     DefaultLeeway,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify
+// Check the signature, the dates, the issuer and the audience of a token. The
+// principal is identified by the sub claim and has the known roles of the roles
+// claim, the others are ignored
+func (v Verifier) Verify(ctx context.Context, token string) (auth.Principal, error) {
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+	}
+	if v.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(v.audience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, ErrMissingKeyID
+		}
+		return v.keys.Key(ctx, kid)
+	}, parserOpts...)
+	if err != nil {
+		return auth.Principal{}, domainerror.WrapError(domainerror.ErrUnauthenticated, err)
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return auth.Principal{}, domainerror.WrapError(domainerror.ErrUnauthenticated, ErrMissingSubject)
+	}
+	return auth.NewPrincipal(subject, v.roles(claims)...), nil
+}
+
+func (v Verifier) roles(claims jwt.MapClaims) []auth.Role {
+	var val any = map[string]any(claims)
+	for _, key := range strings.Split(v.rolesClaim, ".") {
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return nil
+		}
+		val = obj[key]
+	}
+
+	var names []string
+	switch val := val.(type) {
+	case string:
+		names = strings.Fields(val)
+	case []any:
+		for _, name := range val {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	res := []auth.Role{}
+	for _, name := range names {
+		if role, err := auth.RoleFromString(name); err == nil {
+			res = append(res, role)
+		}
+	}
+	return res
+}
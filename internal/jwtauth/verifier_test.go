@@ -0,0 +1,103 @@
+package jwtauth_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/jwtauth"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	require := td.Require(t)
+	issuer, err := testutils.NewIssuer()
+	require.CmpNoError(err)
+
+	tests := []struct {
+		name    string
+		opts    []jwtauth.Option
+		claims  jwt.MapClaims
+		want    []auth.Role
+		wantErr error
+	}{
+		{
+			name:   "valid case -- roles list, unknown roles are ignored",
+			claims: jwt.MapClaims{"sub": "alice", "roles": []string{"breeds:write", "offline_access", "webhooks:admin"}},
+			want:   []auth.Role{auth.NewRole(auth.ResourceBreeds, auth.ScopeWrite), auth.NewRole(auth.ResourceWebhooks, auth.ScopeAdmin)},
+		},
+		{
+			name:   "valid case -- nested space separated roles",
+			opts:   []jwtauth.Option{jwtauth.WithRolesClaim("realm_access.scope")},
+			claims: jwt.MapClaims{"sub": "alice", "realm_access": map[string]any{"scope": "breeds:read openid"}},
+			want:   []auth.Role{auth.NewRole(auth.ResourceBreeds, auth.ScopeRead)},
+		},
+		{
+			name:   "valid case -- no roles",
+			claims: jwt.MapClaims{"sub": "alice"},
+			want:   []auth.Role{},
+		},
+		{
+			name:    "invalid case -- no subject",
+			claims:  jwt.MapClaims{"roles": []string{"breeds:read"}},
+			wantErr: domainerror.ErrUnauthenticated,
+		},
+		{
+			name:    "invalid case -- other issuer",
+			opts:    []jwtauth.Option{jwtauth.WithIssuer("https://other.test")},
+			claims:  jwt.MapClaims{"sub": "alice"},
+			wantErr: domainerror.ErrUnauthenticated,
+		},
+		{
+			name:    "invalid case -- no expiration",
+			claims:  jwt.MapClaims{"sub": "alice", "exp": nil},
+			wantErr: domainerror.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{"iss": testutils.IssuerURL, "exp": time.Now().Add(time.Minute).Unix()}
+			for key, val := range tt.claims {
+				if val == nil {
+					delete(claims, key)
+				} else {
+					claims[key] = val
+				}
+			}
+			token, err := issuer.Sign(claims)
+			require.CmpNoError(err)
+
+			res, err := jwtauth.NewVerifier(issuer.KeySet(), tt.opts...).Verify(context.Background(), token)
+			require.CmpErrorIs(err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Cmp(res.ID(), "alice")
+				require.Cmp(res.Roles(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	require := td.Require(t)
+	issuer, err := testutils.NewIssuer()
+	require.CmpNoError(err)
+	srv := httptest.NewServer(issuer)
+	defer srv.Close()
+
+	keys, err := jwtauth.LoadKeySet(context.Background(), srv.URL)
+	require.CmpNoError(err)
+	_, err = keys.Key(context.Background(), testutils.IssuerKeyID)
+	require.CmpNoError(err)
+	// Unknown keys are not fetched again before the refresh interval
+	_, err = keys.Key(context.Background(), "rotated")
+	require.CmpErrorIs(err, jwtauth.ErrUnknownKey)
+
+	_, err = jwtauth.LoadKeySet(context.Background(), "./missing.json")
+	require.CmpError(err)
+}
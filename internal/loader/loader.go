@@ -0,0 +1,132 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultWait     = time.Millisecond
+	DefaultMaxBatch = 100
+)
+
+// BatchFunc
+// Load the values of several keys at once. The keys missing from the result have
+// no value
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader
+// Gather the keys loaded concurrently into batches, to load them with one call of
+// the BatchFunc instead of one call by key. A batch is loaded once it waited for
+// the keys of the other callers, or once it is full. The values are kept for the
+// life of the loader, which is meant to be the one of a request
+type Loader[K comparable, V any] struct {
+	fn       BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	current *batch[K, V]
+	loaded  map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys []K
+	done chan struct{}
+	res  map[K]V
+	err  error
+}
+
+type Option func(*config)
+
+type config struct {
+	wait     time.Duration
+	maxBatch int
+}
+
+// WithWait
+// How long a batch waits for other keys before being loaded
+func WithWait(wait time.Duration) Option {
+	return func(c *config) {
+		c.wait = wait
+	}
+}
+
+// WithMaxBatch
+// Number of keys from which a batch is loaded without waiting
+func WithMaxBatch(size int) Option {
+	return func(c *config) {
+		c.maxBatch = size
+	}
+}
+
+func New[K comparable, V any](fn BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	cfg := config{
+		wait:     DefaultWait,
+		maxBatch: DefaultMaxBatch,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Loader[K, V]{
+		fn:       fn,
+		wait:     cfg.wait,
+		maxBatch: cfg.maxBatch,
+		loaded:   make(map[K]*batch[K, V]),
+	}
+}
+
+// Load
+// Value of the key, loaded along the keys requested meanwhile. ok is false when
+// the key has no value
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (val V, ok bool, err error) {
+	l.mu.Lock()
+	b, found := l.loaded[key]
+	if !found {
+		if l.current == nil {
+			b = &batch[K, V]{done: make(chan struct{})}
+			l.current = b
+			time.AfterFunc(l.wait, func() {
+				l.dispatch(ctx, b)
+			})
+		}
+		b = l.current
+		b.keys = append(b.keys, key)
+		l.loaded[key] = b
+		if len(b.keys) >= l.maxBatch {
+			l.current = nil
+			go l.run(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return val, false, ctx.Err()
+	}
+	if b.err != nil {
+		return val, false, b.err
+	}
+	val, ok = b.res[key]
+	return val, ok, nil
+}
+
+// dispatch
+// Load the batch once it waited, unless it was loaded for being full
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.current != b {
+		l.mu.Unlock()
+		return
+	}
+	l.current = nil
+	l.mu.Unlock()
+	l.run(ctx, b)
+}
+
+func (l *Loader[K, V]) run(ctx context.Context, b *batch[K, V]) {
+	b.res, b.err = l.fn(ctx, b.keys)
+	close(b.done)
+}
@@ -0,0 +1,96 @@
+package loader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/loader"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// recorder
+// BatchFunc doubling the keys, recording the batches it is called with
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (r *recorder) load(_ context.Context, keys []int) (map[int]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, keys)
+	if r.err != nil {
+		return nil, r.err
+	}
+	res := make(map[int]int, len(keys))
+	for _, key := range keys {
+		// Odd keys have no value
+		if key%2 == 0 {
+			res[key] = key * 2
+		}
+	}
+	return res, nil
+}
+
+// loadAll
+// Load the keys concurrently, like the resolvers of an operation
+func loadAll(l *loader.Loader[int, int], keys ...int) []error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(keys))
+	)
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, errs[i] = l.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestLoader_Load(t *testing.T) {
+	var (
+		require = td.Require(t)
+		rec     = &recorder{}
+		l       = loader.New(rec.load, loader.WithWait(10*time.Millisecond))
+	)
+
+	require.Cmp(loadAll(l, 2, 4, 2, 3), []error{nil, nil, nil, nil})
+	require.Cmp(rec.batches, td.Bag(td.Bag(2, 4, 3)), "valid case -- keys are batched and deduplicated")
+
+	val, ok, err := l.Load(context.Background(), 4)
+	require.CmpNoError(err)
+	require.True(ok)
+	require.Cmp(val, 8)
+	_, ok, err = l.Load(context.Background(), 3)
+	require.CmpNoError(err)
+	require.False(ok, "valid case -- missing key")
+	require.Len(rec.batches, 1, "valid case -- loaded keys are cached")
+}
+
+func TestLoader_Load_MaxBatch(t *testing.T) {
+	var (
+		require = td.Require(t)
+		rec     = &recorder{}
+		l       = loader.New(rec.load, loader.WithWait(time.Hour), loader.WithMaxBatch(2))
+	)
+
+	require.Cmp(loadAll(l, 2, 4), []error{nil, nil}, "valid case -- full batch is loaded without waiting")
+	require.Cmp(rec.batches, td.Bag(td.Bag(2, 4)))
+}
+
+func TestLoader_Load_Error(t *testing.T) {
+	var (
+		require = td.Require(t)
+		rec     = &recorder{err: errors.New("boom")}
+		l       = loader.New(rec.load)
+	)
+
+	require.Cmp(loadAll(l, 2, 4), []error{rec.err, rec.err}, "invalid case -- every key of the batch fails")
+}
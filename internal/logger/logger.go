@@ -1,17 +1,93 @@
 package logger
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"time"
 
 	charmLog "github.com/charmbracelet/log"
 )
 
-var Logger = charmLog.NewWithOptions(os.Stderr, charmLog.Options{
-	Formatter:       charmLog.TextFormatter,
-	ReportCaller:    true,
-	ReportTimestamp: true,
-	TimeFormat:      time.Kitchen,
-	Prefix:          "🧑‍💻 backend-test",
-	Level:           charmLog.DebugLevel,
-})
+const (
+	// FormatText is the human readable output, for the development
+	FormatText = "text"
+	// FormatJSON and FormatLogfmt are the structured outputs, for the log collectors
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+var (
+	ErrInvalidFormat = errors.New("log format must be one of the following values: [text, json, logfmt]")
+)
+
+var Logger = charmLog.NewWithOptions(os.Stderr, options(charmLog.TextFormatter, charmLog.DebugLevel))
+
+// New
+// Logger writing to w in the given format, from the given level. Empty values keep
+// the defaults of Logger
+func New(w io.Writer, format string, level string) (*charmLog.Logger, error) {
+	var formatter charmLog.Formatter
+	switch format {
+	case "", FormatText:
+		formatter = charmLog.TextFormatter
+	case FormatJSON:
+		formatter = charmLog.JSONFormatter
+	case FormatLogfmt:
+		formatter = charmLog.LogfmtFormatter
+	default:
+		return nil, ErrInvalidFormat
+	}
+
+	lvl := charmLog.DebugLevel
+	if level != "" {
+		var err error
+		if lvl, err = charmLog.ParseLevel(level); err != nil {
+			return nil, fmt.Errorf("invalid log level %s: %w", level, err)
+		}
+	}
+	return charmLog.NewWithOptions(w, options(formatter, lvl)), nil
+}
+
+// options
+// The structured outputs have precise timestamps and no prefix
+func options(formatter charmLog.Formatter, level charmLog.Level) charmLog.Options {
+	if formatter != charmLog.TextFormatter {
+		return charmLog.Options{
+			Formatter:       formatter,
+			ReportCaller:    true,
+			ReportTimestamp: true,
+			TimeFormat:      time.RFC3339Nano,
+			Level:           level,
+		}
+	}
+	return charmLog.Options{
+		Formatter:       formatter,
+		ReportCaller:    true,
+		ReportTimestamp: true,
+		TimeFormat:      time.Kitchen,
+		Prefix:          "🧑‍💻 backend-test",
+		Level:           level,
+	}
+}
+
+type key int
+
+const loggerKey key = iota
+
+// WithContext
+// Store the logger of everything done in the context, e.g. with the request id
+func WithContext(ctx context.Context, logger *charmLog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext
+// Logger stored in the context, Logger when there is none
+func FromContext(ctx context.Context) *charmLog.Logger {
+	if val, ok := ctx.Value(loggerKey).(*charmLog.Logger); ok {
+		return val
+	}
+	return Logger
+}
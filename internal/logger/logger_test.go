@@ -0,0 +1,45 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestNew(t *testing.T) {
+	require := td.Require(t)
+
+	var buf bytes.Buffer
+	log, err := logger.New(&buf, logger.FormatJSON, "info")
+	require.CmpNoError(err)
+	log.Debug("hidden")
+	log.Info("shown", "request_id", "first")
+	var line map[string]any
+	require.CmpNoError(json.Unmarshal(buf.Bytes(), &line))
+	require.Cmp(line, td.SuperMapOf(map[string]any{"level": "info", "msg": "shown", "request_id": "first", "time": td.NotEmpty()}, nil))
+
+	buf.Reset()
+	log, err = logger.New(&buf, logger.FormatLogfmt, "")
+	require.CmpNoError(err)
+	log.Debug("shown", "request_id", "first")
+	require.Cmp(buf.String(), td.Re(`level=debug .*msg=shown request_id=first`))
+
+	_, err = logger.New(&buf, "xml", "")
+	require.CmpErrorIs(err, logger.ErrInvalidFormat)
+	_, err = logger.New(&buf, logger.FormatJSON, "verbose")
+	require.CmpError(err)
+}
+
+func TestFromContext(t *testing.T) {
+	require := td.Require(t)
+
+	require.Shallow(logger.FromContext(context.Background()), logger.Logger)
+
+	log, err := logger.New(&bytes.Buffer{}, logger.FormatJSON, "")
+	require.CmpNoError(err)
+	require.Shallow(logger.FromContext(logger.WithContext(context.Background(), log)), log)
+}
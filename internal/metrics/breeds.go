@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// BreedsRepository labels the queries of the breeds repository
+	BreedsRepository = "breeds"
+	// SpeciesScrapeTimeout bounds the count of the breeds done on each scrape
+	SpeciesScrapeTimeout = 5 * time.Second
+)
+
+// Datastore
+// Instrument the repositories of a datastore
+type Datastore struct {
+	gateways.IDatastore
+	breeds breeds.Repository
+}
+
+func (m *Metrics) Datastore(datastore gateways.IDatastore) Datastore {
+	return Datastore{
+		IDatastore: datastore,
+		breeds:     m.BreedRepository(datastore.Breeds()),
+	}
+}
+
+func (d Datastore) Breeds() breeds.Repository {
+	return d.breeds
+}
+
+// BreedRepository
+// Observe the duration of every query of the repository
+func (m *Metrics) BreedRepository(repository breeds.Repository) breeds.Repository {
+	return breedRepository{
+		next:    repository,
+		observe: m.observer(BreedsRepository),
+	}
+}
+
+func (m *Metrics) observer(repository string) func(method string, start time.Time, err error) {
+	return func(method string, start time.Time, err error) {
+		m.queryDuration.WithLabelValues(repository, method, Outcome(err)).Observe(time.Since(start).Seconds())
+	}
+}
+
+type breedRepository struct {
+	next    breeds.Repository
+	observe func(method string, start time.Time, err error)
+}
+
+func (b breedRepository) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.GetOneByName(ctx, name)
+	b.observe("GetOneByName", start, err)
+	return res, err
+}
+
+func (b breedRepository) List(ctx context.Context, opts breeds.ListOpts) ([]*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.List(ctx, opts)
+	b.observe("List", start, err)
+	return res, err
+}
+
+func (b breedRepository) CreateOne(ctx context.Context, breed *breeds.Breed) (*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.CreateOne(ctx, breed)
+	b.observe("CreateOne", start, err)
+	return res, err
+}
+
+func (b breedRepository) UpdateOne(ctx context.Context, breed *breeds.Breed) (*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.UpdateOne(ctx, breed)
+	b.observe("UpdateOne", start, err)
+	return res, err
+}
+
+func (b breedRepository) DeleteOneByName(ctx context.Context, name values.BreedName) error {
+	start := time.Now()
+	err := b.next.DeleteOneByName(ctx, name)
+	b.observe("DeleteOneByName", start, err)
+	return err
+}
+
+func (b breedRepository) RestoreOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.RestoreOneByName(ctx, name)
+	b.observe("RestoreOneByName", start, err)
+	return res, err
+}
+
+func (b breedRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+	res, err := b.next.PurgeDeleted(ctx, before)
+	b.observe("PurgeDeleted", start, err)
+	return res, err
+}
+
+func (b breedRepository) ListVersions(ctx context.Context, name values.BreedName) ([]*breeds.Version, error) {
+	start := time.Now()
+	res, err := b.next.ListVersions(ctx, name)
+	b.observe("ListVersions", start, err)
+	return res, err
+}
+
+func (b breedRepository) CreateSeveral(ctx context.Context, arr []*breeds.Breed) ([]*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.CreateSeveral(ctx, arr)
+	b.observe("CreateSeveral", start, err)
+	return res, err
+}
+
+func (b breedRepository) CountBySpecies(ctx context.Context) (map[values.Species]int, error) {
+	start := time.Now()
+	res, err := b.next.CountBySpecies(ctx)
+	b.observe("CountBySpecies", start, err)
+	return res, err
+}
+
+func (b breedRepository) AsOf(at time.Time) breeds.Reader {
+	return breedReader{next: b.next.AsOf(at), observe: b.observe}
+}
+
+// breedReader
+// Observe the queries on a past state of the catalog, prefixed by AsOf
+type breedReader struct {
+	next    breeds.Reader
+	observe func(method string, start time.Time, err error)
+}
+
+func (b breedReader) GetOneByName(ctx context.Context, name values.BreedName) (*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.GetOneByName(ctx, name)
+	b.observe("AsOf.GetOneByName", start, err)
+	return res, err
+}
+
+func (b breedReader) List(ctx context.Context, opts breeds.ListOpts) ([]*breeds.Breed, error) {
+	start := time.Now()
+	res, err := b.next.List(ctx, opts)
+	b.observe("AsOf.List", start, err)
+	return res, err
+}
+
+// SpeciesCollector
+// Gauge of the breeds which are not deleted by species, counted on each scrape
+type SpeciesCollector struct {
+	repository breeds.Repository
+	desc       *prometheus.Desc
+}
+
+func NewSpeciesCollector(repository breeds.Repository) SpeciesCollector {
+	return SpeciesCollector{
+		repository: repository,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "breeds", "by_species"),
+			"Number of breeds which are not deleted by species.",
+			[]string{"species"}, nil,
+		),
+	}
+}
+
+func (s SpeciesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.desc
+}
+
+func (s SpeciesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), SpeciesScrapeTimeout)
+	defer cancel()
+
+	counts, err := s.repository.CountBySpecies(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(s.desc, err)
+		return
+	}
+	for _, species := range []values.Species{values.Cat, values.Dog} {
+		ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, float64(counts[species]), species.String())
+	}
+}
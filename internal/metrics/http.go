@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/httputil"
+)
+
+// UnmatchedRoute labels the requests which did not match any route, to bound the cardinality
+const UnmatchedRoute = "unmatched"
+
+// Middleware
+// Count the requests and observe their latency by route template, method and status.
+// It must be used on the router, so that the matched route is known
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start    = time.Now()
+			recorder = httputil.NewResponseRecorder(w)
+		)
+		next.ServeHTTP(recorder, r)
+
+		route := UnmatchedRoute
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+		status := strconv.Itoa(recorder.Status())
+		m.httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		m.httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,137 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/metrics"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	require := td.Require(t)
+	var (
+		m = metrics.New()
+		r = mux.NewRouter()
+	)
+	r.Use(m.Middleware)
+	r.HandleFunc("/v1/breeds/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["name"] == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/v1/breeds/bengal", "/v1/breeds/beagle", "/v1/breeds/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.CmpNoError(testutil.GatherAndCompare(m, strings.NewReader(`
+# HELP backend_http_requests_total Number of HTTP requests by route, method and status.
+# TYPE backend_http_requests_total counter
+backend_http_requests_total{method="GET",route="/v1/breeds/{name}",status="200"} 2
+backend_http_requests_total{method="GET",route="/v1/breeds/{name}",status="404"} 1
+`), "backend_http_requests_total"))
+	count, err := testutil.GatherAndCount(m, "backend_http_request_duration_seconds")
+	require.CmpNoError(err)
+	require.Cmp(count, 2)
+}
+
+func TestMetrics_Interceptor(t *testing.T) {
+	require := td.Require(t)
+	var (
+		m       = metrics.New()
+		info    = usecases.UseCaseInfo{Action: usecases.ActionRetrieve, Name: usecases.BreedUsecase}
+		handler = m.Interceptor()(func(_ context.Context, call usecases.Call) (any, error) {
+			if call.Input == "" {
+				return nil, domainerror.ErrResourceNotFound
+			}
+			return call.Input, nil
+		})
+	)
+
+	_, err := handler(context.Background(), usecases.Call{Info: info, Input: "bengal"})
+	require.CmpNoError(err)
+	_, err = handler(context.Background(), usecases.Call{Info: info, Input: ""})
+	require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+
+	require.CmpNoError(testutil.GatherAndCompare(m, strings.NewReader(`
+# HELP backend_usecase_executions_total Number of usecase executions by usecase, action and outcome.
+# TYPE backend_usecase_executions_total counter
+backend_usecase_executions_total{action="retrieve",outcome="not_found",usecase="breed"} 1
+backend_usecase_executions_total{action="retrieve",outcome="success",usecase="breed"} 1
+`), "backend_usecase_executions_total"))
+}
+
+func TestOutcome(t *testing.T) {
+	require := td.Require(t)
+
+	require.Cmp(metrics.Outcome(nil), metrics.OutcomeSuccess)
+	require.Cmp(metrics.Outcome(domainerror.WrapError(domainerror.ErrDomainValidation, values.ErrInvalidSpecies)), metrics.OutcomeInvalid)
+	require.Cmp(metrics.Outcome(domainerror.ErrForbidden), metrics.OutcomeDenied)
+	require.Cmp(metrics.Outcome(context.DeadlineExceeded), metrics.OutcomeCanceled)
+	require.Cmp(metrics.Outcome(domainerror.ErrInternalError), metrics.OutcomeError)
+}
+
+func TestMetrics_Datastore(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			m     = metrics.New()
+			store = m.Datastore(datastore)
+		)
+		require.CmpNoError(m.Register(metrics.NewSpeciesCollector(store.Breeds())))
+
+		b, err := breeds.NewFactory(breeds.FactoryOpts{
+			Name:    "bengal",
+			Species: values.Cat.String(),
+			PetSize: values.Small.String(),
+		}).Instantiate()
+		require.CmpNoError(err)
+		_, err = store.Breeds().CreateOne(ctx, b)
+		require.CmpNoError(err)
+		_, err = store.Breeds().GetOneByName(ctx, values.BreedName("unknown"))
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+
+		require.CmpNoError(testutil.GatherAndCompare(m, strings.NewReader(`
+# HELP backend_breeds_by_species Number of breeds which are not deleted by species.
+# TYPE backend_breeds_by_species gauge
+backend_breeds_by_species{species="cat"} 1
+backend_breeds_by_species{species="dog"} 0
+`), "backend_breeds_by_species"))
+
+		families, err := m.Gather()
+		require.CmpNoError(err)
+		var labels []string
+		for _, family := range families {
+			if family.GetName() != "backend_repository_query_duration_seconds" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				var method, outcome string
+				for _, label := range metric.GetLabel() {
+					switch label.GetName() {
+					case "method":
+						method = label.GetValue()
+					case "outcome":
+						outcome = label.GetValue()
+					}
+				}
+				labels = append(labels, method+" "+outcome)
+			}
+		}
+		require.Cmp(labels, td.Bag("CountBySpecies success", "CreateOne success", "GetOneByName not_found"))
+	})
+}
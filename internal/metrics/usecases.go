@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+const (
+	OutcomeSuccess       = "success"
+	OutcomeInvalid       = "invalid"
+	OutcomeNotFound      = "not_found"
+	OutcomeAlreadyExists = "already_exists"
+	OutcomeNothingTodo   = "nothing_todo"
+	OutcomeDenied        = "denied"
+	OutcomeCanceled      = "canceled"
+	OutcomeError         = "error"
+)
+
+// Outcome
+// Label an error by its kind rather than its message, to bound the cardinality
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, domainerror.ErrDomainValidation):
+		return OutcomeInvalid
+	case errors.Is(err, domainerror.ErrResourceNotFound):
+		return OutcomeNotFound
+	case errors.Is(err, domainerror.ErrResourceAlreadyExists):
+		return OutcomeAlreadyExists
+	case errors.Is(err, domainerror.ErrNothingTodo):
+		return OutcomeNothingTodo
+	case errors.Is(err, domainerror.ErrUnauthenticated), errors.Is(err, domainerror.ErrForbidden):
+		return OutcomeDenied
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return OutcomeCanceled
+	default:
+		return OutcomeError
+	}
+}
+
+// Interceptor
+// Count the usecase executions and observe their duration by usecase, action and outcome
+func (m *Metrics) Interceptor() usecases.Interceptor {
+	return func(next usecases.Handler) usecases.Handler {
+		return func(ctx context.Context, call usecases.Call) (any, error) {
+			start := time.Now()
+			res, err := next(ctx, call)
+
+			outcome := Outcome(err)
+			m.usecaseExecutions.WithLabelValues(call.Info.Name.String(), call.Info.Action.String(), outcome).Inc()
+			m.usecaseDuration.WithLabelValues(call.Info.Name.String(), call.Info.Action.String(), outcome).Observe(time.Since(start).Seconds())
+			return res, err
+		}
+	}
+}
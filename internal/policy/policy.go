@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"gopkg.in/yaml.v3"
+)
+
+// Engine
+// usecases.Policy denying the requests matched by one of the rules of a policy file
+type Engine struct {
+	rules []rule
+}
+
+// Load
+// Engine with the rules of a policy file
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read policy file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse
+// Engine with the rules of a yaml policy document:
+//
+//	rules:
+//	  - name: operators may edit cats only
+//	    usecases: [breed]
+//	    actions: [create, update]
+//	    roles: [breeds:write]
+//	    without_roles: [breeds:admin]
+//	    input:
+//	      species:
+//	        not_in: [cat]
+//	  - name: no deletes outside business hours
+//	    usecases: [breed]
+//	    actions: [delete]
+//	    outside:
+//	      days: [monday, tuesday, wednesday, thursday, friday]
+//	      from: "09:00"
+//	      to: "18:00"
+//	      location: Europe/Paris
+//
+// A request is denied by a rule when it matches all of its conditions, the
+// omitted ones matching every request
+func Parse(data []byte) (*Engine, error) {
+	var doc struct {
+		Rules []Rule `yaml:"rules"`
+	}
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("cannot decode policy file: %w", err)
+	}
+
+	res := &Engine{}
+	for i, val := range doc.Rules {
+		r, err := val.compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy rule %d: %w", i+1, err)
+		}
+		res.rules = append(res.rules, r)
+	}
+	return res, nil
+}
+
+func (e Engine) Evaluate(_ context.Context, req usecases.PolicyRequest) error {
+	for _, val := range e.rules {
+		if val.matches(req) {
+			return domainerror.WrapError(domainerror.ErrForbidden, fmt.Errorf("%s denied by the policy %q", req.Info, val.name))
+		}
+	}
+	return nil
+}
+
+type rule struct {
+	name         string
+	usecases     []string
+	actions      []string
+	roles        []auth.Role
+	withoutRoles []auth.Role
+	input        map[string]Condition
+	outside      *hours
+}
+
+func (r rule) matches(req usecases.PolicyRequest) bool {
+	if len(r.usecases) > 0 && !slices.Contains(r.usecases, req.Info.Name.String()) {
+		return false
+	}
+	if len(r.actions) > 0 && !slices.Contains(r.actions, req.Info.Action.String()) {
+		return false
+	}
+	if len(r.roles) > 0 && !slices.ContainsFunc(r.roles, req.Principal.HasRole) {
+		return false
+	}
+	if slices.ContainsFunc(r.withoutRoles, req.Principal.HasRole) {
+		return false
+	}
+	if len(r.input) > 0 {
+		attributes := inputAttributes(req.Input)
+		for key, cond := range r.input {
+			if !cond.matches(attributes[key]) {
+				return false
+			}
+		}
+	}
+	if r.outside != nil && r.outside.contains(req.At) {
+		return false
+	}
+	return true
+}
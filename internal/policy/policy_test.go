@@ -0,0 +1,127 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/policy"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestEngine_Evaluate(t *testing.T) {
+	require := td.Require(t)
+	engine, err := policy.Load("../../policy.example.yaml")
+	require.CmpNoError(err)
+
+	var (
+		operator = auth.NewPrincipal("operator", auth.NewRole(auth.ResourceBreeds, auth.ScopeWrite))
+		admin    = auth.NewPrincipal("admin", auth.NewRole(auth.ResourceBreeds, auth.ScopeAdmin))
+		paris, _ = time.LoadLocation("Europe/Paris")
+		// Wednesday
+		opened = time.Date(2024, 6, 12, 10, 0, 0, 0, paris)
+		closed = time.Date(2024, 6, 12, 20, 0, 0, 0, paris)
+		sunday = time.Date(2024, 6, 16, 10, 0, 0, 0, paris)
+		create = usecases.UseCaseInfo{Action: usecases.ActionCreate, Name: usecases.BreedUsecase}
+		remove = usecases.UseCaseInfo{Action: usecases.ActionDelete, Name: usecases.BreedUsecase}
+		list   = usecases.UseCaseInfo{Action: usecases.ActionList, Name: usecases.BreedUsecase}
+	)
+
+	tests := []struct {
+		name    string
+		req     usecases.PolicyRequest
+		wantErr error
+	}{
+		{
+			name: "valid case -- operator edits a cat",
+			req:  usecases.PolicyRequest{Principal: operator, Info: create, Input: breeds.FactoryOpts{Name: "bengal", Species: "CAT"}, At: opened},
+		},
+		{
+			name:    "invalid case -- operator edits a dog",
+			req:     usecases.PolicyRequest{Principal: operator, Info: create, Input: breeds.FactoryOpts{Name: "beagle", Species: "dog"}, At: opened},
+			wantErr: domainerror.ErrForbidden,
+		},
+		{
+			name: "valid case -- admin edits a dog",
+			req:  usecases.PolicyRequest{Principal: admin, Info: create, Input: &breeds.FactoryOpts{Name: "beagle", Species: "dog"}, At: opened},
+		},
+		{
+			name: "valid case -- operator lists dogs",
+			req:  usecases.PolicyRequest{Principal: operator, Info: list, Input: breedsUsecase.ListOpts{Species: common.ToPointer("dog")}, At: closed},
+		},
+		{
+			name: "valid case -- delete during business hours",
+			req:  usecases.PolicyRequest{Principal: admin, Info: remove, Input: "beagle", At: opened},
+		},
+		{
+			name:    "invalid case -- delete in the evening",
+			req:     usecases.PolicyRequest{Principal: admin, Info: remove, Input: "beagle", At: closed},
+			wantErr: domainerror.ErrForbidden,
+		},
+		{
+			name:    "invalid case -- delete on sunday",
+			req:     usecases.PolicyRequest{Principal: admin, Info: remove, Input: "beagle", At: sunday},
+			wantErr: domainerror.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.CmpErrorIs(engine.Evaluate(context.Background(), tt.req), tt.wantErr)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         string
+		errContains string
+	}{
+		{
+			name:        "invalid case -- unknown field",
+			doc:         "rules:\n  - name: deny\n    effect: deny\n",
+			errContains: "field effect not found",
+		},
+		{
+			name:        "invalid case -- missing name",
+			doc:         "rules:\n  - actions: [delete]\n",
+			errContains: policy.ErrMissingName.Error(),
+		},
+		{
+			name:        "invalid case -- unknown action",
+			doc:         "rules:\n  - name: deny\n    actions: [remove]\n",
+			errContains: policy.ErrUnknownAction.Error(),
+		},
+		{
+			name:        "invalid case -- invalid role",
+			doc:         "rules:\n  - name: deny\n    roles: [operator]\n",
+			errContains: auth.ErrInvalidRole.Error(),
+		},
+		{
+			name:        "invalid case -- invalid hours",
+			doc:         "rules:\n  - name: deny\n    outside: {from: \"18:00\", to: \"09:00\"}\n",
+			errContains: policy.ErrInvalidHours.Error(),
+		},
+		{
+			name:        "invalid case -- empty input condition",
+			doc:         "rules:\n  - name: deny\n    input: {species: {}}\n",
+			errContains: policy.ErrEmptyCondition.Error(),
+		},
+	}
+
+	require := td.Require(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policy.Parse([]byte(tt.doc))
+			require.CmpError(err)
+			require.Contains(err.Error(), tt.errContains)
+		})
+	}
+}
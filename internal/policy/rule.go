@@ -0,0 +1,231 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+var (
+	ErrMissingName     = errors.New("rule must have a name")
+	ErrUnknownUsecase  = errors.New("unknown usecase")
+	ErrUnknownAction   = errors.New("unknown action")
+	ErrInvalidHours    = errors.New("hours must be formatted as HH:MM, from before to")
+	ErrInvalidWeekday  = errors.New("unknown day")
+	ErrEmptyCondition  = errors.New("input condition must have in or not_in values")
+	ErrInvalidLocation = errors.New("unknown location")
+)
+
+// Rule
+// Rule of a policy file, see Parse
+type Rule struct {
+	Name         string               `yaml:"name"`
+	Usecases     []string             `yaml:"usecases"`
+	Actions      []string             `yaml:"actions"`
+	Roles        []string             `yaml:"roles"`
+	WithoutRoles []string             `yaml:"without_roles"`
+	Input        map[string]Condition `yaml:"input"`
+	Outside      *Hours               `yaml:"outside"`
+}
+
+// Condition
+// Match an input field whose value is among In, or not among NotIn. The values
+// are compared case insensitively, a missing field has an empty value
+type Condition struct {
+	In    []string `yaml:"in"`
+	NotIn []string `yaml:"not_in"`
+}
+
+// Hours
+// Opening hours, From included and To excluded, on the given days (every day when empty)
+type Hours struct {
+	Days     []string `yaml:"days"`
+	From     string   `yaml:"from"`
+	To       string   `yaml:"to"`
+	Location string   `yaml:"location"`
+}
+
+func (r Rule) compile() (rule, error) {
+	res := rule{
+		name:  r.Name,
+		input: map[string]Condition{},
+	}
+	if r.Name == "" {
+		return res, ErrMissingName
+	}
+
+	for _, val := range r.Usecases {
+		if !slices.Contains(usecaseNames(), val) {
+			return res, fmt.Errorf("%w %s", ErrUnknownUsecase, val)
+		}
+		res.usecases = append(res.usecases, val)
+	}
+	for _, val := range r.Actions {
+		if !slices.Contains(actionNames(), val) {
+			return res, fmt.Errorf("%w %s", ErrUnknownAction, val)
+		}
+		res.actions = append(res.actions, val)
+	}
+
+	var err error
+	if res.roles, err = parseRoles(r.Roles); err != nil {
+		return res, err
+	}
+	if res.withoutRoles, err = parseRoles(r.WithoutRoles); err != nil {
+		return res, err
+	}
+
+	for key, val := range r.Input {
+		if len(val.In) == 0 && len(val.NotIn) == 0 {
+			return res, fmt.Errorf("%w (%s)", ErrEmptyCondition, key)
+		}
+		res.input[strings.ToLower(key)] = val
+	}
+
+	if r.Outside != nil {
+		if res.outside, err = r.Outside.compile(); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func parseRoles(arr []string) ([]auth.Role, error) {
+	res := []auth.Role{}
+	for _, val := range arr {
+		role, err := auth.RoleFromString(val)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, role)
+	}
+	return res, nil
+}
+
+func usecaseNames() []string {
+	res := []string{}
+	for val := usecases.BreedUsecase; val.String() != ""; val++ {
+		res = append(res, val.String())
+	}
+	return res
+}
+
+func actionNames() []string {
+	res := []string{}
+	for val := usecases.ActionCreate; val.String() != ""; val++ {
+		res = append(res, val.String())
+	}
+	return res
+}
+
+func (c Condition) matches(value string) bool {
+	contains := func(arr []string) bool {
+		return slices.ContainsFunc(arr, func(val string) bool { return strings.EqualFold(val, value) })
+	}
+	if len(c.In) > 0 && !contains(c.In) {
+		return false
+	}
+	return !contains(c.NotIn)
+}
+
+// inputAttributes
+// Values of the exported fields of a struct input by lowercased name. Other
+// inputs, like the name of a breed, are found under "value"
+func inputAttributes(input any) map[string]string {
+	res := map[string]string{}
+	val := reflect.ValueOf(input)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return res
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		res["value"] = fmt.Sprint(val.Interface())
+		return res
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := val.Field(i)
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Pointer {
+			continue
+		}
+		res[strings.ToLower(field.Name)] = fmt.Sprint(value.Interface())
+	}
+	return res
+}
+
+type hours struct {
+	days     []time.Weekday
+	from     time.Duration
+	to       time.Duration
+	location *time.Location
+}
+
+func (h Hours) compile() (*hours, error) {
+	res := &hours{location: time.UTC}
+	if h.Location != "" {
+		loc, err := time.LoadLocation(h.Location)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s", ErrInvalidLocation, h.Location)
+		}
+		res.location = loc
+	}
+
+	for _, val := range h.Days {
+		day, ok := parseWeekday(val)
+		if !ok {
+			return nil, fmt.Errorf("%w %s", ErrInvalidWeekday, val)
+		}
+		res.days = append(res.days, day)
+	}
+
+	from, err := time.Parse("15:04", h.From)
+	if err != nil {
+		return nil, ErrInvalidHours
+	}
+	to, err := time.Parse("15:04", h.To)
+	if err != nil {
+		return nil, ErrInvalidHours
+	}
+	res.from = time.Duration(from.Hour())*time.Hour + time.Duration(from.Minute())*time.Minute
+	res.to = time.Duration(to.Hour())*time.Hour + time.Duration(to.Minute())*time.Minute
+	if res.from >= res.to {
+		return nil, ErrInvalidHours
+	}
+	return res, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// contains
+// Tell whether a time is within the hours
+func (h hours) contains(at time.Time) bool {
+	at = at.In(h.location)
+	if len(h.days) > 0 && !slices.Contains(h.days, at.Weekday()) {
+		return false
+	}
+	elapsed := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute + time.Duration(at.Second())*time.Second
+	return elapsed >= h.from && elapsed < h.to
+}
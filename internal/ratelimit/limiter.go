@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Class
+// Kind of operations sharing a budget
+type Class int
+
+const (
+	Read Class = iota
+	Write
+)
+
+func (c Class) String() string {
+	switch c {
+	case Read:
+		return "read"
+	case Write:
+		return "write"
+	default:
+		return ""
+	}
+}
+
+// Limiter
+// Limit the requests of each client with distinct budgets for the reads and the writes
+type Limiter struct {
+	backend Backend
+	budgets map[Class]Budget
+	now     func() time.Time
+}
+
+// Option
+// Configure the limiter
+type Option func(*Limiter)
+
+// WithBackend
+// Store the buckets in the given backend instead of the memory of the process
+func WithBackend(backend Backend) Option {
+	return func(l *Limiter) {
+		l.backend = backend
+	}
+}
+
+// WithClock
+// Read the time from the given function instead of time.Now
+func WithClock(now func() time.Time) Option {
+	return func(l *Limiter) {
+		l.now = now
+	}
+}
+
+func New(read Budget, write Budget, opts ...Option) *Limiter {
+	l := &Limiter{
+		backend: NewMemoryBackend(),
+		budgets: map[Class]Budget{Read: read, Write: write},
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Budget
+// Budget of the class of operations
+func (l *Limiter) Budget(class Class) Budget {
+	return l.budgets[class]
+}
+
+// Take
+// Take a token from the bucket of the client for the class of operations
+func (l *Limiter) Take(ctx context.Context, client string, class Class) (Result, error) {
+	return l.backend.Take(ctx, class.String()+":"+client, l.budgets[class], l.now())
+}
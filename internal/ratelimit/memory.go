@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultPruneInterval is how often the full buckets are removed from the memory
+const DefaultPruneInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	fullAt time.Time
+}
+
+// MemoryBackend
+// Token buckets of the process. A full bucket being the same as a missing one,
+// the full buckets are pruned to bound the memory
+type MemoryBackend struct {
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	lastPruned time.Time
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		buckets: map[string]*bucket{},
+	}
+}
+
+func (m *MemoryBackend) Take(_ context.Context, key string, budget Budget, now time.Time) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.Sub(m.lastPruned) >= DefaultPruneInterval {
+		for k, val := range m.buckets {
+			if !now.Before(val.fullAt) {
+				delete(m.buckets, k)
+			}
+		}
+		m.lastPruned = now
+	}
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Limit), last: now}
+		m.buckets[key] = b
+	}
+	tokens, res := take(b.tokens, b.last, budget, now)
+	b.tokens = tokens
+	b.last = now
+	b.fullAt = now.Add(res.Reset)
+	return res, nil
+}
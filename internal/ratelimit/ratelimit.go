@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidBudget = errors.New("budget must be formatted as <limit>/<period>, e.g. 100/1m")
+)
+
+// Budget
+// Number of requests allowed over a period. The bucket holds Limit tokens and is
+// refilled at Limit per Period, so bursts up to Limit are allowed
+type Budget struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseBudget
+// Parse a budget formatted as <limit>/<period>, e.g. 100/1m
+func ParseBudget(s string) (Budget, error) {
+	rawLimit, rawPeriod, ok := strings.Cut(s, "/")
+	if !ok {
+		return Budget{}, ErrInvalidBudget
+	}
+	limit, err := strconv.Atoi(rawLimit)
+	if err != nil || limit <= 0 {
+		return Budget{}, ErrInvalidBudget
+	}
+	period, err := time.ParseDuration(rawPeriod)
+	if err != nil || period <= 0 {
+		return Budget{}, ErrInvalidBudget
+	}
+	return Budget{Limit: limit, Period: period}, nil
+}
+
+func (b Budget) String() string {
+	return fmt.Sprintf("%d;w=%d", b.Limit, int(b.Period.Seconds()))
+}
+
+// rate
+// Tokens added to the bucket per second
+func (b Budget) rate() float64 {
+	return float64(b.Limit) / b.Period.Seconds()
+}
+
+// Result
+// Outcome of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until a token is available, zero when allowed
+	RetryAfter time.Duration
+}
+
+// Backend
+// Store of the token buckets. A backend shared by the replicas of the service,
+// e.g. on Redis, makes them share their budgets
+type Backend interface {
+	Take(ctx context.Context, key string, budget Budget, now time.Time) (Result, error)
+}
+
+// take
+// Take a token from a bucket holding tokens at last, and return the tokens left
+func take(tokens float64, last time.Time, budget Budget, now time.Time) (float64, Result) {
+	rate := budget.rate()
+	tokens = math.Min(float64(budget.Limit), tokens+now.Sub(last).Seconds()*rate)
+
+	res := Result{Limit: budget.Limit}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	res.Reset = secondsToDuration((float64(budget.Limit) - tokens) / rate)
+	return tokens, res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
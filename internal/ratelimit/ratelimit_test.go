@@ -0,0 +1,64 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestParseBudget(t *testing.T) {
+	require := td.Require(t)
+
+	budget, err := ratelimit.ParseBudget("100/1m")
+	require.CmpNoError(err)
+	require.Cmp(budget, ratelimit.Budget{Limit: 100, Period: time.Minute})
+	require.Cmp(budget.String(), "100;w=60")
+
+	for _, val := range []string{"", "100", "0/1m", "abc/1m", "100/abc", "100/-1s"} {
+		_, err := ratelimit.ParseBudget(val)
+		require.CmpErrorIs(err, ratelimit.ErrInvalidBudget, val)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	require := td.Require(t)
+	var (
+		ctx     = context.Background()
+		now     = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		limiter = ratelimit.New(
+			ratelimit.Budget{Limit: 2, Period: time.Minute},
+			ratelimit.Budget{Limit: 1, Period: time.Minute},
+			ratelimit.WithClock(func() time.Time { return now }),
+		)
+	)
+
+	res, err := limiter.Take(ctx, "first", ratelimit.Read)
+	require.CmpNoError(err)
+	require.Cmp(res, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 30 * time.Second})
+	res, err = limiter.Take(ctx, "first", ratelimit.Read)
+	require.CmpNoError(err)
+	require.Cmp(res, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute})
+	res, err = limiter.Take(ctx, "first", ratelimit.Read)
+	require.CmpNoError(err)
+	require.Cmp(res, ratelimit.Result{Allowed: false, Limit: 2, Remaining: 0, Reset: time.Minute, RetryAfter: 30 * time.Second})
+
+	// The budgets are distinct by class and by client
+	res, err = limiter.Take(ctx, "first", ratelimit.Write)
+	require.CmpNoError(err)
+	require.True(res.Allowed)
+	res, err = limiter.Take(ctx, "second", ratelimit.Read)
+	require.CmpNoError(err)
+	require.True(res.Allowed)
+
+	// The bucket is refilled over time
+	now = now.Add(30 * time.Second)
+	res, err = limiter.Take(ctx, "first", ratelimit.Read)
+	require.CmpNoError(err)
+	require.Cmp(res, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute})
+	res, err = limiter.Take(ctx, "first", ratelimit.Write)
+	require.CmpNoError(err)
+	require.Cmp(res, ratelimit.Result{Allowed: false, Limit: 1, Remaining: 0, Reset: 30 * time.Second, RetryAfter: 30 * time.Second})
+}
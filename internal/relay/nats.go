@@ -0,0 +1,133 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+)
+
+const (
+	natsTimeout = 5 * time.Second
+	natsConnect = `CONNECT {"verbose":false,"pedantic":false,"name":"backend-test"}`
+)
+
+// NATSSink
+// Publish the messages on a NATS compatible server using the core text protocol.
+// Every message goes on <subject>.<event name> and is followed by a PING, the
+// PONG answer telling the server processed it
+type NATSSink struct {
+	mu      sync.Mutex
+	addr    string
+	subject string
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+func NewNATSSink(addr, subject string) *NATSSink {
+	return &NATSSink{
+		addr:    addr,
+		subject: subject,
+	}
+}
+
+func (s *NATSSink) Send(ctx context.Context, msg *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.send(ctx, msg); err != nil {
+		s.reset()
+		return err
+	}
+	return nil
+}
+
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+	return nil
+}
+
+func (s *NATSSink) send(ctx context.Context, msg *outbox.Message) error {
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsTimeout)
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	frame := fmt.Sprintf("PUB %s.%s %d\r\n%s\r\nPING\r\n", s.subject, msg.EventName(), len(msg.Payload()), msg.Payload())
+	if _, err := s.conn.Write([]byte(frame)); err != nil {
+		return err
+	}
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (s *NATSSink) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	if err := conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return err
+	}
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected nats greeting %q", line)
+	}
+	_, err = s.conn.Write([]byte(natsConnect + "\r\n"))
+	return err
+}
+
+func (s *NATSSink) readLine() (string, error) {
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (s *NATSSink) reset() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = nil
+	s.reader = nil
+}
@@ -0,0 +1,109 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+)
+
+const (
+	DefaultBatchSize  = 100
+	DefaultBackoffMin = time.Second
+	DefaultBackoffMax = 10 * time.Minute
+)
+
+// Backoff
+// Delay before the next attempt of a message which already failed attempts times
+type Backoff func(attempts int) time.Duration
+
+// ExponentialBackoff
+// Double the delay on every failure, starting at min and capped at max
+func ExponentialBackoff(min, max time.Duration) Backoff {
+	return func(attempts int) time.Duration {
+		delay := min
+		for i := 1; i < attempts && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// Relay
+// Publish the pending outbox messages to a Sink. Delivered messages are marked as
+// such, failed ones are retried later according to the backoff
+type Relay struct {
+	repository outbox.Repository
+	sink       Sink
+	logger     *charmLog.Logger
+	batchSize  int
+	backoff    Backoff
+	now        func() time.Time
+}
+
+type Option func(*Relay)
+
+func WithBatchSize(size int) Option {
+	return func(r *Relay) {
+		r.batchSize = size
+	}
+}
+
+func WithBackoff(backoff Backoff) Option {
+	return func(r *Relay) {
+		r.backoff = backoff
+	}
+}
+
+func New(repository outbox.Repository, sink Sink, logger *charmLog.Logger, opts ...Option) *Relay {
+	r := &Relay{
+		repository: repository,
+		sink:       sink,
+		logger:     logger,
+		batchSize:  DefaultBatchSize,
+		backoff:    ExponentialBackoff(DefaultBackoffMin, DefaultBackoffMax),
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RelayPending
+// Send one batch of due messages and return how many were delivered. A sink
+// failure only reschedules the message: the returned error is about the outbox itself
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.repository.ListPending(ctx, r.now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	held := make(map[string]struct{})
+	for _, msg := range messages {
+		// Keep the order of the events of a breed once one of them failed
+		if _, ok := held[msg.BreedName().String()]; ok {
+			continue
+		}
+
+		if err := r.sink.Send(ctx, msg); err != nil {
+			held[msg.BreedName().String()] = struct{}{}
+			next := r.now().Add(r.backoff(msg.Attempts() + 1))
+			r.logger.Warnf("Outbox message %d (%s) delivery failed, next attempt at %s: %s", msg.ID(), msg.EventName(), next, err)
+			if err := r.repository.MarkFailed(ctx, msg.ID(), next, err); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err := r.repository.MarkDelivered(ctx, msg.ID(), r.now()); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
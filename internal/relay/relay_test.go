@@ -0,0 +1,88 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/relay"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// flakySink
+// Local stand-in sink failing its first calls
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	received []string
+}
+
+func (s *flakySink) Send(_ context.Context, msg *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink down")
+	}
+	s.received = append(s.received, msg.BreedName().String()+" "+msg.EventName())
+	return nil
+}
+
+func TestRelay_RelayPending(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		var (
+			sink = &flakySink{failures: 1}
+			r    = relay.New(datastore.Outbox(), sink, logger, relay.WithBackoff(func(int) time.Duration { return 0 }))
+		)
+
+		for _, name := range []string{"test", "other"} {
+			b, err := breeds.NewFactory(breeds.FactoryOpts{
+				Name:    name,
+				Species: values.Dog.String(),
+				PetSize: values.Tall.String(),
+			}).Instantiate()
+			require.CmpNoError(err)
+			_, err = datastore.Breeds().CreateOne(ctx, b)
+			require.CmpNoError(err)
+		}
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "test"))
+
+		// The creation of test fails, its deletion is held back
+		delivered, err := r.RelayPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(delivered, 1)
+		require.Cmp(sink.received, []string{"other breed.created"})
+
+		delivered, err = r.RelayPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(delivered, 1)
+
+		delivered, err = r.RelayPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(delivered, 1)
+		require.Cmp(sink.received, []string{"other breed.created", "test breed.created", "test breed.deleted"})
+
+		delivered, err = r.RelayPending(ctx)
+		require.CmpNoError(err)
+		require.Cmp(delivered, 0)
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := relay.ExponentialBackoff(time.Second, 5*time.Second)
+
+	td.Cmp(t, backoff(1), time.Second)
+	td.Cmp(t, backoff(2), 2*time.Second)
+	td.Cmp(t, backoff(3), 4*time.Second)
+	td.Cmp(t, backoff(4), 5*time.Second)
+	td.Cmp(t, backoff(100), 5*time.Second)
+}
@@ -0,0 +1,210 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/events"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+)
+
+const (
+	EventNameHeader = "X-Event-Name"
+	EventIDHeader   = "X-Event-ID"
+)
+
+// Sink
+// Destination of the outbox messages. Send must only succeed once the message is accepted
+type Sink interface {
+	Send(context.Context, *outbox.Message) error
+}
+
+// WriterSink
+// Write every message payload on its own line
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{
+		w: w,
+	}
+}
+
+func (s *WriterSink) Send(_ context.Context, msg *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.w.Write(append(append([]byte{}, msg.Payload()...), '\n'))
+	return err
+}
+
+// NewFileSink
+// Append the messages to the file at path, created if needed
+func NewFileSink(path string) (*WriterSink, io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewWriterSink(f), f, nil
+}
+
+// HTTPSink
+// POST every message payload to an url. Any non 2xx response is a failure
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (s *HTTPSink) Send(ctx context.Context, msg *outbox.Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(msg.Payload()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventNameHeader, msg.EventName())
+	req.Header.Set(EventIDHeader, strconv.FormatInt(msg.ID(), 10))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s answered %s", s.url, res.Status)
+	}
+	return nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// SinkFromURL
+// Build a Sink from its url:
+//   - stdout
+//   - file:///var/log/breeds-events.log
+//   - http(s)://host/path
+//   - nats://host:4222/subject
+//
+// The returned closer releases the sink resources
+func SinkFromURL(raw string) (Sink, io.Closer, error) {
+	if raw == "" || raw == "stdout" {
+		return NewWriterSink(os.Stdout), nopCloser{}, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid sink url %s: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "file":
+		return NewFileSink(u.Path)
+	case "http", "https":
+		return NewHTTPSink(raw, nil), nopCloser{}, nil
+	case "nats":
+		subject := strings.Trim(u.Path, "/")
+		if subject == "" {
+			return nil, nil, fmt.Errorf("missing subject in nats sink url %s", raw)
+		}
+		s := NewNATSSink(u.Host, subject)
+		return s, s, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported sink url %s", raw)
+	}
+}
+
+// PublisherSink
+// Publish the breed event of every message to an in-process events.Publisher, e.g.
+// the domain events bus, once its mutation is committed
+type PublisherSink struct {
+	publisher events.Publisher
+}
+
+func NewPublisherSink(publisher events.Publisher) *PublisherSink {
+	return &PublisherSink{
+		publisher: publisher,
+	}
+}
+
+func (s *PublisherSink) Send(ctx context.Context, msg *outbox.Message) error {
+	evt, err := outbox.Decode(msg.Payload())
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(ctx, evt)
+	return nil
+}
+
+// FanOutSink
+// Send every message to several sinks. A message failing on some of them is only
+// sent again to those: the sinks which accepted it are remembered until it is
+// delivered, as long as the process runs
+type FanOutSink struct {
+	sinks []Sink
+	mu    sync.Mutex
+	// accepted lists the sinks which accepted each message failed by another one
+	accepted map[int64]map[int]struct{}
+}
+
+func NewFanOutSink(sinks ...Sink) *FanOutSink {
+	return &FanOutSink{
+		sinks:    sinks,
+		accepted: make(map[int64]map[int]struct{}),
+	}
+}
+
+func (s *FanOutSink) Send(ctx context.Context, msg *outbox.Message) error {
+	s.mu.Lock()
+	accepted, ok := s.accepted[msg.ID()]
+	s.mu.Unlock()
+	if !ok {
+		accepted = make(map[int]struct{})
+	}
+
+	var errArr []error
+	for i, val := range s.sinks {
+		if _, ok := accepted[i]; ok {
+			continue
+		}
+		if err := val.Send(ctx, msg); err != nil {
+			errArr = append(errArr, err)
+			continue
+		}
+		accepted[i] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(errArr) == 0 {
+		delete(s.accepted, msg.ID())
+		return nil
+	}
+	s.accepted[msg.ID()] = accepted
+	return errors.Join(errArr...)
+}
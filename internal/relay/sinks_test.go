@@ -0,0 +1,168 @@
+package relay_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/relay"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+var testMessage = outbox.NewMessage(42, "breed.created", "test", []byte(`{"event":"breed.created"}`), time.Now(), 0)
+
+func TestWriterSink_Send(t *testing.T) {
+	var buf bytes.Buffer
+
+	td.CmpNoError(t, relay.NewWriterSink(&buf).Send(context.Background(), testMessage))
+	td.CmpNoError(t, relay.NewWriterSink(&buf).Send(context.Background(), testMessage))
+	td.Cmp(t, buf.String(), "{\"event\":\"breed.created\"}\n{\"event\":\"breed.created\"}\n")
+}
+
+func TestPublisherSink_Send(t *testing.T) {
+	require := td.Require(t)
+	recorder := &testutils.EventRecorder{}
+	sink := relay.NewPublisherSink(recorder)
+
+	breed, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    "test",
+		Species: values.Cat.String(),
+		PetSize: values.Small.String(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	evt := breeds.NewBreedCreated(breed)
+	payload, err := outbox.Encode(evt)
+	require.CmpNoError(err)
+
+	require.CmpNoError(sink.Send(context.Background(), outbox.NewMessage(1, evt.Name(), breed.Name(), payload, evt.OccurredAt(), 0)))
+	published := recorder.Events()
+	require.Len(published, 1)
+	require.Cmp(published[0], td.Isa(breeds.BreedCreated{}), "valid case -- typed event")
+	require.Cmp(published[0].(breeds.BreedCreated).BreedName(), values.BreedName("test"))
+	require.Cmp(published[0].OccurredAt(), td.Code(evt.OccurredAt().Equal))
+
+	require.CmpError(sink.Send(context.Background(), testMessage), "invalid case -- undecodable payload")
+	require.Empty(recorder.Events())
+}
+
+func TestFanOutSink_Send(t *testing.T) {
+	var (
+		stable = &flakySink{}
+		flaky  = &flakySink{failures: 1}
+		sink   = relay.NewFanOutSink(stable, flaky)
+	)
+
+	td.CmpError(t, sink.Send(context.Background(), testMessage), "invalid case -- one sink fails")
+	td.CmpNoError(t, sink.Send(context.Background(), testMessage))
+	td.Cmp(t, stable.received, []string{"test breed.created"}, "valid case -- accepted once")
+	td.Cmp(t, flaky.received, []string{"test breed.created"}, "valid case -- retried")
+
+	td.CmpNoError(t, sink.Send(context.Background(), testMessage))
+	td.Cmp(t, stable.received, td.Len(2), "valid case -- delivered messages are forgotten")
+}
+
+func TestSinkFromURL(t *testing.T) {
+	require := td.Require(t)
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	sink, closer, err := relay.SinkFromURL("file://" + path)
+	require.CmpNoError(err)
+	require.CmpNoError(sink.Send(context.Background(), testMessage))
+	require.CmpNoError(closer.Close())
+	raw, err := os.ReadFile(path)
+	require.CmpNoError(err)
+	require.Cmp(string(raw), "{\"event\":\"breed.created\"}\n")
+
+	for _, val := range []string{"", "stdout", "http://localhost/events", "nats://localhost:4222/breeds"} {
+		_, _, err := relay.SinkFromURL(val)
+		require.CmpNoError(err, val)
+	}
+	for _, val := range []string{"ftp://localhost", "nats://localhost:4222"} {
+		_, _, err := relay.SinkFromURL(val)
+		require.CmpError(err, val)
+	}
+}
+
+func TestHTTPSink_Send(t *testing.T) {
+	var (
+		require = td.Require(t)
+		status  = http.StatusNoContent
+		got     *http.Request
+		body    []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	sink := relay.NewHTTPSink(srv.URL, nil)
+
+	require.CmpNoError(sink.Send(context.Background(), testMessage))
+	require.Cmp(got.Method, http.MethodPost)
+	require.Cmp(got.Header.Get(relay.EventNameHeader), "breed.created")
+	require.Cmp(got.Header.Get(relay.EventIDHeader), "42")
+	require.Cmp(string(body), `{"event":"breed.created"}`)
+
+	status = http.StatusServiceUnavailable
+	require.CmpError(sink.Send(context.Background(), testMessage))
+}
+
+func TestNATSSink_Send(t *testing.T) {
+	require := td.Require(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.CmpNoError(err)
+	defer ln.Close()
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte("INFO {}\r\n"))
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "PUB breeds.breed.deleted"):
+				_, _ = r.ReadString('\n')
+				_, _ = conn.Write([]byte("-ERR 'Permissions Violation'\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				payload, _ := r.ReadString('\n')
+				published <- line + " " + strings.TrimRight(payload, "\r\n")
+			case line == "PING":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+
+	sink := relay.NewNATSSink(ln.Addr().String(), "breeds")
+	defer sink.Close()
+
+	require.CmpNoError(sink.Send(context.Background(), testMessage))
+	require.Cmp(<-published, `PUB breeds.breed.created 25 {"event":"breed.created"}`)
+
+	deleted := outbox.NewMessage(43, "breed.deleted", "test", []byte(`{}`), time.Now(), 0)
+	require.CmpError(sink.Send(context.Background(), deleted))
+}
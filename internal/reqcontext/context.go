@@ -0,0 +1,63 @@
+package reqcontext
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+)
+
+type key int
+
+const (
+	actorKey key = iota
+	requestIDKey
+	usecaseKey
+	principalKey
+)
+
+// WithActor
+// Store the identity of the caller performing the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+func Actor(ctx context.Context) string {
+	val, _ := ctx.Value(actorKey).(string)
+	return val
+}
+
+// WithRequestID
+// Store the id used to correlate everything done for one request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func RequestID(ctx context.Context) string {
+	val, _ := ctx.Value(requestIDKey).(string)
+	return val
+}
+
+// WithUsecase
+// Store the usecase currently executed (see usecases.UseCaseInfo)
+func WithUsecase(ctx context.Context, usecase string) context.Context {
+	return context.WithValue(ctx, usecaseKey, usecase)
+}
+
+func Usecase(ctx context.Context) string {
+	val, _ := ctx.Value(usecaseKey).(string)
+	return val
+}
+
+// WithPrincipal
+// Store the authenticated caller of the request
+func WithPrincipal(ctx context.Context, principal auth.Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// Principal
+// Authenticated caller of the request. False when the request is not authenticated,
+// e.g. for the background jobs
+func Principal(ctx context.Context) (auth.Principal, bool) {
+	val, ok := ctx.Value(principalKey).(auth.Principal)
+	return val, ok
+}
@@ -0,0 +1,228 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+)
+
+const (
+	DefaultBufferSize     = 1000
+	DefaultSubscriberSize = 64
+	pageSize              = 500
+)
+
+var (
+	ErrClosed = errors.New("events stream is closed")
+)
+
+type subscriber struct {
+	events  chan Event
+	species string
+}
+
+// Broker
+// Follow the breed events written in the outbox and fan them out to the stream
+// subscribers. The latest events are kept in a bounded buffer so that a client
+// can resume after the last event it received. Older ones are read back from the outbox.
+// Outbox ids are given on insertion, so an event committed after a newer one
+// was polled is not streamed
+type Broker struct {
+	repository     outbox.Repository
+	logger         *charmLog.Logger
+	bufferSize     int
+	subscriberSize int
+
+	mu          sync.Mutex
+	cursor      int64
+	initialized bool
+	closed      bool
+	buffer      []Event
+	subscribers map[*subscriber]struct{}
+}
+
+type Option func(*Broker)
+
+// WithBufferSize
+// Number of events kept in memory for resumption
+func WithBufferSize(size int) Option {
+	return func(b *Broker) {
+		b.bufferSize = size
+	}
+}
+
+// WithSubscriberSize
+// Number of events a subscriber can lag behind before being disconnected
+func WithSubscriberSize(size int) Option {
+	return func(b *Broker) {
+		b.subscriberSize = size
+	}
+}
+
+func NewBroker(repository outbox.Repository, logger *charmLog.Logger, opts ...Option) *Broker {
+	b := &Broker{
+		repository:     repository,
+		logger:         logger,
+		bufferSize:     DefaultBufferSize,
+		subscriberSize: DefaultSubscriberSize,
+		subscribers:    make(map[*subscriber]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Poll
+// Fetch the events written in the outbox since the last poll and send them to
+// the subscribers. The first poll only sets the starting point of the stream
+func (b *Broker) Poll(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	if !b.initialized {
+		last, err := b.repository.LastID(ctx)
+		if err != nil {
+			return err
+		}
+		b.cursor = last
+		b.initialized = true
+		return nil
+	}
+
+	for {
+		messages, err := b.repository.ListSince(ctx, b.cursor, pageSize)
+		if err != nil {
+			return err
+		}
+		for _, msg := range messages {
+			b.publish(EventFromMessage(msg))
+		}
+		if len(messages) < pageSize {
+			return nil
+		}
+	}
+}
+
+func (b *Broker) publish(evt Event) {
+	b.cursor = evt.ID
+	b.buffer = append(b.buffer, evt)
+	if len(b.buffer) > b.bufferSize {
+		b.buffer = b.buffer[len(b.buffer)-b.bufferSize:]
+	}
+
+	for s := range b.subscribers {
+		if !evt.Matches(s.species) {
+			continue
+		}
+		select {
+		case s.events <- evt:
+		default:
+			// Too slow: the client resumes from its last event when reconnecting
+			b.logger.Warnf("Events stream subscriber disconnected after lagging behind")
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Broker) remove(s *subscriber) {
+	if _, ok := b.subscribers[s]; ok {
+		delete(b.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Subscribe
+// Follow the events of a species (every species if empty). When lastEventID is
+// set, the events following it are returned to be sent first. The channel is
+// closed when the subscriber lags behind or the broker is closed, and cancel
+// must be called once done
+func (b *Broker) Subscribe(ctx context.Context, lastEventID *int64, species string) ([]Event, <-chan Event, func(), error) {
+	s := &subscriber{
+		events:  make(chan Event, b.subscriberSize),
+		species: species,
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, nil, nil, ErrClosed
+	}
+	b.subscribers[s] = struct{}{}
+	cursor := b.cursor
+	buffered := append([]Event{}, b.buffer...)
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(s)
+	}
+	if lastEventID == nil || *lastEventID >= cursor {
+		return nil, s.events, cancel, nil
+	}
+
+	replay, err := b.replay(ctx, *lastEventID, cursor, buffered)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	res := []Event{}
+	for _, evt := range replay {
+		if evt.Matches(species) {
+			res = append(res, evt)
+		}
+	}
+	return res, s.events, cancel, nil
+}
+
+// replay
+// Events in (after, until], from the buffer when it holds them all, from the outbox otherwise
+func (b *Broker) replay(ctx context.Context, after, until int64, buffered []Event) ([]Event, error) {
+	var res []Event
+
+	if len(buffered) > 0 && buffered[0].ID <= after+1 {
+		for _, evt := range buffered {
+			if evt.ID > after && evt.ID <= until {
+				res = append(res, evt)
+			}
+		}
+		return res, nil
+	}
+
+	for after < until {
+		messages, err := b.repository.ListSince(ctx, after, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		for _, msg := range messages {
+			if msg.ID() > until {
+				return res, nil
+			}
+			res = append(res, EventFromMessage(msg))
+			after = msg.ID()
+		}
+	}
+	return res, nil
+}
+
+// Close
+// Disconnect every subscriber. Subscribing is not possible anymore
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subscribers {
+		b.remove(s)
+	}
+}
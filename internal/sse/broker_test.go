@@ -0,0 +1,91 @@
+package sse_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func createBreed(ctx context.Context, require *td.T, datastore gateways.IDatastore, name string, species values.Species) {
+	b, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    name,
+		Species: species.String(),
+		PetSize: values.Medium.String(),
+	}).Instantiate()
+	require.CmpNoError(err)
+	_, err = datastore.Breeds().CreateOne(ctx, b)
+	require.CmpNoError(err)
+}
+
+func names(evts []sse.Event) []string {
+	return common.Map(evts, func(val sse.Event) string { return val.Name })
+}
+
+func TestBroker(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, logger *charmLog.Logger) {
+		broker := sse.NewBroker(datastore.Outbox(), logger, sse.WithBufferSize(2), sse.WithSubscriberSize(1))
+
+		// Events written before the first poll are not streamed
+		createBreed(ctx, require, datastore, "before", values.Cat)
+		require.CmpNoError(broker.Poll(ctx))
+
+		_, all, cancelAll, err := broker.Subscribe(ctx, nil, "")
+		require.CmpNoError(err)
+		defer cancelAll()
+		_, dogs, cancelDogs, err := broker.Subscribe(ctx, nil, values.Dog.String())
+		require.CmpNoError(err)
+		defer cancelDogs()
+
+		createBreed(ctx, require, datastore, "cat", values.Cat)
+		require.CmpNoError(broker.Poll(ctx))
+		first := <-all
+		require.Cmp(first.Name, breeds.EventBreedCreated)
+		require.Cmp(first.Species, values.Cat.String())
+		require.Len(dogs, 0)
+
+		createBreed(ctx, require, datastore, "dog", values.Dog)
+		require.CmpNoError(datastore.Breeds().DeleteOneByName(ctx, "dog"))
+		require.CmpNoError(broker.Poll(ctx))
+		require.Cmp((<-dogs).Name, breeds.EventBreedCreated)
+
+		// Both subscribers lagged behind
+		_, ok := <-dogs
+		require.False(ok)
+		<-all
+		_, ok = <-all
+		require.False(ok)
+
+		// Resumption from the buffer, then from the outbox once out of the buffer
+		replay, _, cancel, err := broker.Subscribe(ctx, common.ToPointer(first.ID), "")
+		require.CmpNoError(err)
+		cancel()
+		require.Cmp(names(replay), []string{breeds.EventBreedCreated, breeds.EventBreedDeleted})
+
+		replay, _, cancel, err = broker.Subscribe(ctx, common.ToPointer(first.ID-1), values.Cat.String())
+		require.CmpNoError(err)
+		cancel()
+		require.Len(replay, 1)
+		require.Cmp(replay[0].ID, first.ID)
+
+		broker.Close()
+		_, _, _, err = broker.Subscribe(ctx, nil, "")
+		require.CmpErrorIs(err, sse.ErrClosed)
+	})
+}
+
+func TestEvent_Write(t *testing.T) {
+	var buf bytes.Buffer
+
+	evt := sse.Event{ID: 42, Name: breeds.EventBreedCreated, Data: []byte("{\n\"a\": 1}")}
+	td.CmpNoError(t, evt.Write(&buf))
+	td.Cmp(t, buf.String(), "id: 42\nevent: breed.created\ndata: {\ndata: \"a\": 1}\n\n")
+}
@@ -0,0 +1,52 @@
+package sse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/japhy-tech/backend-test/internal/domain/outbox"
+)
+
+// Event
+// Breed change sent on the stream. ID is the id of the event in the outbox
+type Event struct {
+	ID      int64
+	Name    string
+	Species string
+	Data    []byte
+}
+
+func EventFromMessage(msg *outbox.Message) Event {
+	var envelope outbox.Envelope
+
+	// Payloads are written by the outbox, an invalid one only loses the species filtering
+	_ = json.Unmarshal(msg.Payload(), &envelope)
+	return Event{
+		ID:      msg.ID(),
+		Name:    msg.EventName(),
+		Species: envelope.Breed.Species,
+		Data:    msg.Payload(),
+	}
+}
+
+// Matches
+// Tell whether the event passes the species filter. An empty filter matches everything
+func (e Event) Matches(species string) bool {
+	return species == "" || e.Species == species
+}
+
+// Write
+// Write the event in the text/event-stream format
+func (e Event) Write(w io.Writer) error {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "id: %d\nevent: %s\n", e.ID, e.Name)
+	for _, line := range bytes.Split(e.Data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
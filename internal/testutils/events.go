@@ -0,0 +1,31 @@
+package testutils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/japhy-tech/backend-test/internal/domain/events"
+)
+
+// EventRecorder
+// events.Publisher keeping every published event, to assert on them in tests
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (r *EventRecorder) Publish(_ context.Context, evts ...events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, evts...)
+}
+
+// Events
+// Return the recorded events and forget them
+func (r *EventRecorder) Events() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := r.events
+	r.events = nil
+	return res
+}
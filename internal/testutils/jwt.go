@@ -0,0 +1,84 @@
+package testutils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/japhy-tech/backend-test/internal/jwtauth"
+)
+
+const (
+	IssuerURL      = "https://issuer.test"
+	IssuerAudience = "backend-test"
+	IssuerKeyID    = "test-key"
+)
+
+// Issuer
+// Stand-in for the identity provider: it signs tokens with its own RSA key and
+// serves the matching JWKS
+type Issuer struct {
+	key *rsa.PrivateKey
+}
+
+func NewIssuer() (*Issuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &Issuer{key: key}, nil
+}
+
+// JWKS
+// Document publishing the public key of the issuer
+func (i Issuer) JWKS() []byte {
+	res, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kid": IssuerKeyID,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+	return res
+}
+
+func (i Issuer) KeySet() jwtauth.KeySet {
+	res, _ := jwtauth.ParseJWKS(i.JWKS())
+	return res
+}
+
+// ServeHTTP
+// Serve the JWKS, to be used as a remote key set
+func (i Issuer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(i.JWKS())
+}
+
+// Token
+// Token of the subject valid for an hour, with the given roles
+func (i Issuer) Token(subject string, roles ...string) (string, error) {
+	return i.Sign(jwt.MapClaims{
+		"iss":   IssuerURL,
+		"aud":   IssuerAudience,
+		"sub":   subject,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": roles,
+	})
+}
+
+// Sign
+// Token with arbitrary claims
+func (i Issuer) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = IssuerKeyID
+	return token.SignedString(i.key)
+}
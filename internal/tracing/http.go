@@ -0,0 +1,17 @@
+package tracing
+
+import (
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware
+// Start a span per request, named after its route template and child of the
+// traceparent sent by the client. It must be used on the router
+func Middleware(provider trace.TracerProvider) mux.MiddlewareFunc {
+	return otelmux.Middleware(ServiceName,
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithPropagators(Propagator()),
+	)
+}
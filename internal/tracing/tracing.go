@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// ServiceName is the service reported with the spans
+	ServiceName = "backend-test"
+	// TracerName is the instrumentation scope of the spans created by the service
+	TracerName = "github.com/japhy-tech/backend-test"
+)
+
+// Propagator
+// W3C trace context, e.g. the traceparent header, and baggage
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// ShutdownFunc
+// Flush the pending spans and release the exporter
+type ShutdownFunc func(context.Context) error
+
+// Setup
+// Build the tracer provider exporting to the given url (see ExporterFromURL) and set
+// it as the global one along with the propagator. Tracing is disabled when the url
+// is empty
+func Setup(ctx context.Context, raw string) (trace.TracerProvider, ShutdownFunc, error) {
+	otel.SetTextMapPropagator(Propagator())
+	if raw == "" {
+		provider := noop.NewTracerProvider()
+		otel.SetTracerProvider(provider)
+		return provider, func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := ExporterFromURL(ctx, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider, func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closer.Close())
+	}, nil
+}
+
+// ExporterFromURL
+// Build the span exporter of the url: "stdout" and file:///path write the spans as
+// JSON, http(s) urls are OTLP collectors, e.g. http://otel-collector:4318
+func ExporterFromURL(ctx context.Context, raw string) (sdktrace.SpanExporter, io.Closer, error) {
+	if raw == "stdout" {
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, nopCloser{}, err
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid traces exporter url %s: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "file":
+		f, err := os.OpenFile(u.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot open traces file %s: %w", u.Path, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	case "http", "https":
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(raw))
+		return exporter, nopCloser{}, err
+	default:
+		return nil, nil, fmt.Errorf("unsupported traces exporter url %s", raw)
+	}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
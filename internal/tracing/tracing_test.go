@@ -0,0 +1,89 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/gateways/mysql"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/tracing"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"github.com/maxatome/go-testdeep/td"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestExporterFromURL(t *testing.T) {
+	require := td.Require(t)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	exporter, closer, err := tracing.ExporterFromURL(ctx, "file://"+path)
+	require.CmpNoError(err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	_, span := provider.Tracer(tracing.TracerName).Start(ctx, "test")
+	span.End()
+	require.CmpNoError(provider.Shutdown(ctx))
+	require.CmpNoError(closer.Close())
+	content, err := os.ReadFile(path)
+	require.CmpNoError(err)
+	require.Cmp(string(content), td.Contains(`"Name":"test"`))
+
+	_, _, err = tracing.ExporterFromURL(ctx, "nats://localhost:4222")
+	require.CmpError(err)
+}
+
+func TestTracing(t *testing.T) {
+	require := td.Require(t)
+	var (
+		recorder  = tracetest.NewSpanRecorder()
+		provider  = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		datastore = mysql.New(testutils.MysqlDSN, logger.Logger, mysql.WithTracerProvider(provider))
+		list      = tracing.Interceptor(provider)(func(ctx context.Context, call usecases.Call) (any, error) {
+			return datastore.Breeds().List(ctx, breeds.ListOpts{})
+		})
+		r = mux.NewRouter()
+	)
+	defer datastore.Close()
+
+	r.Use(tracing.Middleware(provider))
+	r.HandleFunc("/v1/breeds", func(w http.ResponseWriter, r *http.Request) {
+		_, err := list(r.Context(), usecases.Call{Info: usecases.UseCaseInfo{Action: usecases.ActionList, Name: usecases.BreedUsecase}})
+		require.CmpNoError(err)
+	}).Methods(http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/breeds", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// The spans of the connection to the datastore are not part of the request
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			spans[span.Name()] = span
+		}
+	}
+	require.Cmp(spans, td.SuperMapOf(map[string]sdktrace.ReadOnlySpan{}, td.MapEntries{
+		"/v1/breeds":   td.Ignore(),
+		"<LIST BREED>": td.Ignore(),
+		"sql.conn.query": td.Code(func(span sdktrace.ReadOnlySpan) bool {
+			for _, attr := range span.Attributes() {
+				if attr.Key == attribute.Key("db.statement") {
+					return td.Cmp(t, attr.Value.AsString(), td.Contains("FROM `breeds`"))
+				}
+			}
+			return false
+		}),
+	}))
+	require.Cmp(spans["/v1/breeds"].Parent().SpanID().String(), "00f067aa0ba902b7")
+	require.Cmp(spans["<LIST BREED>"].Parent().SpanID(), spans["/v1/breeds"].SpanContext().SpanID())
+	require.Cmp(spans["sql.conn.query"].Parent().SpanID(), spans["<LIST BREED>"].SpanContext().SpanID())
+}
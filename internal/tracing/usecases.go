@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Interceptor
+// Start a span per usecase execution, named after its UseCaseInfo
+func Interceptor(provider trace.TracerProvider) usecases.Interceptor {
+	tracer := provider.Tracer(TracerName)
+	return func(next usecases.Handler) usecases.Handler {
+		return func(ctx context.Context, call usecases.Call) (any, error) {
+			ctx, span := tracer.Start(ctx, call.Info.String(), trace.WithAttributes(
+				attribute.String("usecase.name", call.Info.Name.String()),
+				attribute.String("usecase.action", call.Info.Action.String()),
+			))
+			defer span.End()
+
+			res, err := next(ctx, call)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return res, err
+		}
+	}
+}
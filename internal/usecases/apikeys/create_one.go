@@ -0,0 +1,70 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type CreateOneOpts struct {
+	Name   string
+	Scopes []string
+}
+
+// Created
+// The key is only known when it is created, it cannot be retrieved afterwards
+type Created struct {
+	APIKey *apikeys.APIKey
+	Key    string
+}
+
+type CreateOne struct {
+	usecases.Base
+}
+
+func (c CreateOne) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionCreate,
+		Name:   usecases.APIKeyUsecase,
+	}
+}
+
+func (c CreateOne) Handle(ctx context.Context, opts CreateOneOpts) (*Created, error) {
+	key, hash, err := apikeys.GenerateKey()
+	if err != nil {
+		return nil, domainerror.WrapError(domainerror.ErrInternalError, err)
+	}
+	k, err := apikeys.NewFactory(apikeys.FactoryOpts{
+		ID:        uuid.NewString(),
+		Name:      opts.Name,
+		Hash:      hash,
+		Scopes:    opts.Scopes,
+		CreatedAt: time.Now().UTC(),
+	}).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.Datastore().APIKeys().GetOneByName(ctx, k.Name())
+	if err == nil {
+		return nil, domainerror.WrapError(domainerror.ErrResourceAlreadyExists, fmt.Errorf("api key %s already exists", k.Name()))
+	}
+	if !errors.Is(err, domainerror.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	res, err := c.Datastore().APIKeys().CreateOne(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	return &Created{
+		APIKey: res,
+		Key:    key,
+	}, nil
+}
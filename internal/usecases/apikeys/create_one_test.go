@@ -0,0 +1,51 @@
+package apikeys_test
+
+import (
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	apikeysUsecase "github.com/japhy-tech/backend-test/internal/usecases/apikeys"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestCreateOne_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler = usecases.New(&apikeysUsecase.CreateOne{}, datastore)
+			revokeHandler = usecases.NewSimple(&apikeysUsecase.RevokeOneByName{}, datastore)
+		)
+
+		created, err := createHandler.Handle(ctx, apikeysUsecase.CreateOneOpts{Name: "backoffice", Scopes: []string{"read", "write"}})
+		require.CmpNoError(err)
+		require.Cmp(created.APIKey.Scopes(), []auth.Scope{auth.ScopeRead, auth.ScopeWrite})
+		require.Cmp(created.APIKey.Hash(), apikeys.HashKey(created.Key))
+
+		// Only the hash is stored
+		found, err := datastore.APIKeys().GetOneByHash(ctx, apikeys.HashKey(created.Key))
+		require.CmpNoError(err)
+		require.Cmp(found.Name(), "backoffice")
+		require.False(found.Revoked())
+
+		_, err = createHandler.Handle(ctx, apikeysUsecase.CreateOneOpts{Name: "backoffice", Scopes: []string{"admin"}})
+		require.CmpErrorIs(err, domainerror.ErrResourceAlreadyExists)
+
+		require.CmpNoError(revokeHandler.Handle(ctx, "backoffice"))
+		found, err = datastore.APIKeys().GetOneByName(ctx, "backoffice")
+		require.CmpNoError(err)
+		require.True(found.Revoked())
+		// Revoking twice keeps the first revocation date
+		require.CmpNoError(revokeHandler.Handle(ctx, "backoffice"))
+		again, err := datastore.APIKeys().GetOneByName(ctx, "backoffice")
+		require.CmpNoError(err)
+		require.Cmp(again.RevokedAt(), found.RevokedAt())
+
+		require.CmpErrorIs(revokeHandler.Handle(ctx, "unknown"), domainerror.ErrResourceNotFound)
+	})
+}
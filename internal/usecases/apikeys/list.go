@@ -0,0 +1,23 @@
+package apikeys
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/apikeys"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type List struct {
+	usecases.Base
+}
+
+func (l List) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionList,
+		Name:   usecases.APIKeyUsecase,
+	}
+}
+
+func (l List) Handle(ctx context.Context, _ struct{}) ([]*apikeys.APIKey, error) {
+	return l.Datastore().APIKeys().List(ctx)
+}
@@ -0,0 +1,25 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type RevokeOneByName struct {
+	usecases.Base
+}
+
+func (r RevokeOneByName) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionRevoke,
+		Name:   usecases.APIKeyUsecase,
+	}
+}
+
+// Handle
+// Revoke a key, the requests using it are rejected from now on
+func (r RevokeOneByName) Handle(ctx context.Context, name string) error {
+	return r.Datastore().APIKeys().RevokeOne(ctx, name, time.Now().UTC())
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+)
+
+// RequiredRole
+// Role a principal must have to execute the usecase
+func (u UseCaseInfo) RequiredRole() auth.Role {
+	switch u.Name {
+	case BreedUsecase:
+		switch u.Action {
+		case ActionCreate, ActionUpdate, ActionRestore:
+			return auth.NewRole(auth.ResourceBreeds, auth.ScopeWrite)
+		case ActionDelete, ActionPurge:
+			return auth.NewRole(auth.ResourceBreeds, auth.ScopeAdmin)
+		default:
+			return auth.NewRole(auth.ResourceBreeds, auth.ScopeRead)
+		}
+	case WebhookUsecase:
+		return auth.NewRole(auth.ResourceWebhooks, auth.ScopeAdmin)
+	default:
+		return auth.NewRole(auth.ResourceAPIKeys, auth.ScopeAdmin)
+	}
+}
+
+// Authorize
+// Check that the principal of the context has the role required by the usecase.
+// Contexts without principal are not checked: they are not coming from the API,
+// whose requests are all authenticated, but from the jobs and the commands
+func Authorize(ctx context.Context, info UseCaseInfo) error {
+	principal, ok := reqcontext.Principal(ctx)
+	if !ok {
+		return nil
+	}
+	if required := info.RequiredRole(); !principal.HasRole(required) {
+		return domainerror.WrapError(domainerror.ErrForbidden, fmt.Errorf("%s requires the %s role", info, required))
+	}
+	return nil
+}
+
+// AuthorizeInput
+// Check that the principal of the context has the role required by a part of the
+// input of the usecase, on top of the one required by the usecase itself, e.g. to
+// list the deleted breeds. Contexts without principal are not checked, as by Authorize
+func AuthorizeInput(ctx context.Context, info UseCaseInfo, input string, required auth.Role) error {
+	principal, ok := reqcontext.Principal(ctx)
+	if !ok {
+		return nil
+	}
+	if !principal.HasRole(required) {
+		return domainerror.WrapError(domainerror.ErrForbidden, fmt.Errorf("%s with %s requires the %s role", info, input, required))
+	}
+	return nil
+}
+
+// PolicyRequest
+// What a policy decides on: who executes which usecase with which input, and when
+type PolicyRequest struct {
+	Principal auth.Principal
+	Info      UseCaseInfo
+	Input     any
+	At        time.Time
+}
+
+// Policy
+// Rules deciding whether a principal may execute a usecase, on top of its roles.
+// Evaluate returns an error wrapping domainerror.ErrForbidden to deny the request
+type Policy interface {
+	Evaluate(context.Context, PolicyRequest) error
+}
+
+// PolicyFunc
+// Function implementing Policy
+type PolicyFunc func(context.Context, PolicyRequest) error
+
+func (f PolicyFunc) Evaluate(ctx context.Context, req PolicyRequest) error {
+	return f(ctx, req)
+}
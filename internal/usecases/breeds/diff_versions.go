@@ -0,0 +1,67 @@
+package breeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+var (
+	ErrInvalidVersion = errors.New("version must be greater than 0")
+)
+
+type DiffVersions struct {
+	usecases.Base
+}
+
+type DiffVersionsOpts struct {
+	Name string
+	From int
+	To   int
+}
+
+func (d DiffVersionsOpts) Validate() error {
+	if err := values.BreedName(d.Name).Validate(); err != nil {
+		return err
+	}
+	if d.From < 1 || d.To < 1 {
+		return ErrInvalidVersion
+	}
+	return nil
+}
+
+func (d DiffVersions) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionDiff,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+// Handle
+// List the fields which changed between two versions of a breed
+func (d DiffVersions) Handle(ctx context.Context, params DiffVersionsOpts) ([]breeds.FieldChange, error) {
+	var (
+		breedName = values.BreedName(params.Name)
+		memo      = make(map[int]*breeds.Breed)
+	)
+
+	versions, err := d.Datastore().Breeds().ListVersions(ctx, breedName)
+	if err != nil {
+		return nil, err
+	}
+	for _, val := range versions {
+		memo[val.Number()] = val.Breed()
+	}
+
+	for _, number := range []int{params.From, params.To} {
+		if _, ok := memo[number]; !ok {
+			return nil, domainerror.WrapError(domainerror.ErrResourceNotFound, fmt.Errorf("version %d of breed %s not found", number, breedName))
+		}
+	}
+	return breeds.Diff(memo[params.From], memo[params.To]), nil
+}
@@ -0,0 +1,104 @@
+package breeds_test
+
+import (
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedUsecases "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestDiffVersions_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler = usecases.New(&breedUsecases.CreateOne{}, datastore)
+			updateHandler = usecases.New(&breedUsecases.UpdateOne{}, datastore)
+			diffHandler   = usecases.New(&breedUsecases.DiffVersions{}, datastore)
+
+			tests = []struct {
+				name        string
+				input       breedUsecases.DiffVersionsOpts
+				want        []breeds.FieldChange
+				wantErr     error
+				errContains string
+			}{
+				{
+					name:  "valid case",
+					input: breedUsecases.DiffVersionsOpts{Name: "test", From: 1, To: 2},
+					want: []breeds.FieldChange{
+						{Field: "pet_size", From: "medium", To: "tall"},
+						{Field: "average_male_adult_weight", From: 1, To: 3},
+					},
+				},
+				{
+					name:  "valid case -- reversed",
+					input: breedUsecases.DiffVersionsOpts{Name: "test", From: 2, To: 1},
+					want: []breeds.FieldChange{
+						{Field: "pet_size", From: "tall", To: "medium"},
+						{Field: "average_male_adult_weight", From: 3, To: 1},
+					},
+				},
+				{
+					name:  "valid case -- same version",
+					input: breedUsecases.DiffVersionsOpts{Name: "test", From: 2, To: 2},
+					want:  []breeds.FieldChange{},
+				},
+				{
+					name:    "invalid case -- version not found",
+					input:   breedUsecases.DiffVersionsOpts{Name: "test", From: 1, To: 3},
+					wantErr: domainerror.ErrResourceNotFound,
+				},
+				{
+					name:        "invalid case -- invalid version",
+					input:       breedUsecases.DiffVersionsOpts{Name: "test", From: 0, To: 1},
+					wantErr:     domainerror.ErrDomainValidation,
+					errContains: breedUsecases.ErrInvalidVersion.Error(),
+				},
+				{
+					name:        "invalid case -- invalid name",
+					input:       breedUsecases.DiffVersionsOpts{Name: "invalid name", From: 1, To: 2},
+					wantErr:     domainerror.ErrDomainValidation,
+					errContains: values.ErrNameInvalid.Error(),
+				},
+			}
+		)
+
+		_, err := createHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		})
+		require.CmpNoError(err)
+		_, err = updateHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Tall.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(3),
+		})
+		require.CmpNoError(err)
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := diffHandler.Handle(ctx, tt.input)
+				require.CmpErrorIs(err, tt.wantErr)
+
+				if tt.wantErr != nil {
+					require.Contains(err.Error(), tt.errContains)
+				} else {
+					require.Cmp(res, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package breeds_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/events"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/relay"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedUsecases "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestBreedUsecases_Events(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			recorder      = &testutils.EventRecorder{}
+			outboxRelay   = relay.New(datastore.Outbox(), relay.NewPublisherSink(recorder), charmLog.New(io.Discard))
+			createHandler = usecases.New(&breedUsecases.CreateOne{}, datastore)
+			updateHandler = usecases.New(&breedUsecases.UpdateOne{}, datastore)
+			deleteHandler = usecases.NewSimple(&breedUsecases.DeleteOneByName{}, datastore)
+			opts          = breeds.FactoryOpts{
+				Name:                "test",
+				Species:             values.Cat.String(),
+				PetSize:             values.Medium.String(),
+				AverageFemaleWeight: common.ToPointer(1),
+				AverageMaleWeight:   common.ToPointer(1),
+			}
+			// Events published once the outbox is relayed
+			relayed = func() []events.Event {
+				_, err := outboxRelay.RelayPending(ctx)
+				require.CmpNoError(err)
+				return recorder.Events()
+			}
+		)
+
+		_, err := createHandler.Handle(ctx, opts)
+		require.CmpNoError(err)
+		evts := relayed()
+		require.Len(evts, 1)
+		require.Cmp(evts[0], td.Isa(breeds.BreedCreated{}))
+		require.Cmp(evts[0].(breeds.BreedCreated).BreedName(), values.BreedName("test"))
+
+		// A failing mutation raises nothing
+		_, err = createHandler.Handle(ctx, opts)
+		require.CmpError(err)
+		require.Empty(relayed())
+
+		opts.PetSize = values.Tall.String()
+		opts.AverageMaleWeight = common.ToPointer(2)
+		_, err = updateHandler.Handle(ctx, opts)
+		require.CmpNoError(err)
+		evts = relayed()
+		require.Len(evts, 1)
+		require.Cmp(evts[0], td.Isa(breeds.BreedUpdated{}))
+		require.Cmp(evts[0].(breeds.BreedUpdated).Changes(), []breeds.FieldChange{
+			{Field: "pet_size", From: values.Medium.String(), To: values.Tall.String()},
+			{Field: "average_male_adult_weight", From: 1, To: 2},
+		})
+
+		require.CmpNoError(deleteHandler.Handle(ctx, "test"))
+		evts = relayed()
+		require.Len(evts, 1)
+		require.Cmp(evts[0], td.Isa(breeds.BreedDeleted{}))
+
+		// Relayed events are not published again
+		require.Empty(relayed())
+	})
+}
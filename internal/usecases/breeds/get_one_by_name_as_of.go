@@ -0,0 +1,37 @@
+package breeds
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type GetOneByNameAsOf struct {
+	usecases.Base
+}
+
+type GetOneByNameAsOfOpts struct {
+	Name string
+	AsOf time.Time
+}
+
+func (g GetOneByNameAsOf) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionRetrieve,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+// Handle
+// Retrieve a breed as it was at the given date
+func (g GetOneByNameAsOf) Handle(ctx context.Context, params GetOneByNameAsOfOpts) (*breeds.Breed, error) {
+	breedName := values.BreedName(params.Name)
+
+	if err := values.Verify(breedName); err != nil {
+		return nil, err
+	}
+	return g.Datastore().Breeds().AsOf(params.AsOf).GetOneByName(ctx, breedName)
+}
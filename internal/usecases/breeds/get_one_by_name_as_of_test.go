@@ -0,0 +1,74 @@
+package breeds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedUsecases "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestGetOneByNameAsOf_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler   = usecases.New(&breedUsecases.CreateOne{}, datastore)
+			versionsHandler = usecases.New(&breedUsecases.ListVersions{}, datastore)
+			asOfHandler     = usecases.New(&breedUsecases.GetOneByNameAsOf{}, datastore)
+		)
+
+		_, err := createHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Cat.String(),
+			PetSize: values.Medium.String(),
+		})
+		require.CmpNoError(err)
+
+		versions, err := versionsHandler.Handle(ctx, "test")
+		require.CmpNoError(err)
+		require.Cmp(versions, td.Len(1))
+
+		tests := []struct {
+			name        string
+			input       breedUsecases.GetOneByNameAsOfOpts
+			wantErr     error
+			errContains string
+		}{
+			{
+				name:  "valid case",
+				input: breedUsecases.GetOneByNameAsOfOpts{Name: "test", AsOf: versions[0].ValidFrom()},
+			},
+			{
+				name:    "invalid case -- not created yet",
+				input:   breedUsecases.GetOneByNameAsOfOpts{Name: "test", AsOf: versions[0].ValidFrom().Add(-time.Hour)},
+				wantErr: domainerror.ErrResourceNotFound,
+			},
+			{
+				name:        "invalid case -- invalid name",
+				input:       breedUsecases.GetOneByNameAsOfOpts{Name: "o", AsOf: time.Now()},
+				wantErr:     domainerror.ErrDomainValidation,
+				errContains: values.ErrNameToShort.Error(),
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := asOfHandler.Handle(ctx, tt.input)
+				require.CmpErrorIs(err, tt.wantErr)
+
+				if tt.wantErr != nil {
+					require.Contains(err.Error(), tt.errContains)
+				} else {
+					require.Cmp(res, versions[0].Breed())
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package breeds
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type History struct {
+	usecases.Base
+}
+
+func (h History) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionHistory,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+// Handle
+// List the audit entries of a breed from the oldest to the newest.
+// Deleted breeds keep their history
+func (h History) Handle(ctx context.Context, name string) ([]*audit.Entry, error) {
+	breedName := values.BreedName(name)
+
+	if err := values.Verify(breedName); err != nil {
+		return nil, err
+	}
+	return h.Datastore().Audit().ListByBreedName(ctx, breedName)
+}
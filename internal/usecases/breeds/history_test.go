@@ -0,0 +1,89 @@
+package breeds_test
+
+import (
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/domain/audit"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedUsecases "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestHistory_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler  = usecases.New(&breedUsecases.CreateOne{}, datastore)
+			updateHandler  = usecases.New(&breedUsecases.UpdateOne{}, datastore)
+			deleteHandler  = usecases.NewSimple(&breedUsecases.DeleteOneByName{}, datastore)
+			historyHandler = usecases.New(&breedUsecases.History{}, datastore)
+
+			tests = []struct {
+				name             string
+				input            string
+				expectedUsecases []string
+				wantErr          error
+				errContains      string
+			}{
+				{
+					name:  "valid case",
+					input: "test",
+					expectedUsecases: []string{
+						createHandler.Info().String(),
+						updateHandler.Info().String(),
+						deleteHandler.Info().String(),
+					},
+				},
+				{
+					name:             "valid case -- no history",
+					input:            "not_found",
+					expectedUsecases: []string{},
+				},
+				{
+					name:        "invalid case -- invalid name",
+					input:       "invalid name",
+					wantErr:     domainerror.ErrDomainValidation,
+					errContains: values.ErrNameInvalid.Error(),
+				},
+			}
+		)
+
+		_, err := createHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Cat.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		})
+		require.CmpNoError(err)
+		_, err = updateHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:                "test",
+			Species:             values.Dog.String(),
+			PetSize:             values.Medium.String(),
+			AverageFemaleWeight: common.ToPointer(1),
+			AverageMaleWeight:   common.ToPointer(1),
+		})
+		require.CmpNoError(err)
+		require.CmpNoError(deleteHandler.Handle(ctx, "test"))
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, err := historyHandler.Handle(ctx, tt.input)
+				require.CmpErrorIs(err, tt.wantErr)
+
+				if tt.wantErr != nil {
+					require.Contains(err.Error(), tt.errContains)
+				} else {
+					require.Cmp(common.Map(res, func(val *audit.Entry) string { return val.Usecase() }), tt.expectedUsecases)
+				}
+			})
+		}
+	})
+}
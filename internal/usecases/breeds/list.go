@@ -2,7 +2,10 @@ package breeds
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 	"github.com/japhy-tech/backend-test/internal/usecases"
@@ -17,6 +20,41 @@ type ListOpts struct {
 	Species             *string
 	AverageFemaleWeight *int
 	AverageMaleWeight   *int
+	IncludeDeleted      bool
+	// AsOf
+	// List the breeds as they were at this date instead of the current ones
+	AsOf *time.Time
+	// Fields
+	// Read only these fields of the breeds, all of them when empty (see breeds.Fields)
+	Fields []string
+	// Include
+	// Load these relations along the breeds (see breeds.Relations)
+	Include []string
+	// Names
+	// List these breeds only
+	Names []string
+	// After
+	// Page through the breeds sorted by name, listing those after this name
+	After *string
+	// Limit
+	// List at most this number of breeds, all of them when zero
+	Limit int
+}
+
+var (
+	ErrInvalidLimit = errors.New("limit cannot be negative")
+)
+
+func (l ListOpts) Validate() error {
+	if l.After != nil {
+		if err := values.BreedName(*l.After).Validate(); err != nil {
+			return err
+		}
+	}
+	if l.Limit < 0 {
+		return ErrInvalidLimit
+	}
+	return nil
 }
 
 func (g List) Info() usecases.UseCaseInfo {
@@ -45,6 +83,38 @@ func (g List) Handle(ctx context.Context, params ListOpts) ([]*breeds.Breed, err
 	}
 	opts.AverageMaleWeight = params.AverageMaleWeight
 	opts.AverageFemaleWeight = params.AverageFemaleWeight
+	if params.IncludeDeleted {
+		// The deleted breeds are only listed for the admins, who delete them
+		if err := usecases.AuthorizeInput(ctx, g.Info(), "include_deleted", auth.NewRole(auth.ResourceBreeds, auth.ScopeAdmin)); err != nil {
+			return nil, err
+		}
+	}
+	opts.IncludeDeleted = params.IncludeDeleted
+	opts.NameIn = params.Names
 
+	if params.After != nil {
+		after := values.BreedName(*params.After)
+		opts.NameAfter = &after
+	}
+	opts.Limit = params.Limit
+
+	for _, val := range params.Fields {
+		field, err := breeds.FieldFromString(val)
+		if err != nil {
+			return nil, err
+		}
+		opts.Fields = append(opts.Fields, field)
+	}
+	for _, val := range params.Include {
+		relation, err := breeds.RelationFromString(val)
+		if err != nil {
+			return nil, err
+		}
+		opts.Include = append(opts.Include, relation)
+	}
+
+	if params.AsOf != nil {
+		return g.Datastore().Breeds().AsOf(*params.AsOf).List(ctx, opts)
+	}
 	return g.Datastore().Breeds().List(ctx, opts)
 }
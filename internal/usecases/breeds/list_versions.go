@@ -0,0 +1,29 @@
+package breeds
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type ListVersions struct {
+	usecases.Base
+}
+
+func (l ListVersions) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionListVersions,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+func (l ListVersions) Handle(ctx context.Context, name string) ([]*breeds.Version, error) {
+	breedName := values.BreedName(name)
+
+	if err := values.Verify(breedName); err != nil {
+		return nil, err
+	}
+	return l.Datastore().Breeds().ListVersions(ctx, breedName)
+}
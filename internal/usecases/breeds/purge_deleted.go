@@ -0,0 +1,31 @@
+package breeds
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type PurgeDeleted struct {
+	usecases.Base
+}
+
+func (p PurgeDeleted) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionPurge,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+// Handle
+// Permanently remove the breeds soft deleted for longer than the given retention.
+// It returns the number of purged breeds
+func (p PurgeDeleted) Handle(ctx context.Context, retention time.Duration) (int, error) {
+	if retention < 0 {
+		return 0, domainerror.WrapError(domainerror.ErrDomainValidation, errors.New("retention must be positive"))
+	}
+	return p.Datastore().Breeds().PurgeDeleted(ctx, time.Now().UTC().Add(-retention))
+}
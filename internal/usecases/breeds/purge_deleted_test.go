@@ -0,0 +1,73 @@
+package breeds_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedUsecases "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestPurgeDeleted_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler = usecases.New(&breedUsecases.CreateOne{}, datastore)
+			deleteHandler = usecases.NewSimple(&breedUsecases.DeleteOneByName{}, datastore)
+			purgeHandler  = usecases.New(&breedUsecases.PurgeDeleted{}, datastore)
+
+			tests = []struct {
+				name      string
+				retention time.Duration
+				want      int
+				wantErr   error
+			}{
+				{
+					name:      "valid case -- retention not over",
+					retention: time.Hour,
+					want:      0,
+				},
+				{
+					name:      "valid case -- retention over",
+					retention: 0,
+					want:      1,
+				},
+				{
+					name:      "valid case -- nothing left",
+					retention: 0,
+					want:      0,
+				},
+				{
+					name:      "invalid case -- negative retention",
+					retention: -time.Hour,
+					wantErr:   domainerror.ErrDomainValidation,
+				},
+			}
+		)
+
+		_, err := createHandler.Handle(ctx, breeds.FactoryOpts{
+			Name:    "test",
+			Species: values.Cat.String(),
+			PetSize: values.Medium.String(),
+		})
+		require.CmpNoError(err)
+		require.CmpNoError(deleteHandler.Handle(ctx, "test"))
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				n, err := purgeHandler.Handle(ctx, tt.retention)
+				require.CmpErrorIs(err, tt.wantErr)
+				if tt.wantErr == nil {
+					require.Cmp(n, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package breeds
+
+import (
+	"context"
+	"errors"
+
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type RestoreOneByName struct {
+	usecases.Base
+}
+
+func (r RestoreOneByName) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionRestore,
+		Name:   usecases.BreedUsecase,
+	}
+}
+
+func (r RestoreOneByName) Handle(ctx context.Context, name string) (*breeds.Breed, error) {
+	var (
+		breedRepo = r.Datastore().Breeds()
+		breedName = values.BreedName(name)
+	)
+
+	if err := values.Verify(breedName); err != nil {
+		return nil, err
+	}
+
+	_, err := breedRepo.GetOneByName(ctx, breedName)
+	if err == nil {
+		return nil, domainerror.ErrNothingTodo
+	}
+	if !errors.Is(err, domainerror.ErrResourceNotFound) {
+		return nil, err
+	}
+	return breedRepo.RestoreOneByName(ctx, breedName)
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+)
+
+// Call
+// One execution of a usecase. Input is the input given to its Handle method, and
+// Datastore the one the usecase was built with
+type Call struct {
+	Info      UseCaseInfo
+	Input     any
+	Datastore gateways.IDatastore
+}
+
+// Handler
+// Execute a call and return the usecase output, nil for simple usecases
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Interceptor
+// Wrap the execution of the usecases, to deal with what is not specific to one of them
+type Interceptor func(next Handler) Handler
+
+// Chain
+// Compose interceptors into one, the first being the outermost
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(next Handler) Handler {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i](next)
+		}
+		return next
+	}
+}
+
+// Logging
+// Log the start and the outcome of the calls with the logger of their context, so
+// that they are correlated with the request
+func Logging() Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			log := logger.FromContext(ctx).With("usecase", call.Info.String())
+			log.Infof("Execute usecase %s", call.Info)
+			res, err := next(ctx, call)
+			if err != nil {
+				log.Errorf("Usecase %s [FAILED]: %s", call.Info, err)
+			} else {
+				log.Infof("Usecase %s [SUCCEED]", call.Info)
+			}
+			return res, err
+		}
+	}
+}
+
+// Authorization
+// Reject the calls whose principal does not have the role required by the usecase (see Authorize)
+func Authorization() Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			if err := Authorize(ctx, call.Info); err != nil {
+				return nil, err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// Validation
+// Reject the calls whose input implements values.Validator and is not valid, before
+// the usecase is executed
+func Validation() Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			if input, ok := call.Input.(values.Validator); ok {
+				if err := values.Verify(input); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// Transaction
+// Execute the calls of the usecases changing data in one transaction of their
+// datastore, so that everything they read and write is committed or rolled back
+// together. It is meant to be the innermost interceptor, for the transaction to be
+// held only while the usecase is executed
+func Transaction() Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			if call.Info.ReadOnly() || call.Datastore == nil {
+				return next(ctx, call)
+			}
+			var res any
+			err := call.Datastore.Transaction(ctx, func(ctx context.Context) error {
+				var err error
+				res, err = next(ctx, call)
+				return err
+			})
+			return res, err
+		}
+	}
+}
+
+// PolicyEnforcement
+// Evaluate the policy before executing the calls. Like Authorize, calls
+// without principal are not checked
+func PolicyEnforcement(policy Policy) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			principal, ok := reqcontext.Principal(ctx)
+			if !ok {
+				return next(ctx, call)
+			}
+			err := policy.Evaluate(ctx, PolicyRequest{
+				Principal: principal,
+				Info:      call.Info,
+				Input:     call.Input,
+				At:        time.Now(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// Timeout
+// Cancel the context of the calls lasting longer than d
+func Timeout(d time.Duration) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, call)
+		}
+	}
+}
+
+// Retry
+// Execute again, up to attempts times in total, the calls of the read only
+// usecases failing with an internal error, e.g. a lost database connection.
+// The other calls are never retried, not to apply a change twice
+func Retry(attempts int, delay time.Duration) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call Call) (any, error) {
+			res, err := next(ctx, call)
+			for i := 1; i < attempts && err != nil && call.Info.ReadOnly() && errors.Is(err, domainerror.ErrInternalError); i++ {
+				select {
+				case <-ctx.Done():
+					return res, err
+				case <-time.After(delay):
+				}
+				res, err = next(ctx, call)
+			}
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/auth"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/values"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// countUsecase
+// Usecase failing with err until it has been called failures times
+type countUsecase struct {
+	usecases.Base
+	action   usecases.UsecaseAction
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *countUsecase) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{Action: c.action, Name: usecases.BreedUsecase}
+}
+
+func (c *countUsecase) Handle(ctx context.Context, input int) (int, error) {
+	c.calls++
+	if c.calls <= c.failures {
+		return 0, c.err
+	}
+	return input * 2, ctx.Err()
+}
+
+type simpleUsecase struct {
+	usecases.Base
+}
+
+func (s simpleUsecase) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{Action: usecases.ActionDelete, Name: usecases.BreedUsecase}
+}
+
+func (s simpleUsecase) Handle(_ context.Context, name string) error {
+	if name == "" {
+		return domainerror.ErrDomainValidation
+	}
+	return nil
+}
+
+func TestChain(t *testing.T) {
+	require := td.Require(t)
+	var (
+		trace  []string
+		record = func(name string) usecases.Interceptor {
+			return func(next usecases.Handler) usecases.Handler {
+				return func(ctx context.Context, call usecases.Call) (any, error) {
+					trace = append(trace, name+" "+call.Info.String())
+					return next(ctx, call)
+				}
+			}
+		}
+		opts = []usecases.Option{usecases.WithInterceptors(record("first"), record("second"))}
+	)
+
+	res, err := usecases.New(&countUsecase{action: usecases.ActionList}, nil, opts...).Handle(context.Background(), 21)
+	require.CmpNoError(err)
+	require.Cmp(res, 42)
+	require.CmpErrorIs(usecases.NewSimple(&simpleUsecase{}, nil, opts...).Handle(context.Background(), ""), domainerror.ErrDomainValidation)
+	require.Cmp(trace, []string{"first <LIST BREED>", "second <LIST BREED>", "first <DELETE BREED>", "second <DELETE BREED>"})
+}
+
+func TestAuthorization(t *testing.T) {
+	require := td.Require(t)
+	var (
+		reader = reqcontext.WithPrincipal(context.Background(), auth.NewPrincipal("alice", auth.NewRole(auth.ResourceBreeds, auth.ScopeRead)))
+		admin  = reqcontext.WithPrincipal(context.Background(), auth.NewPrincipal("bob", auth.NewRole(auth.ResourceBreeds, auth.ScopeAdmin)))
+		opts   = usecases.WithInterceptors(usecases.Authorization())
+	)
+
+	require.CmpErrorIs(usecases.NewSimple(&simpleUsecase{}, nil, opts).Handle(reader, "bengal"), domainerror.ErrForbidden)
+	require.CmpNoError(usecases.NewSimple(&simpleUsecase{}, nil, opts).Handle(admin, "bengal"))
+	// Jobs and commands have no principal
+	require.CmpNoError(usecases.NewSimple(&simpleUsecase{}, nil, opts).Handle(context.Background(), "bengal"))
+}
+
+func TestRetry(t *testing.T) {
+	require := td.Require(t)
+	opts := usecases.WithInterceptors(usecases.Retry(3, time.Millisecond))
+
+	list := &countUsecase{action: usecases.ActionList, failures: 2, err: domainerror.ErrInternalError}
+	res, err := usecases.New(list, nil, opts).Handle(context.Background(), 1)
+	require.CmpNoError(err)
+	require.Cmp(res, 2)
+	require.Cmp(list.calls, 3)
+
+	list = &countUsecase{action: usecases.ActionList, failures: 3, err: domainerror.ErrInternalError}
+	_, err = usecases.New(list, nil, opts).Handle(context.Background(), 1)
+	require.CmpErrorIs(err, domainerror.ErrInternalError)
+	require.Cmp(list.calls, 3)
+
+	// Only internal errors are retried
+	list = &countUsecase{action: usecases.ActionList, failures: 1, err: domainerror.ErrResourceNotFound}
+	_, err = usecases.New(list, nil, opts).Handle(context.Background(), 1)
+	require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+	require.Cmp(list.calls, 1)
+
+	// Changes are never retried
+	create := &countUsecase{action: usecases.ActionCreate, failures: 1, err: domainerror.ErrInternalError}
+	_, err = usecases.New(create, nil, opts).Handle(context.Background(), 1)
+	require.CmpErrorIs(err, domainerror.ErrInternalError)
+	require.Cmp(create.calls, 1)
+}
+
+func TestTimeout(t *testing.T) {
+	require := td.Require(t)
+	slow := usecases.WithInterceptors(usecases.Timeout(time.Millisecond), func(next usecases.Handler) usecases.Handler {
+		return func(ctx context.Context, call usecases.Call) (any, error) {
+			<-ctx.Done()
+			return next(ctx, call)
+		}
+	})
+
+	_, err := usecases.New(&countUsecase{action: usecases.ActionList}, nil, slow).Handle(context.Background(), 1)
+	require.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+// validatedInput
+// Input which is valid when positive
+type validatedInput int
+
+func (v validatedInput) Validate() error {
+	if v <= 0 {
+		return errors.New("input must be positive")
+	}
+	return nil
+}
+
+type validatedUsecase struct {
+	usecases.Base
+	calls int
+}
+
+func (v *validatedUsecase) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{Action: usecases.ActionList, Name: usecases.BreedUsecase}
+}
+
+func (v *validatedUsecase) Handle(_ context.Context, input validatedInput) (int, error) {
+	v.calls++
+	return int(input), nil
+}
+
+func TestValidation(t *testing.T) {
+	var (
+		require = td.Require(t)
+		usecase = &validatedUsecase{}
+		handler = usecases.New(usecase, nil, usecases.WithInterceptors(usecases.Validation()))
+	)
+
+	res, err := handler.Handle(context.Background(), 1)
+	require.CmpNoError(err, "valid case")
+	require.Cmp(res, 1)
+
+	_, err = handler.Handle(context.Background(), -1)
+	require.CmpErrorIs(err, domainerror.ErrDomainValidation, "invalid case")
+	require.Cmp(err, td.Contains("input must be positive"))
+	require.Cmp(usecase.calls, 1, "invalid inputs are not handled")
+}
+
+// createThenFail
+// Usecase creating the breed it is given, then failing when it is told to
+type createThenFail struct {
+	usecases.Base
+}
+
+type createThenFailOpts struct {
+	Name string
+	Fail bool
+}
+
+func (c createThenFail) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{Action: usecases.ActionCreate, Name: usecases.BreedUsecase}
+}
+
+func (c createThenFail) Handle(ctx context.Context, params createThenFailOpts) (*breeds.Breed, error) {
+	b, err := breeds.NewFactory(breeds.FactoryOpts{
+		Name:    params.Name,
+		Species: values.Cat.String(),
+		PetSize: values.Small.String(),
+	}).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Datastore().Breeds().CreateOne(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	if params.Fail {
+		return nil, domainerror.ErrInternalError
+	}
+	return res, nil
+}
+
+func TestTransaction(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		handler := usecases.New(&createThenFail{}, datastore, usecases.WithInterceptors(usecases.Transaction()))
+
+		_, err := handler.Handle(ctx, createThenFailOpts{Name: "committed"})
+		require.CmpNoError(err)
+		_, err = datastore.Breeds().GetOneByName(ctx, "committed")
+		require.CmpNoError(err, "valid case -- committed")
+
+		_, err = handler.Handle(ctx, createThenFailOpts{Name: "rolled_back", Fail: true})
+		require.CmpErrorIs(err, domainerror.ErrInternalError)
+		_, err = datastore.Breeds().GetOneByName(ctx, "rolled_back")
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound, "invalid case -- rolled back")
+		history, err := datastore.Audit().ListByBreedName(ctx, "rolled_back")
+		require.CmpNoError(err)
+		require.Empty(history, "invalid case -- audit rolled back")
+	})
+}
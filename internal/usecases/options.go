@@ -0,0 +1,35 @@
+package usecases
+
+type config struct {
+	interceptors []Interceptor
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		interceptors: DefaultInterceptors(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Option
+// Configure a usecase built with New or NewSimple
+type Option func(*config)
+
+// WithInterceptors
+// Execute the usecase through the given interceptors instead of the default ones
+// (see DefaultInterceptors). The first one is the outermost
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *config) {
+		c.interceptors = interceptors
+	}
+}
+
+// DefaultInterceptors
+// Interceptors of the usecases built without WithInterceptors: the executions are
+// logged, authorized, their input validated and their changes made in a transaction
+func DefaultInterceptors() []Interceptor {
+	return []Interceptor{Logging(), Authorization(), Validation(), Transaction()}
+}
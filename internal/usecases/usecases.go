@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	"github.com/japhy-tech/backend-test/internal/gateways"
-	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
 )
 
 type UsecaseAction int
@@ -18,8 +18,18 @@ const (
 	ActionUpdate
 	ActionRetrieve
 	ActionList
+	ActionHistory
+	ActionRestore
+	ActionPurge
+	ActionListVersions
+	ActionDiff
+	ActionListDeliveries
+	ActionReplay
+	ActionRevoke
 
 	BreedUsecase UsecaseName = iota
+	WebhookUsecase
+	APIKeyUsecase
 )
 
 func (u UsecaseAction) String() string {
@@ -34,6 +44,22 @@ func (u UsecaseAction) String() string {
 		return "create"
 	case ActionList:
 		return "list"
+	case ActionHistory:
+		return "history"
+	case ActionRestore:
+		return "restore"
+	case ActionPurge:
+		return "purge"
+	case ActionListVersions:
+		return "list_versions"
+	case ActionDiff:
+		return "diff"
+	case ActionListDeliveries:
+		return "list_deliveries"
+	case ActionReplay:
+		return "replay"
+	case ActionRevoke:
+		return "revoke"
 	default:
 		return ""
 	}
@@ -43,6 +69,10 @@ func (u UsecaseName) String() string {
 	switch u {
 	case BreedUsecase:
 		return "breed"
+	case WebhookUsecase:
+		return "webhook"
+	case APIKeyUsecase:
+		return "api_key"
 	default:
 		return ""
 	}
@@ -53,6 +83,17 @@ type UseCaseInfo struct {
 	Name   UsecaseName
 }
 
+// ReadOnly
+// Tell whether the usecase changes nothing
+func (u UseCaseInfo) ReadOnly() bool {
+	switch u.Action {
+	case ActionRetrieve, ActionList, ActionHistory, ActionListVersions, ActionDiff, ActionListDeliveries:
+		return true
+	default:
+		return false
+	}
+}
+
 func (u UseCaseInfo) String() string {
 	return fmt.Sprintf("<%s %s>", strings.ToUpper(u.Action.String()), strings.ToUpper(u.Name.String()))
 }
@@ -73,21 +114,18 @@ type ISimpleUsecase[Input any] interface {
 	IBase
 }
 
+// Default
+// Execute an IUsecase through the interceptors it was built with
 type Default[Input any, Output any] struct {
 	content IUsecase[Input, Output]
+	handler Handler
 }
 
 func (b Default[Input, Output]) Handle(ctx context.Context, input Input) (Output, error) {
-	l := logger.Logger
-	l.Infof("Execute usecase %s", b.content.Info())
-
-	r, err := b.content.Handle(ctx, input)
-	if err != nil {
-		l.Errorf("Usecase %s [FAILED]: %s", b.content.Info(), err)
-	} else {
-		l.Infof("Usecase %s [SUCCEED]", b.content.Info())
-	}
-	return r, err
+	ctx = reqcontext.WithUsecase(ctx, b.content.Info().String())
+	res, err := b.handler(ctx, Call{Info: b.content.Info(), Input: input, Datastore: b.content.Datastore()})
+	out, _ := res.(Output)
+	return out, err
 }
 
 func (b Default[Input, Output]) Init(datastore gateways.IDatastore) {
@@ -102,22 +140,19 @@ func (b Default[Input, Output]) Info() UseCaseInfo {
 	return b.content.Info()
 }
 
+// SimpleDefault
+// Execute an ISimpleUsecase through the interceptors it was built with
 type SimpleDefault[Input any] struct {
 	content ISimpleUsecase[Input]
+	handler Handler
 }
 
 func (b SimpleDefault[Input]) Handle(ctx context.Context, input Input) error {
-	l := logger.Logger
-	l.Infof("Execute usecase %s", b.content.Info())
-
-	err := b.content.Handle(ctx, input)
-	if err != nil {
-		l.Errorf("Usecase %s [FAILED]: %s", b.content.Info(), err)
-	} else {
-		l.Infof("Usecase %s [SUCCEED]", b.content.Info())
-	}
+	ctx = reqcontext.WithUsecase(ctx, b.content.Info().String())
+	_, err := b.handler(ctx, Call{Info: b.content.Info(), Input: input, Datastore: b.content.Datastore()})
 	return err
 }
+
 func (b SimpleDefault[Input]) Init(datastore gateways.IDatastore) {
 	b.content.Init(datastore)
 }
@@ -130,14 +165,22 @@ func (b SimpleDefault[Input]) Info() UseCaseInfo {
 	return b.content.Info()
 }
 
-func New[Input any, Output any](usecase IUsecase[Input, Output], datastore gateways.IDatastore) IUsecase[Input, Output] {
+func New[Input any, Output any](usecase IUsecase[Input, Output], datastore gateways.IDatastore, opts ...Option) IUsecase[Input, Output] {
+	cfg := newConfig(opts...)
 	r := &Default[Input, Output]{content: usecase}
 	r.Init(datastore)
+	r.handler = Chain(cfg.interceptors...)(func(ctx context.Context, call Call) (any, error) {
+		return usecase.Handle(ctx, call.Input.(Input))
+	})
 	return r
 }
 
-func NewSimple[Input any](usecase ISimpleUsecase[Input], datastore gateways.IDatastore) ISimpleUsecase[Input] {
+func NewSimple[Input any](usecase ISimpleUsecase[Input], datastore gateways.IDatastore, opts ...Option) ISimpleUsecase[Input] {
+	cfg := newConfig(opts...)
 	r := &SimpleDefault[Input]{content: usecase}
 	r.Init(datastore)
+	r.handler = Chain(cfg.interceptors...)(func(ctx context.Context, call Call) (any, error) {
+		return nil, usecase.Handle(ctx, call.Input.(Input))
+	})
 	return r
 }
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type CreateOneOpts struct {
+	URL        string
+	Secret     string
+	EventTypes []string
+	// Enabled defaults to true
+	Enabled *bool
+}
+
+type CreateOne struct {
+	usecases.Base
+}
+
+func (c CreateOne) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionCreate,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+func (c CreateOne) Handle(ctx context.Context, opts CreateOneOpts) (*webhooks.Webhook, error) {
+	w, err := webhooks.NewFactory(webhooks.FactoryOpts{
+		ID:         uuid.NewString(),
+		URL:        opts.URL,
+		Secret:     opts.Secret,
+		EventTypes: opts.EventTypes,
+		Enabled:    opts.Enabled == nil || *opts.Enabled,
+		CreatedAt:  time.Now().UTC(),
+	}).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+	return c.Datastore().Webhooks().CreateOne(ctx, w)
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type DeleteOneByID struct {
+	usecases.Base
+}
+
+func (d DeleteOneByID) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionDelete,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+// Handle
+// Delete a webhook along with its delivery log
+func (d DeleteOneByID) Handle(ctx context.Context, id string) error {
+	return d.Datastore().Webhooks().DeleteOneByID(ctx, id)
+}
@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type GetOneByID struct {
+	usecases.Base
+}
+
+func (g GetOneByID) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionRetrieve,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+func (g GetOneByID) Handle(ctx context.Context, id string) (*webhooks.Webhook, error) {
+	return g.Datastore().Webhooks().GetOneByID(ctx, id)
+}
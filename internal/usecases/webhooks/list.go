@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type List struct {
+	usecases.Base
+}
+
+func (l List) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionList,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+func (l List) Handle(ctx context.Context, _ struct{}) ([]*webhooks.Webhook, error) {
+	return l.Datastore().Webhooks().List(ctx)
+}
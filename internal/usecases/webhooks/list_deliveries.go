@@ -0,0 +1,25 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type ListDeliveries struct {
+	usecases.Base
+}
+
+func (l ListDeliveries) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionListDeliveries,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+// Handle
+// Delivery log of a webhook, from the newest to the oldest
+func (l ListDeliveries) Handle(ctx context.Context, webhookID string) ([]*webhooks.Delivery, error) {
+	return l.Datastore().Webhooks().ListDeliveries(ctx, webhookID)
+}
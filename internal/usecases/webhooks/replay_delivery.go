@@ -0,0 +1,30 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type ReplayDeliveryOpts struct {
+	WebhookID  string
+	DeliveryID int64
+}
+
+type ReplayDelivery struct {
+	usecases.Base
+}
+
+func (r ReplayDelivery) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionReplay,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+// Handle
+// Send a succeeded or failed delivery again. Nothing is done for a pending one
+func (r ReplayDelivery) Handle(ctx context.Context, opts ReplayDeliveryOpts) (*webhooks.Delivery, error) {
+	return r.Datastore().Webhooks().Replay(ctx, opts.WebhookID, opts.DeliveryID)
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+)
+
+type UpdateOneOpts struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	// Secret is kept when empty
+	Secret  string
+	Enabled bool
+}
+
+type UpdateOne struct {
+	usecases.Base
+}
+
+func (u UpdateOne) Info() usecases.UseCaseInfo {
+	return usecases.UseCaseInfo{
+		Action: usecases.ActionUpdate,
+		Name:   usecases.WebhookUsecase,
+	}
+}
+
+// Handle
+// Replace a webhook subscription. Enabling a disabled webhook resets its failures
+func (u UpdateOne) Handle(ctx context.Context, opts UpdateOneOpts) (*webhooks.Webhook, error) {
+	repo := u.Datastore().Webhooks()
+
+	existing, err := repo.GetOneByID(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryOpts := webhooks.FactoryOpts{
+		ID:                  existing.ID(),
+		URL:                 opts.URL,
+		Secret:              opts.Secret,
+		EventTypes:          opts.EventTypes,
+		Enabled:             opts.Enabled,
+		ConsecutiveFailures: existing.ConsecutiveFailures(),
+		DisabledAt:          existing.DisabledAt(),
+		CreatedAt:           existing.CreatedAt(),
+	}
+	if factoryOpts.Secret == "" {
+		factoryOpts.Secret = existing.Secret().String()
+	}
+	if opts.Enabled && !existing.Enabled() {
+		factoryOpts.ConsecutiveFailures = 0
+		factoryOpts.DisabledAt = nil
+	}
+
+	w, err := webhooks.NewFactory(factoryOpts).Instantiate()
+	if err != nil {
+		return nil, err
+	}
+	return repo.UpdateOne(ctx, w)
+}
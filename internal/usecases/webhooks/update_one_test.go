@@ -0,0 +1,67 @@
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/domain/breeds"
+	"github.com/japhy-tech/backend-test/internal/domain/webhooks"
+	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/testutils"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	webhookUsecases "github.com/japhy-tech/backend-test/internal/usecases/webhooks"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestUpdateOne_Handle(t *testing.T) {
+	testutils.TestDecorator(t, func(ctx context.Context, datastore gateways.IDatastore, require *td.T, _ *charmLog.Logger) {
+		var (
+			createHandler = usecases.New(&webhookUsecases.CreateOne{}, datastore)
+			updateHandler = usecases.New(&webhookUsecases.UpdateOne{}, datastore)
+		)
+
+		created, err := createHandler.Handle(ctx, webhookUsecases.CreateOneOpts{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{breeds.EventBreedCreated},
+		})
+		require.CmpNoError(err)
+		require.True(created.Enabled())
+
+		// Disable the webhook as the dispatcher would
+		_, err = datastore.Webhooks().Enqueue(ctx, 1, breeds.EventBreedCreated, []byte(`{}`))
+		require.CmpNoError(err)
+		deliveries, err := datastore.Webhooks().ListDeliveries(ctx, created.ID())
+		require.CmpNoError(err)
+		disabled, err := datastore.Webhooks().RecordFailure(ctx, deliveries[0].ID(), webhooks.DeliveryFailure{Error: "down"}, 1)
+		require.CmpNoError(err)
+		require.True(disabled)
+
+		res, err := updateHandler.Handle(ctx, webhookUsecases.UpdateOneOpts{
+			ID:         created.ID(),
+			URL:        "https://example.com/hooks",
+			EventTypes: []string{breeds.EventBreedCreated},
+			Enabled:    true,
+		})
+		require.CmpNoError(err)
+		require.True(res.Enabled())
+		require.Cmp(res.ConsecutiveFailures(), 0)
+		require.Nil(res.DisabledAt())
+		require.Cmp(res.Secret().String(), "0123456789abcdef")
+
+		_, err = updateHandler.Handle(ctx, webhookUsecases.UpdateOneOpts{
+			ID:         "unknown",
+			URL:        "https://example.com/hooks",
+			EventTypes: []string{breeds.EventBreedCreated},
+		})
+		require.CmpErrorIs(err, domainerror.ErrResourceNotFound)
+
+		_, err = updateHandler.Handle(ctx, webhookUsecases.UpdateOneOpts{
+			ID:  created.ID(),
+			URL: "https://example.com/hooks",
+		})
+		require.CmpErrorIs(err, domainerror.ErrDomainValidation)
+	})
+}
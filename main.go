@@ -1,8 +1,11 @@
 //go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ./api/swagger.yml
+//go:generate buf generate
+//go:generate go run github.com/99designs/gqlgen generate
 
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/csv"
 	"errors"
@@ -10,76 +13,409 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
-	charmLog "github.com/charmbracelet/log"
 	"github.com/gorilla/mux"
+	apispec "github.com/japhy-tech/backend-test/api"
+	"github.com/japhy-tech/backend-test/database_actions"
 	"github.com/japhy-tech/backend-test/internal/api"
 	"github.com/japhy-tech/backend-test/internal/common"
+	"github.com/japhy-tech/backend-test/internal/compression"
+	"github.com/japhy-tech/backend-test/internal/dispatcher"
+	"github.com/japhy-tech/backend-test/internal/docs"
 	"github.com/japhy-tech/backend-test/internal/domain/breeds"
 	"github.com/japhy-tech/backend-test/internal/domain/values"
 	"github.com/japhy-tech/backend-test/internal/domainerror"
+	"github.com/japhy-tech/backend-test/internal/eventbus"
 	"github.com/japhy-tech/backend-test/internal/gateways"
+	"github.com/japhy-tech/backend-test/internal/gateways/cache"
 	"github.com/japhy-tech/backend-test/internal/gateways/mysql"
+	"github.com/japhy-tech/backend-test/internal/graphqlapi"
+	"github.com/japhy-tech/backend-test/internal/grpcapi"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"github.com/japhy-tech/backend-test/internal/jobs"
+	"github.com/japhy-tech/backend-test/internal/jwtauth"
 	"github.com/japhy-tech/backend-test/internal/logger"
+	"github.com/japhy-tech/backend-test/internal/metrics"
+	"github.com/japhy-tech/backend-test/internal/policy"
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/japhy-tech/backend-test/internal/relay"
+	"github.com/japhy-tech/backend-test/internal/reqcontext"
+	"github.com/japhy-tech/backend-test/internal/sse"
+	"github.com/japhy-tech/backend-test/internal/tracing"
+	"github.com/japhy-tech/backend-test/internal/usecases"
+	breedsUsecase "github.com/japhy-tech/backend-test/internal/usecases/breeds"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 const (
 	MysqlDSN = "root:root@(mysql-test:3306)/core?parseTime=true"
 	ApiPort  = "5000"
+	GrpcPort = "5001"
+
+	// CSVSyncActor is recorded in the breeds audit for rows inserted from the csv file
+	CSVSyncActor = "csv_sync"
+	// PurgeActor is recorded in the breeds audit for breeds removed by the purge job
+	PurgeActor = "purge_job"
+
+	// DeletedBreedsRetention is how long a soft deleted breed can be restored before being purged
+	DeletedBreedsRetention = 30 * 24 * time.Hour
+	PurgeInterval          = time.Hour
+
+	// OutboxSinkEnv selects where the breed events are relayed (see relay.SinkFromURL), stdout by default
+	OutboxSinkEnv       = "OUTBOX_SINK"
+	OutboxRelayInterval = time.Second
+	WebhooksInterval    = time.Second
+	EventsPollInterval  = time.Second
+
+	// JWKSEnv enables the bearer tokens authentication with the keys of a JWKS file
+	// path or url. JWTIssuerEnv, JWTAudienceEnv and JWTRolesClaimEnv configure the
+	// checks of the tokens
+	JWKSEnv          = "JWT_JWKS"
+	JWTIssuerEnv     = "JWT_ISSUER"
+	JWTAudienceEnv   = "JWT_AUDIENCE"
+	JWTRolesClaimEnv = "JWT_ROLES_CLAIM"
+
+	// PolicyFileEnv is the path of the policy file checked before the usecases are
+	// executed (see policy.Parse), no policy is checked when unset
+	PolicyFileEnv = "POLICY_FILE"
+
+	// UsecaseTimeout bounds the execution of the usecases of the api, the read only
+	// ones being attempted UsecaseAttempts times when the datastore fails
+	UsecaseTimeout    = 10 * time.Second
+	UsecaseAttempts   = 3
+	UsecaseRetryDelay = 100 * time.Millisecond
+
+	// RateLimitReadEnv and RateLimitWriteEnv are the budgets of each client for the
+	// read and the write operations, formatted as <limit>/<period>
+	RateLimitReadEnv   = "RATE_LIMIT_READ"
+	RateLimitWriteEnv  = "RATE_LIMIT_WRITE"
+	DefaultReadBudget  = "300/1m"
+	DefaultWriteBudget = "60/1m"
+
+	// LogFormatEnv is the output of the logs: text, json or logfmt (see logger.New),
+	// LogLevelEnv is the minimum level logged
+	LogFormatEnv = "LOG_FORMAT"
+	LogLevelEnv  = "LOG_LEVEL"
+
+	// TracesExporterEnv is the url the traces are exported to (see tracing.ExporterFromURL),
+	// tracing is disabled when unset
+	TracesExporterEnv = "TRACES_EXPORTER"
+
+	// BreedsCacheTTL is how long the breeds read are served from the cache, which holds
+	// at most BreedsCacheSize entries
+	BreedsCacheTTL  = time.Minute
+	BreedsCacheSize = 1000
+
+	// ListBreedsCacheControlEnv and GetBreedCacheControlEnv are the Cache-Control sent
+	// with the breeds lists and the breeds read by name, api.DefaultCacheControl when unset
+	ListBreedsCacheControlEnv = "CACHE_CONTROL_LIST_BREEDS"
+	GetBreedCacheControlEnv   = "CACHE_CONTROL_GET_BREED"
+
+	// IdempotencyKeysTTLEnv is how long the responses of the requests sent with an
+	// Idempotency-Key are replayed, api.DefaultIdempotencyKeysTTL when unset
+	IdempotencyKeysTTLEnv    = "IDEMPOTENCY_KEYS_TTL"
+	IdempotencyPurgeInterval = time.Hour
+
+	// CompressionMinSizeEnv is the size in bytes from which the responses are compressed,
+	// compression.DefaultMinSize when unset
+	CompressionMinSizeEnv = "COMPRESSION_MIN_SIZE"
+
+	// GraphQLComplexityLimitEnv is the complexity from which the GraphQL operations are
+	// refused, graphqlapi.DefaultComplexityLimit when unset
+	GraphQLComplexityLimitEnv = "GRAPHQL_COMPLEXITY_LIMIT"
+
+	// ValidateResponsesEnv enables the validation of the responses against the specification,
+	// logging the violations, when set to true
+	ValidateResponsesEnv = "OPENAPI_VALIDATE_RESPONSES"
+
+	// ShutdownDrainDelay is how long the requests are still served once the service is
+	// reported as not ready, for the load balancers to stop sending them. ShutdownTimeout
+	// is how long the open requests are waited for on shutdown
+	ShutdownDrainDelay = 5 * time.Second
+	ShutdownTimeout    = 10 * time.Second
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Init the logger, every other logger deriving from it
+	log, err := logger.New(os.Stderr, os.Getenv(LogFormatEnv), os.Getenv(LogLevelEnv))
+	if err != nil {
+		logger.Logger.Fatalf("cannot init logger: %s", err)
+	}
+	logger.Logger = log
+
+	// Init tracing, before the datastore whose queries are traced
+	tracerProvider, shutdownTracing, err := tracing.Setup(ctx, os.Getenv(TracesExporterEnv))
+	if err != nil {
+		logger.Logger.Fatalf("cannot init tracing: %s", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Logger.Errorf("cannot flush traces: %s", err)
+		}
+	}()
+
 	// Init datastore
-	datastore := mysql.New(MysqlDSN, logger.Logger)
+	datastore := mysql.New(MysqlDSN, logger.Logger, mysql.WithTracerProvider(tracerProvider))
 	defer datastore.Close()
 
-	/// Sync data from csv with the datastore
-	breeds, err := breedsFromCSV("./breeds.csv")
+	// Management commands, e.g. `go run . apikeys list`
+	if len(os.Args) > 1 {
+		if err := runCommand(ctx, datastore, os.Args[1:], os.Stdout); err != nil {
+			logger.Logger.Fatal(err.Error())
+		}
+		return
+	}
+
+	// Metrics of the service, the queries of the api and the jobs being observed
+	// behind the cache of the breeds
+	serviceMetrics := metrics.New()
+	observed := serviceMetrics.Datastore(datastore)
+	store := cache.NewDatastore(observed, cache.NewMemoryStore(BreedsCacheSize), cache.WithTTL(BreedsCacheTTL))
+	if err := serviceMetrics.Register(
+		collectors.NewDBStatsCollector(datastore.DB(), "core"),
+		metrics.NewSpeciesCollector(observed.Breeds()),
+	); err != nil {
+		logger.Logger.Fatalf("cannot register metrics: %s", err)
+	}
+
+	/// Sync data from csv through the cache, the service being ready once it is done
+	csvSynced := &health.Flag{}
+	go func() {
+		breeds, err := breedsFromCSV("./breeds.csv")
+		if err != nil {
+			logger.Logger.Fatalf("cannot convert csv data: %s", err)
+		}
+		if err := syncDatastore(breeds, store); err != nil {
+			logger.Logger.Fatalf("cannot insert csv data in datastore: %s", err)
+		}
+		csvSynced.Done()
+	}()
+
+	// Init the domain events bus, fed with the outbox messages by the relay below
+	bus := eventbus.New(logger.Logger)
+	bus.Subscribe("log", eventbus.LogSubscriber(logger.Logger))
+
+	// Purge soft deleted breeds once their retention is over
+	go jobs.Every(reqcontext.WithActor(ctx, PurgeActor), logger.Logger, "purge deleted breeds", PurgeInterval, func(ctx context.Context) error {
+		_, err := usecases.New(&breedsUsecase.PurgeDeleted{}, store).Handle(ctx, DeletedBreedsRetention)
+		return err
+	})
+
+	// Purge the expired idempotency keys
+	idempotencyTTL := api.DefaultIdempotencyKeysTTL
+	if raw := os.Getenv(IdempotencyKeysTTLEnv); raw != "" {
+		idempotencyTTL, err = time.ParseDuration(raw)
+		if err != nil {
+			logger.Logger.Fatalf("invalid %s: %s", IdempotencyKeysTTLEnv, err)
+		}
+	}
+	go jobs.Every(ctx, logger.Logger, "purge idempotency keys", IdempotencyPurgeInterval, func(ctx context.Context) error {
+		_, err := datastore.IdempotencyKeys().PurgeExpired(ctx, time.Now())
+		return err
+	})
+
+	// Relay the breed events written in the outbox
+	sink, closeSink, err := relay.SinkFromURL(os.Getenv(OutboxSinkEnv))
+	if err != nil {
+		logger.Logger.Fatalf("cannot init outbox sink: %s", err)
+	}
+	defer closeSink.Close()
+	outboxRelay := relay.New(datastore.Outbox(), relay.NewFanOutSink(sink, dispatcher.NewEnqueuer(datastore.Webhooks()), relay.NewPublisherSink(bus)), logger.Logger)
+	go jobs.Every(ctx, logger.Logger, "relay outbox", OutboxRelayInterval, func(ctx context.Context) error {
+		_, err := outboxRelay.RelayPending(ctx)
+		return err
+	})
+
+	// Send the breed events to the webhooks
+	webhooksDispatcher := dispatcher.New(datastore.Webhooks(), logger.Logger)
+	go jobs.Every(ctx, logger.Logger, "dispatch webhooks", WebhooksInterval, func(ctx context.Context) error {
+		_, err := webhooksDispatcher.DispatchPending(ctx)
+		return err
+	})
+
+	// Stream the breed events written in the outbox
+	broker := sse.NewBroker(datastore.Outbox(), logger.Logger)
+	if err := broker.Poll(ctx); err != nil {
+		logger.Logger.Fatalf("cannot init breed events stream: %s", err)
+	}
+	go jobs.Every(ctx, logger.Logger, "poll breed events", EventsPollInterval, broker.Poll)
+
+	// Authenticate the api requests with api keys, and bearer tokens when configured
+	authenticators := []api.Authenticator{api.APIKeyAuthenticator(datastore.APIKeys())}
+	if source := os.Getenv(JWKSEnv); source != "" {
+		keys, err := jwtauth.LoadKeySet(ctx, source)
+		if err != nil {
+			logger.Logger.Fatalf("cannot load jwks: %s", err)
+		}
+		verifierOpts := []jwtauth.Option{jwtauth.WithIssuer(os.Getenv(JWTIssuerEnv)), jwtauth.WithAudience(os.Getenv(JWTAudienceEnv))}
+		if claim := os.Getenv(JWTRolesClaimEnv); claim != "" {
+			verifierOpts = append(verifierOpts, jwtauth.WithRolesClaim(claim))
+		}
+		authenticators = append(authenticators, api.BearerAuthenticator(jwtauth.NewVerifier(keys, verifierOpts...)))
+	}
+
+	// Interceptors executing the usecases of the api, the policy file being checked
+	// once the roles and the input are
+	interceptors := []usecases.Interceptor{tracing.Interceptor(tracerProvider), usecases.Logging(), serviceMetrics.Interceptor(), usecases.Authorization(), usecases.Validation()}
+	if path := os.Getenv(PolicyFileEnv); path != "" {
+		engine, err := policy.Load(path)
+		if err != nil {
+			logger.Logger.Fatalf("cannot load policy: %s", err)
+		}
+		interceptors = append(interceptors, usecases.PolicyEnforcement(engine))
+	}
+	interceptors = append(interceptors, usecases.Timeout(UsecaseTimeout), usecases.Retry(UsecaseAttempts, UsecaseRetryDelay), usecases.Transaction())
+
+	// Limit the requests of each client
+	readBudget, err := ratelimit.ParseBudget(cmp.Or(os.Getenv(RateLimitReadEnv), DefaultReadBudget))
+	if err != nil {
+		logger.Logger.Fatalf("invalid %s: %s", RateLimitReadEnv, err)
+	}
+	writeBudget, err := ratelimit.ParseBudget(cmp.Or(os.Getenv(RateLimitWriteEnv), DefaultWriteBudget))
+	if err != nil {
+		logger.Logger.Fatalf("invalid %s: %s", RateLimitWriteEnv, err)
+	}
+	limiter := ratelimit.New(readBudget, writeBudget)
+
+	compressionMinSize := compression.DefaultMinSize
+	if raw := os.Getenv(CompressionMinSizeEnv); raw != "" {
+		compressionMinSize, err = strconv.Atoi(raw)
+		if err != nil {
+			logger.Logger.Fatalf("invalid %s: %s", CompressionMinSizeEnv, err)
+		}
+	}
+
+	graphqlComplexityLimit := graphqlapi.DefaultComplexityLimit
+	if raw := os.Getenv(GraphQLComplexityLimitEnv); raw != "" {
+		graphqlComplexityLimit, err = strconv.Atoi(raw)
+		if err != nil {
+			logger.Logger.Fatalf("invalid %s: %s", GraphQLComplexityLimitEnv, err)
+		}
+	}
+
+	spec, err := api.GetSwagger()
 	if err != nil {
-		logger.Logger.Fatalf("cannot convert csv data: %s", err)
+		logger.Logger.Fatalf("cannot load the specification: %s", err)
 	}
-	if err := syncDatastore(breeds, datastore); err != nil {
-		logger.Logger.Fatalf("cannot insert csv data in datastore: %s", err)
+	var validationOpts []api.ValidationOption
+	if validate, _ := strconv.ParseBool(os.Getenv(ValidateResponsesEnv)); validate {
+		validationOpts = append(validationOpts, api.WithResponseValidation(api.LogResponseViolations))
+	}
+	validation, err := api.ValidationMiddleware(spec, "/v1", validationOpts...)
+	if err != nil {
+		logger.Logger.Fatalf("cannot validate the requests: %s", err)
 	}
 
 	// Init Api handler
 	r := mux.NewRouter()
-	r.Use(loggingMiddleware(logger.Logger))
-	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	}).Methods(http.MethodGet)
-	r.PathPrefix("/v1/docs/").Handler(http.StripPrefix("/v1/docs/", http.FileServer(http.Dir("./api"))))
+	r.Use(tracing.Middleware(tracerProvider))
+	r.Use(api.RequestContextMiddleware)
+	r.Use(api.AccessLogMiddleware)
+	r.Use(serviceMetrics.Middleware)
+	r.Use(compression.New(compression.WithMinSize(compressionMinSize)).Middleware)
+	checker := health.New(
+		health.WithCheck("datastore", datastore.Ping),
+		health.WithCheck("migrations", database_actions.CheckVersion),
+		health.WithCheck("csv_sync", csvSynced.Check),
+	)
+	r.HandleFunc("/livez", checker.Livez).Methods(http.MethodGet)
+	r.HandleFunc("/health", checker.Livez).Methods(http.MethodGet)
+	r.HandleFunc("/readyz", checker.Readyz).Methods(http.MethodGet)
+	r.Handle("/metrics", serviceMetrics.Handler()).Methods(http.MethodGet)
 
-	h := api.HandlerFromMuxWithBaseURL(api.New(logger.Logger, datastore), r, "/v1")
+	// The documentation is embedded, to be served wherever the binary runs
+	docsHandler, err := docs.New(apispec.Swagger, apispec.Docs, "/v1")
+	if err != nil {
+		logger.Logger.Fatalf("cannot serve the documentation: %s", err)
+	}
+	r.HandleFunc("/v1/openapi.json", docsHandler.JSON).Methods(http.MethodGet)
+	r.HandleFunc("/v1/docs/swagger.yml", docsHandler.YAML).Methods(http.MethodGet)
+	r.Handle("/v1/docs", http.RedirectHandler("/v1/docs/", http.StatusMovedPermanently)).Methods(http.MethodGet)
+	r.PathPrefix("/v1/docs/").Handler(http.StripPrefix("/v1/docs/", docsHandler.UI())).Methods(http.MethodGet)
+
+	// The last middleware is the outermost: the requests are authenticated before being limited,
+	// for their principal to be limited rather than the credentials they claim, limited before
+	// being validated, and validated before their idempotency key is checked
+	h := api.HandlerWithOptions(api.New(logger.Logger, store,
+		api.WithUsecaseOptions(usecases.WithInterceptors(interceptors...)),
+		api.WithBroker(broker),
+		api.WithCacheControl("GET /breeds", os.Getenv(ListBreedsCacheControlEnv)),
+		api.WithCacheControl("GET /breeds/name/{breed_name}", os.Getenv(GetBreedCacheControlEnv)),
+		api.WithGraphQL(graphqlapi.New(logger.Logger, store,
+			graphqlapi.WithUsecaseOptions(usecases.WithInterceptors(interceptors...)),
+			graphqlapi.WithComplexityLimit(graphqlComplexityLimit),
+		)),
+	), api.GorillaServerOptions{
+		BaseURL:    "/v1",
+		BaseRouter: r,
+		Middlewares: []api.MiddlewareFunc{
+			api.IdempotencyMiddleware(datastore.IdempotencyKeys(), idempotencyTTL),
+			validation,
+			api.RateLimitMiddleware(limiter, "/v1"),
+			api.AuthMiddleware(authenticators...),
+		},
+	})
 
 	server := &http.Server{
 		Handler: h,
 		Addr:    net.JoinHostPort("", ApiPort),
 	}
+	// Streams never end by themselves
+	server.RegisterOnShutdown(broker.Close)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Logger.Fatal(err.Error())
+		}
+	}()
 
-	if err := server.ListenAndServe(); err != nil {
-		logger.Logger.Fatal(err.Error())
+	// Serve the same usecases over gRPC on their own port
+	grpcServer := grpcapi.NewGRPCServer(grpcapi.New(logger.Logger, store,
+		grpcapi.WithUsecaseOptions(usecases.WithInterceptors(interceptors...)),
+		grpcapi.WithBroker(broker),
+	), checker, authenticators)
+	grpcListener, err := net.Listen("tcp", net.JoinHostPort("", GrpcPort))
+	if err != nil {
+		logger.Logger.Fatalf("cannot listen on port %s: %s", GrpcPort, err)
 	}
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Logger.Fatal(err.Error())
+		}
+	}()
 
 	// =============================== Starting Msg ===============================
-	logger.Logger.Infof("Service started and listen on port %s", ApiPort)
-}
+	logger.Logger.Infof("Service started and listen on port %s, gRPC on port %s", ApiPort, GrpcPort)
 
-func loggingMiddleware(logger *charmLog.Logger) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			next.ServeHTTP(w, r)
-			logger.Infof(
-				"%s | %s | %s | %s | %s",
-				r.Method,
-				r.URL.Path,
-				time.Now().Format(time.RFC822),
-				r.RemoteAddr,
-				r.UserAgent(),
-			)
-		})
+	<-ctx.Done()
+	logger.Logger.Info("Shutting down")
+	checker.Shutdown()
+	time.Sleep(ShutdownDrainDelay)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Logger.Errorf("cannot shut down gracefully: %s", err)
+	}
+	// The watch streams are ended by the broker, closed along the REST server
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Logger.Errorf("cannot shut down gRPC gracefully: %s", shutdownCtx.Err())
+		grpcServer.Stop()
 	}
 }
 
@@ -135,7 +471,7 @@ func breedsFromCSV(filepath string) ([]*breeds.Breed, error) {
 
 func syncDatastore(arr []*breeds.Breed, datastore gateways.IDatastore) error {
 	var (
-		ctx          = context.Background()
+		ctx          = reqcontext.WithActor(context.Background(), CSVSyncActor)
 		breedNameArr = common.Map(arr, func(val *breeds.Breed) string {
 			return val.Name().String()
 		})
@@ -145,7 +481,8 @@ func syncDatastore(arr []*breeds.Breed, datastore gateways.IDatastore) error {
 	)
 
 	logger.Logger.Info("Stating datastore synchronization")
-	found, err := datastore.Breeds().List(ctx, breeds.ListOpts{NameIn: breedNameArr})
+	// Deleted breeds are included so that they are not inserted back
+	found, err := datastore.Breeds().List(ctx, breeds.ListOpts{NameIn: breedNameArr, IncludeDeleted: true})
 	if err != nil {
 		return domainerror.WrapError(ErrFail, err)
 	}